@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/frecency"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// importZoxideAction adds the directories of a zoxide database to the
+// frecency database of superfile, used when zoxide is not available
+func importZoxideAction(_ context.Context, c *cli.Command) error {
+	dbPath := c.Args().First()
+	if dbPath == "" {
+		dbPath = frecency.ZoxideDBPath()
+	}
+	entries, err := frecency.ReadZoxideDB(dbPath)
+	if err != nil {
+		return fmt.Errorf("cannot read the zoxide database: %w", err)
+	}
+	if err := utils.CreateDirectories(variable.SuperFileDataDir); err != nil {
+		return fmt.Errorf("error creating data directory: %w", err)
+	}
+	added, err := frecency.New(variable.FrecencyFile).Import(entries)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d directories from %s, %d of them new\n", len(entries), dbPath, added)
+	return nil
+}
@@ -79,6 +79,12 @@ func Run(content embed.FS) {
 					},
 				},
 			},
+			{
+				Name:      "import-zoxide",
+				Usage:     "Import the directories of a zoxide database, to jump to them without zoxide",
+				ArgsUsage: "[DATABASE]",
+				Action:    importZoxideAction,
+			},
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -105,6 +111,12 @@ func Run(content embed.FS) {
 				Usage:   "Print the last dir to stdout on exit (to use for cd)",
 				Value:   false,
 			},
+			&cli.BoolFlag{
+				Name:    "restore-session",
+				Aliases: []string{"rs"},
+				Usage:   "Restore the panels of the last session",
+				Value:   false,
+			},
 			&cli.StringFlag{
 				Name:    "config-file",
 				Aliases: []string{"c"},
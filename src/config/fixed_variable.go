@@ -46,10 +46,16 @@ var (
 	PinnedFile       = filepath.Join(SuperFileDataDir, "pinned.json")
 	ToggleDotFile    = filepath.Join(SuperFileDataDir, "toggleDotFile")
 	ToggleFooter     = filepath.Join(SuperFileDataDir, "toggleFooter")
+	SortFile         = filepath.Join(SuperFileDataDir, "sort.json")
+	SessionsDir      = filepath.Join(SuperFileDataDir, "sessions")
+	MarksFile        = filepath.Join(SuperFileDataDir, "marks.json")
+	FrecencyFile     = filepath.Join(SuperFileDataDir, "frecency.json")
 
 	// StateDir files
 	LogFile     = filepath.Join(SuperFileStateDir, "superfile.log")
 	LastDirFile = filepath.Join(SuperFileStateDir, "lastdir")
+	JournalFile = filepath.Join(SuperFileStateDir, "journal.json")
+	SessionFile = filepath.Join(SuperFileStateDir, "session.json")
 
 	// Trash Directories
 	DarwinTrashDirectory = filepath.Join(HomeDir, ".Trash")
@@ -70,10 +76,11 @@ var (
 	ChooserFile = ""
 
 	// Other state variables
-	FixHotkeys    = false
-	FixConfigFile = false
-	LastDir       = ""
-	PrintLastDir  = false
+	FixHotkeys     = false
+	FixConfigFile  = false
+	LastDir        = ""
+	PrintLastDir   = false
+	RestoreSession = false
 )
 
 // Still we are preventing other packages to directly modify them via reassign linter
@@ -113,4 +120,5 @@ func UpdateVarFromCliArgs(c *cli.Command) {
 	FixHotkeys = c.Bool("fix-hotkeys")
 	FixConfigFile = c.Bool("fix-config-file")
 	PrintLastDir = c.Bool("print-last-dir")
+	RestoreSession = c.Bool("restore-session")
 }
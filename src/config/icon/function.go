@@ -14,7 +14,8 @@ const noIconColor = "NONE"
 //
 // The function configures various icons for:
 //   - System directories (Home, Download, Documents, etc.)
-//   - File operations (Compress, Extract, Copy, Cut, Delete)
+//   - File operations (Compress, Extract, Copy, Cut, Delete, Undo, Redo, Restore, Rename,
+//     Symlink, Hardlink, Permissions)
 //   - UI elements (Cursor, Browser, Select, etc.)
 //   - Status indicators (Error, Warn, Done, InOperation)
 //   - Navigation and sorting (Directory, Search, SortAsc, SortDesc)
@@ -44,11 +45,21 @@ func InitIcon(nerdfont bool, directoryIconColor string) {
 		Copy = ""
 		Cut = ""
 		Delete = ""
+		Undo = ""
+		Redo = ""
+		Restore = ""
+		Rename = ""
+		Symlink = ""
+		Hardlink = ""
+		Permissions = ""
 
 		// other
 		Cursor = ">"
 		Browser = "B"
 		Select = "S"
+		Tree = "T"
+		TreeExpanded = "-"
+		TreeCollapsed = "+"
 		Error = ""
 		Warn = ""
 		Done = ""
@@ -60,6 +71,10 @@ func InitIcon(nerdfont bool, directoryIconColor string) {
 		Terminal = ""
 		Pinned = ""
 		Disk = ""
+		GitBranch = ""
+		GitAhead = "+"
+		GitBehind = "-"
+		Compare = ""
 	}
 
 	if directoryIconColor == "" {
@@ -28,11 +28,21 @@ var (
 	Copy         = "\U000f018f" // Printable Rune : "󰆏"
 	Cut          = "\U000f0190" // Printable Rune : "󰆐"
 	Delete       = "\U000f01b4" // Printable Rune : "󰆴"
+	Undo         = "\U000f054c" // Printable Rune : "󰕌"
+	Redo         = "\U000f044e" // Printable Rune : "󰑎"
+	Restore      = "\U000f099b" // Printable Rune : "󰦛"
+	Rename       = "\U000f0455" // Printable Rune : "󰑕"
+	Symlink      = "\uf481"     // Printable Rune : ""
+	Hardlink     = "\U000f0337" // Printable Rune : "󰌷"
+	Permissions  = "\U000f033e" // Printable Rune : "󰌾"
 
 	// other
 	Cursor          = "\uf054"     // Printable Rune : ""
 	Browser         = "\U000f0208" // Printable Rune : "󰈈"
 	Select          = "\U000f01bd" // Printable Rune : "󰆽"
+	Tree            = "\U000f0645" // Printable Rune : "󰙅"
+	TreeExpanded    = "\uf47c"     // Printable Rune : ""
+	TreeCollapsed   = "\uf460"     // Printable Rune : ""
 	CheckboxEmpty   = "\U000f0131" // Printable Rune : "󰄱"
 	CheckboxChecked = "\U000f0856" // Printable Rune : "󰡖"
 	Error           = "\uf530"     // Printable Rune : ""
@@ -46,6 +56,10 @@ var (
 	Terminal        = "\ue795"     // Printable Rune : ""
 	Pinned          = "\U000f0403" // Printable Rune : "󰐃"
 	Disk            = "\U000f11f0" // Printable Rune : "󱇰"
+	GitBranch       = "\ue725"     // Printable Rune : ""
+	GitAhead        = "\uf062"     // Printable Rune : ""
+	GitBehind       = "\uf063"     // Printable Rune : ""
+	Compare         = "\ueae1"     // Printable Rune : ""
 
 )
 
@@ -0,0 +1,260 @@
+// Package archivefs exposes the entries of an archive as a read-only fs.FS,
+// so that archives can be browsed like directories without extracting them.
+//
+// An entry of an archive is addressed by a "virtual path", the path of the
+// archive followed by the path of the entry inside it, like
+// /home/user/files.zip/dir/file.txt
+package archivefs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported archive format")
+
+// Mode of the root, and of the directories that have no entry of their own
+const implicitDirMode = fs.ModeDir | 0o555
+
+// FS is the index of the entries of an archive, built once on Open. It is safe
+// for concurrent use, as the index is never modified after Open
+type FS struct {
+	archivePath string
+	entries     map[string]*entry // Keyed by the slash separated path inside the archive
+	children    map[string][]*entry
+	closer      io.Closer
+}
+
+type entry struct {
+	name    string // Full path inside the archive, "." for the root
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+	// Opens the content of a regular file entry
+	open func() (io.ReadCloser, error)
+}
+
+// IsArchive tells whether the file can be browsed, based on its name only
+func IsArchive(path string) bool {
+	return formatOf(path) != formatUnknown
+}
+
+// Open reads the index of the archive. The caller must call Close once done
+func Open(archivePath string) (*FS, error) {
+	f := &FS{
+		archivePath: archivePath,
+		entries:     make(map[string]*entry),
+		children:    make(map[string][]*entry),
+	}
+	f.entries["."] = &entry{name: ".", mode: implicitDirMode}
+	var err error
+	switch formatOf(archivePath) {
+	case formatZip:
+		err = f.indexZip()
+	case formatSevenZip:
+		err = f.indexSevenZip()
+	case formatTar, formatTarGz, formatTarBz2, formatTarXz, formatTarZst:
+		err = f.indexTar()
+	case formatUnknown:
+		err = ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read archive %s: %w", archivePath, err)
+	}
+	f.buildChildren()
+	return f, nil
+}
+
+// Split splits a virtual path into the path of the archive on disk, and the
+// slash separated path of the entry inside it. ok is false if no parent of
+// path is an archive
+func Split(virtualPath string) (string, string, bool) {
+	virtualPath = filepath.Clean(virtualPath)
+	for cur := virtualPath; ; cur = filepath.Dir(cur) {
+		if IsArchive(cur) {
+			if info, err := os.Stat(cur); err == nil && info.Mode().IsRegular() {
+				inner, err := filepath.Rel(cur, virtualPath)
+				if err != nil {
+					return "", "", false
+				}
+				return cur, filepath.ToSlash(inner), true
+			}
+		}
+		if filepath.Dir(cur) == cur {
+			return "", "", false
+		}
+	}
+}
+
+// IsVirtual tells whether path is an entry inside an archive, rather than a
+// file on disk. The archive itself is not virtual
+func IsVirtual(path string) bool {
+	_, inner, ok := Split(path)
+	return ok && inner != "."
+}
+
+// Lstat returns the info of the entry at a virtual path. It reads the whole
+// index of the archive, so it must not be called for many entries
+func Lstat(virtualPath string) (fs.FileInfo, error) {
+	archivePath, inner, ok := Split(virtualPath)
+	if !ok {
+		return nil, &fs.PathError{Op: "lstat", Path: virtualPath, Err: fs.ErrNotExist}
+	}
+	f, err := Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat(inner)
+}
+
+// ArchivePath is the path of the archive on disk
+func (f *FS) ArchivePath() string {
+	return f.archivePath
+}
+
+// VirtualPath returns the virtual path of the entry name
+func (f *FS) VirtualPath(name string) string {
+	return filepath.Join(f.archivePath, filepath.FromSlash(name))
+}
+
+// Contains tells whether the virtual path is the archive, or inside it,
+// and returns the name of the entry
+func (f *FS) Contains(virtualPath string) (string, bool) {
+	inner, err := filepath.Rel(f.archivePath, filepath.Clean(virtualPath))
+	if err != nil || inner == ".." || strings.HasPrefix(inner, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(inner), true
+}
+
+func (f *FS) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+func (f *FS) lookup(op string, name string) (*entry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := f.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	e, err := f.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fileInfo{e}, nil
+}
+
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := f.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	children := f.children[name]
+	dirEntries := make([]fs.DirEntry, len(children))
+	for i, child := range children {
+		dirEntries[i] = fileInfo{child}
+	}
+	return dirEntries, nil
+}
+
+// Open opens a regular file entry. Directories can only be listed via ReadDir
+func (f *FS) Open(name string) (fs.File, error) {
+	e, err := f.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsRegular() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("not a regular file")}
+	}
+	rc, err := e.open()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &file{ReadCloser: rc, e: e}, nil
+}
+
+// addEntry records an entry read from the archive. Names that are not valid
+// relative paths, like absolute ones or ones going up with "..", are skipped
+func (f *FS) addEntry(name string, info fs.FileInfo, open func() (io.ReadCloser, error)) {
+	name = path.Clean(strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "./"))
+	if name == "." || !fs.ValidPath(name) {
+		return
+	}
+	e := &entry{
+		name:    name,
+		size:    info.Size(),
+		mode:    info.Mode(),
+		modTime: info.ModTime(),
+		open:    open,
+	}
+	if e.mode.IsDir() {
+		e.size = 0
+	}
+	f.entries[name] = e
+	// Archives often omit the entries of the parent directories
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if _, ok := f.entries[dir]; ok {
+			break
+		}
+		f.entries[dir] = &entry{name: dir, mode: implicitDirMode, modTime: e.modTime}
+	}
+}
+
+func (f *FS) buildChildren() {
+	for name, e := range f.entries {
+		if name == "." {
+			continue
+		}
+		parent := path.Dir(name)
+		f.children[parent] = append(f.children[parent], e)
+	}
+	for _, children := range f.children {
+		slices.SortFunc(children, func(a, b *entry) int {
+			return strings.Compare(a.name, b.name)
+		})
+	}
+}
+
+type fileInfo struct {
+	e *entry
+}
+
+func (i fileInfo) Name() string               { return path.Base(i.e.name) }
+func (i fileInfo) Size() int64                { return i.e.size }
+func (i fileInfo) Mode() fs.FileMode          { return i.e.mode }
+func (i fileInfo) ModTime() time.Time         { return i.e.modTime }
+func (i fileInfo) IsDir() bool                { return i.e.mode.IsDir() }
+func (i fileInfo) Sys() any                   { return nil }
+func (i fileInfo) Type() fs.FileMode          { return i.e.mode.Type() }
+func (i fileInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+type file struct {
+	io.ReadCloser
+
+	e *entry
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return fileInfo{f.e}, nil
+}
@@ -0,0 +1,201 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+type testEntry struct {
+	name    string
+	content string
+	isDir   bool
+}
+
+// Parent directories of dir/sub/file.txt have no entries of their own,
+// and ../evil.txt must never be listed
+var testEntries = []testEntry{ //nolint:gochecknoglobals // Effectively const
+	{name: "readme.txt", content: "hello"},
+	{name: "empty/", isDir: true},
+	{name: "dir/sub/file.txt", content: "nested"},
+	{name: "../evil.txt", content: "evil"},
+}
+
+var testModTime = time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC) //nolint:gochecknoglobals // Effectively const
+
+func writeZip(t *testing.T, path string) {
+	t.Helper()
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+	zw := zip.NewWriter(out)
+	for _, e := range testEntries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Modified: testModTime, Method: zip.Deflate})
+		require.NoError(t, err)
+		_, err = io.WriteString(w, e.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func writeTar(t *testing.T, path string) {
+	t.Helper()
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+
+	var w io.Writer = out
+	switch formatOf(path) { //nolint:exhaustive // Only tar formats are written here
+	case formatTarGz:
+		gz := gzip.NewWriter(out)
+		defer func() { require.NoError(t, gz.Close()) }()
+		w = gz
+	case formatTarXz:
+		xw, err := xz.NewWriter(out)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, xw.Close()) }()
+		w = xw
+	case formatTarZst:
+		zw, err := zstd.NewWriter(out)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, zw.Close()) }()
+		w = zw
+	}
+
+	tw := tar.NewWriter(w)
+	for _, e := range testEntries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), ModTime: testModTime,
+			Typeflag: tar.TypeReg}
+		if e.isDir {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0o755
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = io.WriteString(tw, e.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+func TestArchiveFS(t *testing.T) {
+	for _, name := range []string{"test.zip", "test.tar", "test.tar.gz", "test.tgz", "test.tar.xz", "test.tar.zst"} {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), name)
+			if formatOf(name) == formatZip {
+				writeZip(t, archivePath)
+			} else {
+				writeTar(t, archivePath)
+			}
+			f, err := Open(archivePath)
+			require.NoError(t, err)
+			defer f.Close()
+
+			entries, err := f.ReadDir(".")
+			require.NoError(t, err)
+			var names []string
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			assert.Equal(t, []string{"dir", "empty", "readme.txt"}, names)
+
+			info, err := f.Stat("readme.txt")
+			require.NoError(t, err)
+			assert.Equal(t, int64(len("hello")), info.Size())
+			assert.True(t, testModTime.Equal(info.ModTime()))
+			assert.False(t, info.IsDir())
+
+			info, err = f.Stat("dir/sub")
+			require.NoError(t, err)
+			assert.True(t, info.IsDir(), "implicit directories are listed")
+
+			content, err := fs.ReadFile(f, "dir/sub/file.txt")
+			require.NoError(t, err)
+			assert.Equal(t, "nested", string(content))
+
+			_, err = f.Stat("../evil.txt")
+			require.ErrorIs(t, err, fs.ErrInvalid)
+			_, err = f.Open("dir")
+			require.Error(t, err, "directories can't be opened")
+
+			dst := filepath.Join(t.TempDir(), "out")
+			require.NoError(t, f.CopyOut(context.Background(), "dir", dst))
+			content, err = os.ReadFile(filepath.Join(dst, "sub", "file.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "nested", string(content))
+			assert.Error(t, f.CopyOut(context.Background(), "readme.txt", filepath.Join(dst, "sub", "file.txt")),
+				"existing files are never overwritten")
+		})
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	dir := t.TempDir()
+	notArchive := filepath.Join(dir, "file.txt")
+	corrupted := filepath.Join(dir, "corrupted.zip")
+	require.NoError(t, os.WriteFile(notArchive, []byte("text"), 0o600))
+	require.NoError(t, os.WriteFile(corrupted, []byte("text"), 0o600))
+
+	_, err := Open(notArchive)
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = Open(corrupted)
+	require.Error(t, err)
+}
+
+func TestSplit(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "test.zip")
+	writeZip(t, archivePath)
+	// A directory named like an archive is not one
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir.zip"), 0o750))
+
+	testdata := []struct {
+		path        string
+		archivePath string
+		inner       string
+		ok          bool
+	}{
+		{archivePath, archivePath, ".", true},
+		{filepath.Join(archivePath, "dir", "sub"), archivePath, "dir/sub", true},
+		{filepath.Join(dir, "dir.zip", "file"), "", "", false},
+		{dir, "", "", false},
+	}
+	for _, tt := range testdata {
+		archive, inner, ok := Split(tt.path)
+		assert.Equal(t, tt.ok, ok, tt.path)
+		assert.Equal(t, tt.archivePath, archive, tt.path)
+		assert.Equal(t, tt.inner, inner, tt.path)
+	}
+
+	assert.False(t, IsVirtual(archivePath))
+	assert.True(t, IsVirtual(filepath.Join(archivePath, "readme.txt")))
+	info, err := Lstat(filepath.Join(archivePath, "readme.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "readme.txt", info.Name())
+	dst := filepath.Join(dir, "readme.txt")
+	require.NoError(t, CopyOutPath(context.Background(), filepath.Join(archivePath, "readme.txt"), dst))
+	content, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	f, err := Open(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+	inner, ok := f.Contains(filepath.Join(archivePath, "dir"))
+	assert.True(t, ok)
+	assert.Equal(t, "dir", inner)
+	_, ok = f.Contains(dir)
+	assert.False(t, ok)
+	assert.Equal(t, filepath.Join(archivePath, "dir", "sub"), f.VirtualPath("dir/sub"))
+}
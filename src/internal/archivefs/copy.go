@@ -0,0 +1,82 @@
+package archivefs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// CopyOutPath is CopyOut for the entry at a virtual path
+func CopyOutPath(ctx context.Context, virtualPath string, dst string) error {
+	archivePath, inner, ok := Split(virtualPath)
+	if !ok {
+		return &fs.PathError{Op: "copy", Path: virtualPath, Err: fs.ErrNotExist}
+	}
+	f, err := Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.CopyOut(ctx, inner, dst)
+}
+
+// CopyOut extracts the entry name, and all its content if it's a directory,
+// to dst on disk. dst must not exist. Entries that are neither regular files
+// nor directories, like symlinks, are skipped
+func (f *FS) CopyOut(ctx context.Context, name string, dst string) error {
+	return fs.WalkDir(f, name, func(entryName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := checkpoint(ctx); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(name), filepath.FromSlash(entryName))
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, utils.UserDirPerm)
+		case d.Type().IsRegular():
+			return f.copyFileOut(entryName, target)
+		default:
+			return nil
+		}
+	})
+}
+
+// Contexts that can also pause the work, like the ones of the processes
+type checkpointer interface {
+	Checkpoint() error
+}
+
+func checkpoint(ctx context.Context) error {
+	if c, ok := ctx.(checkpointer); ok {
+		return c.Checkpoint()
+	}
+	return context.Cause(ctx)
+}
+
+func (f *FS) copyFileOut(name string, target string) (err error) {
+	src, err := f.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, utils.UserFilePerm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, dst.Close())
+	}()
+	_, err = io.Copy(dst, src)
+	return err
+}
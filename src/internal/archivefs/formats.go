@@ -0,0 +1,162 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/bodgit/sevenzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+type format int
+
+const (
+	formatUnknown format = iota
+	formatZip
+	formatSevenZip
+	formatTar
+	formatTarGz
+	formatTarBz2
+	formatTarXz
+	formatTarZst
+)
+
+func formatOf(path string) format {
+	name := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return formatZip
+	case strings.HasSuffix(name, ".7z"):
+		return formatSevenZip
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGz
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"), strings.HasSuffix(name, ".tbz"):
+		return formatTarBz2
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return formatTarXz
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return formatTarZst
+	default:
+		return formatUnknown
+	}
+}
+
+func (f *FS) indexZip() error {
+	r, err := zip.OpenReader(f.archivePath)
+	if err != nil {
+		return err
+	}
+	f.closer = r
+	for _, zf := range r.File {
+		f.addEntry(zf.Name, zf.FileInfo(), zf.Open)
+	}
+	return nil
+}
+
+func (f *FS) indexSevenZip() error {
+	r, err := sevenzip.OpenReader(f.archivePath)
+	if err != nil {
+		return err
+	}
+	f.closer = r
+	for _, sf := range r.File {
+		f.addEntry(sf.Name, sf.FileInfo(), sf.Open)
+	}
+	return nil
+}
+
+// Tar archives can't be read at random, so each opened entry is
+// read by going through the archive again, up to the entry
+func (f *FS) indexTar() error {
+	return walkTar(f.archivePath, func(index int, hdr *tar.Header, _ io.Reader) error {
+		f.addEntry(hdr.Name, hdr.FileInfo(), func() (io.ReadCloser, error) {
+			return f.openTarEntry(index, hdr.Name), nil
+		})
+		return nil
+	})
+}
+
+func (f *FS) openTarEntry(entryIndex int, name string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		found := false
+		err := walkTar(f.archivePath, func(index int, _ *tar.Header, content io.Reader) error {
+			if index != entryIndex {
+				return nil
+			}
+			found = true
+			_, err := io.Copy(pw, content)
+			pw.CloseWithError(err)
+			return fs.SkipAll
+		})
+		if err == nil && !found {
+			err = fmt.Errorf("%s not found in the archive", name)
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// walkTar calls fn for each header of the archive, until it returns an
+// error. fs.SkipAll stops the walk without an error
+func walkTar(archivePath string, fn func(index int, hdr *tar.Header, content io.Reader) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	switch formatOf(archivePath) { //nolint:exhaustive // Only tar formats reach here
+	case formatTarGz:
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case formatTarBz2:
+		r = bzip2.NewReader(file)
+	case formatTarXz:
+		xr, err := xz.NewReader(file)
+		if err != nil {
+			return err
+		}
+		r = xr
+	case formatTarZst:
+		zr, err := zstd.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	tr := tar.NewReader(r)
+	for index := 0; ; index++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(index, hdr, tr); err != nil {
+			if errors.Is(err, fs.SkipAll) {
+				return nil
+			}
+			return err
+		}
+	}
+}
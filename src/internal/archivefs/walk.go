@@ -0,0 +1,117 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/bodgit/sevenzip"
+)
+
+// Longest symlink target read from the content of a zip or 7z entry
+const maxLinkTargetSize = 4096
+
+// Entry is an entry of an archive as it is stored, read by Walk
+type Entry struct {
+	// Slash separated name. It is not cleaned, so it can be absolute,
+	// or go up with ".."
+	Name    string
+	Mode    fs.FileMode
+	ModTime time.Time
+	// Target of a symlink
+	Linkname string
+}
+
+// Walk calls fn for each regular file, directory and symlink of the
+// archive, in the order they are stored, until it returns an error.
+// fs.SkipAll stops the walk without an error. content reads the data of a
+// regular file, and is only valid during the call. Other entries, like the
+// hard links of tar archives, are skipped
+func Walk(archivePath string, fn func(e Entry, content io.Reader) error) error {
+	var err error
+	switch formatOf(archivePath) {
+	case formatZip:
+		err = walkZip(archivePath, fn)
+	case formatSevenZip:
+		err = walkSevenZip(archivePath, fn)
+	case formatTar, formatTarGz, formatTarBz2, formatTarXz, formatTarZst:
+		err = walkTar(archivePath, func(_ int, hdr *tar.Header, content io.Reader) error {
+			switch hdr.Typeflag {
+			case tar.TypeReg, tar.TypeDir, tar.TypeSymlink:
+				return fn(Entry{Name: hdr.Name, Mode: hdr.FileInfo().Mode(), ModTime: hdr.ModTime,
+					Linkname: hdr.Linkname}, content)
+			default:
+				return nil
+			}
+		})
+	case formatUnknown:
+		err = ErrUnsupportedFormat
+	}
+	if errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walkZip(archivePath string, fn func(e Entry, content io.Reader) error) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, zf := range r.File {
+		if err := walkOpenedEntry(zf.Name, zf.FileInfo(), zf.Open, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkSevenZip(archivePath string, fn func(e Entry, content io.Reader) error) error {
+	r, err := sevenzip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, sf := range r.File {
+		if err := walkOpenedEntry(sf.Name, sf.FileInfo(), sf.Open, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkOpenedEntry calls fn for an entry of the formats that can be read at
+// random. Their symlinks store the target as the content of the entry
+func walkOpenedEntry(name string, info fs.FileInfo, open func() (io.ReadCloser, error),
+	fn func(e Entry, content io.Reader) error) error {
+	e := Entry{Name: strings.ReplaceAll(name, "\\", "/"), Mode: info.Mode(), ModTime: info.ModTime()}
+	switch {
+	case e.Mode.IsDir():
+		return fn(e, nil)
+	case e.Mode.IsRegular(), e.Mode&fs.ModeSymlink != 0:
+	default:
+		return nil
+	}
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if e.Mode.IsRegular() {
+		return fn(e, rc)
+	}
+	target, err := io.ReadAll(io.LimitReader(rc, maxLinkTargetSize+1))
+	if err != nil {
+		return err
+	}
+	if len(target) > maxLinkTargetSize {
+		return errors.New("symlink target of " + name + " is too long")
+	}
+	e.Linkname = string(target)
+	return fn(e, nil)
+}
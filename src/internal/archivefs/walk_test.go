@@ -0,0 +1,95 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	for _, name := range []string{"test.zip", "test.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), name)
+			if formatOf(name) == formatZip {
+				writeZip(t, archivePath)
+			} else {
+				writeTar(t, archivePath)
+			}
+
+			contents := make(map[string]string)
+			var dirs []string
+			require.NoError(t, Walk(archivePath, func(e Entry, content io.Reader) error {
+				if e.Mode.IsDir() {
+					dirs = append(dirs, e.Name)
+					return nil
+				}
+				data, err := io.ReadAll(content)
+				contents[e.Name] = string(data)
+				return err
+			}))
+			assert.Equal(t, []string{"empty/"}, dirs, "implicit directories are not walked")
+			assert.Equal(t, map[string]string{
+				"readme.txt":       "hello",
+				"dir/sub/file.txt": "nested",
+				"../evil.txt":      "evil",
+			}, contents, "names are reported as stored")
+
+			count := 0
+			require.NoError(t, Walk(archivePath, func(Entry, io.Reader) error {
+				count++
+				return fs.SkipAll
+			}))
+			assert.Equal(t, 1, count, "the walk stops on SkipAll")
+		})
+	}
+
+	t.Run("Symlinks", func(t *testing.T) {
+		dir := t.TempDir()
+		zipPath := filepath.Join(dir, "links.zip")
+		out, err := os.Create(zipPath)
+		require.NoError(t, err)
+		zw := zip.NewWriter(out)
+		hdr := &zip.FileHeader{Name: "link"}
+		hdr.SetMode(fs.ModeSymlink | 0o777)
+		w, err := zw.CreateHeader(hdr)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, "target.txt")
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		require.NoError(t, out.Close())
+
+		tarPath := filepath.Join(dir, "links.tar")
+		out, err = os.Create(tarPath)
+		require.NoError(t, err)
+		tw := tar.NewWriter(out)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "link", Linkname: "target.txt",
+			Typeflag: tar.TypeSymlink}))
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "hardlink", Linkname: "target.txt",
+			Typeflag: tar.TypeLink}))
+		require.NoError(t, tw.Close())
+		require.NoError(t, out.Close())
+
+		for _, archivePath := range []string{zipPath, tarPath} {
+			var entries []Entry
+			require.NoError(t, Walk(archivePath, func(e Entry, _ io.Reader) error {
+				entries = append(entries, e)
+				return nil
+			}))
+			require.Len(t, entries, 1, "hard links are skipped")
+			assert.Equal(t, "link", entries[0].Name)
+			assert.Equal(t, "target.txt", entries[0].Linkname)
+			assert.NotZero(t, entries[0].Mode&fs.ModeSymlink)
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		require.ErrorIs(t, Walk("file.rar", func(Entry, io.Reader) error { return nil }), ErrUnsupportedFormat)
+	})
+}
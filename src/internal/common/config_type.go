@@ -77,23 +77,31 @@ type ConfigType struct {
 	ShowImagePreview       bool   `toml:"show_image_preview"        comment:"\nWhether to show image preview."`
 	ShowPanelFooterInfo    bool   `toml:"show_panel_footer_info"    comment:"\nWhether to show additional footer info for file panel."`
 	DefaultDirectory       string `toml:"default_directory"         comment:"\nThe path of the first file panel when superfile is opened."`
+	RestoreSession         bool   `toml:"restore_session"           comment:"\nRestore the panels of the last session when superfile is opened without a path."`
 	FileSizeUseSI          bool   `toml:"file_size_use_si"          comment:"\nDisplay file sizes using powers of 1000 (kB, MB, GB) instead of powers of 1024 (KiB, MiB, GiB)."`
-	DefaultSortType        int    `toml:"default_sort_type"         comment:"\nDefault sort type (0: Name, 1: Size, 2: Date Modified, 3: Type, 4: Natural)."`
+	DefaultSortType        int    `toml:"default_sort_type"         comment:"\nDefault sort type (0: Name, 1: Size, 2: Date Modified, 3: Type, 4: Natural, 5: Extension, 6: Date Created, 7: Date Accessed, 8: Version)."`
 	SortOrderReversed      bool   `toml:"sort_order_reversed"       comment:"\nDefault sort order (false: Ascending, true: Descending)."`
 	CaseSensitiveSort      bool   `toml:"case_sensitive_sort"       comment:"\nCase sensitive sort by name (capital \"B\" comes before \"a\" if true)."`
+	SortDirectoriesFirst   bool   `toml:"sort_directories_first"    comment:"\nList the directories before the files by default, except when sorting by date."`
+	SortPerDirectory       bool   `toml:"sort_per_directory"        comment:"\nRemember the sort chosen for each directory, and reopen the directory with it."`
+	DefaultCompressFormat  string `toml:"default_compress_format"   comment:"\nFormat preselected when compressing files (zip, tar, tar.gz, tar.xz, tar.zst)."`
 	ShellCloseOnSuccess    bool   `toml:"shell_close_on_success"    comment:"\nWhether to close the shell on successful command execution."`
 	Debug                  bool   `toml:"debug"                     comment:"\nWhether to enable debug mode."`
 	// IgnoreMissingFields controls whether warnings about missing TOML fields are suppressed.
-	IgnoreMissingFields   bool `toml:"ignore_missing_fields"    comment:"\nWhether to ignore warnings about missing fields in the config file."`
-	PageScrollSize        int  `toml:"page_scroll_size"         comment:"\nNumber of lines to scroll for PgUp/PgDown keys (0: full page, default behavior)."`
-	FilePanelExtraColumns int  `toml:"file_panel_extra_columns" comment:"\nCount of extra columns in file panel in addition to file name. When option equal 0 then feature is disabled."`
-	FilePanelNamePercent  int  `toml:"file_panel_name_percent"  comment:"\nPercentage of file panel width allocated to file names (25-100). Higher values give more space to names, less to extra columns."`
+	IgnoreMissingFields     bool     `toml:"ignore_missing_fields"      comment:"\nWhether to ignore warnings about missing fields in the config file."`
+	PageScrollSize          int      `toml:"page_scroll_size"           comment:"\nNumber of lines to scroll for PgUp/PgDown keys (0: full page, default behavior)."`
+	RecursiveSearchMaxDepth int      `toml:"recursive_search_max_depth" comment:"\nHow many levels of subdirectories the recursive search looks into (1-64)."`
+	FilePanelExtraColumns   int      `toml:"file_panel_extra_columns"   comment:"\nCount of extra columns in file panel in addition to file name. When option equal 0 then feature is disabled."`
+	FilePanelColumns        []string `toml:"file_panel_columns"         comment:"\nExtra columns of the file panel, in order. The first file_panel_extra_columns of them are shown. Values: size, modify_time, permissions, octal_permissions, owner, group, inode, links, link_target, access_time, change_time, birth_time, item_count"`
+	FilePanelNamePercent    int      `toml:"file_panel_name_percent"    comment:"\nPercentage of file panel width allocated to file names (25-100). Higher values give more space to names, less to extra columns."`
+	DirectorySizes          bool     `toml:"directory_sizes"            comment:"\nCompute the total size of the directories in the background, to show it in the size column and sort by it. Reads all the files below the directories shown."`
 
 	Nerdfont                bool     `toml:"nerdfont"                   comment:"\n================   Style =================\n\n If you don't have or don't want Nerdfont installed you can turn this off"`
 	ShowSelectIcons         bool     `toml:"show_select_icons"          comment:"\nShow checkbox icons in select mode (requires nerdfont)"`
 	TransparentBackground   bool     `toml:"transparent_background"     comment:"\nSet transparent background or not (this only work when your terminal background is transparent)"`
 	FilePreviewWidth        int      `toml:"file_preview_width"         comment:"\nFile preview width allow '0' (this mean same as file panel),'x' x must be less than 10 and greater than 1 (This means that the width of the file preview will be one xth of the total width.)"`
 	EnableFilePreviewBorder bool     `toml:"enable_file_preview_border" comment:"\nEnable border around the file preview panel (default: false)"`
+	MillerColumns           bool     `toml:"miller_columns"             comment:"\nShow the parent directory of the focused file panel in a column on its left, like ranger (default: false)"`
 	CodePreviewer           string   `toml:"code_previewer"             comment:"\nWhether to use the builtin syntax highlighting with chroma or use bat. Values: \"\" for builtin chroma, \"bat\" for bat"`
 	SidebarWidth            int      `toml:"sidebar_width"              comment:"\nThe length of the sidebar(excluding borders). If you don't find to display the sidebar, you can input 0 directly. If you want to display the value, please place it in the range of 5-20."`
 	SidebarSections         []string `toml:"sidebar_sections"           comment:"\nOrder of sidebar sections (valid values: \"home\", \"pinned\", \"disks\").\nOnly sections included in this list will be displayed."`
@@ -112,6 +120,7 @@ type ConfigType struct {
 	Metadata          bool `toml:"metadata"            comment:"\n==========PLUGINS========== #\nPlugins means that you need to install some external dependencies to use them.\n\nShow more detailed metadata, please install exiftool before enabling this plugin!"`
 	EnableMD5Checksum bool `toml:"enable_md5_checksum" comment:"Enable MD5 checksum generation for files"`
 	ZoxideSupport     bool `toml:"zoxide_support"      comment:"Zoxide support for the fast navigation"`
+	GitStatus         bool `toml:"git_status"          comment:"Show the git status of the files, and the branch, in the file panels. Requires git"`
 }
 
 // GetIgnoreMissingFields reports whether warnings about missing TOML fields should be ignored.
@@ -138,19 +147,46 @@ type HotkeysType struct {
 	ToggleFilePreviewPanel []string `toml:"toggle_file_preview_panel"`
 	OpenSortOptionsMenu    []string `toml:"open_sort_options_menu"`
 	ToggleReverseSort      []string `toml:"toggle_reverse_sort"`
+	ToggleDirectoriesFirst []string `toml:"toggle_directories_first"`
 
-	FocusOnProcessBar []string `toml:"focus_on_process_bar" comment:"change focus"`
-	FocusOnSidebar    []string `toml:"focus_on_sidebar"`
-	FocusOnMetaData   []string `toml:"focus_on_metadata"`
+	NewTab      []string `toml:"new_tab"      comment:"tabs"`
+	CloseTab    []string `toml:"close_tab"`
+	NextTab     []string `toml:"next_tab"`
+	PreviousTab []string `toml:"previous_tab"`
+	RenameTab   []string `toml:"rename_tab"`
+
+	SetMark        []string `toml:"set_mark"        comment:"marks and history"`
+	JumpToMark     []string `toml:"jump_to_mark"`
+	HistoryBack    []string `toml:"history_back"`
+	HistoryForward []string `toml:"history_forward"`
+
+	ComparePanels          []string `toml:"compare_panels"            comment:"compare directories"`
+	ComparePanelsByContent []string `toml:"compare_panels_by_content"`
+	SelectDifferences      []string `toml:"select_differences"`
+
+	FocusOnProcessBar   []string `toml:"focus_on_process_bar"   comment:"change focus"`
+	FocusOnSidebar      []string `toml:"focus_on_sidebar"`
+	FocusOnMetaData     []string `toml:"focus_on_metadata"`
+	FocusOnParentColumn []string `toml:"focus_on_parent_column"`
 
 	FilePanelItemCreate []string `toml:"file_panel_item_create" comment:"create file/directory and rename "`
 	FilePanelItemRename []string `toml:"file_panel_item_rename"`
+	BulkRename          []string `toml:"bulk_rename"`
+	PatternRename       []string `toml:"pattern_rename"`
 
 	CopyItems              []string `toml:"copy_items"               comment:"file operate"`
 	PasteItems             []string `toml:"paste_items"`
+	PasteSymlink           []string `toml:"paste_symlink"`
+	PasteRelativeSymlink   []string `toml:"paste_relative_symlink"`
+	PasteHardlink          []string `toml:"paste_hardlink"`
 	CutItems               []string `toml:"cut_items"`
 	DeleteItems            []string `toml:"delete_items"`
 	PermanentlyDeleteItems []string `toml:"permanently_delete_items"`
+	ChangePermissions      []string `toml:"change_permissions"`
+	Undo                   []string `toml:"undo"`
+	Redo                   []string `toml:"redo"`
+	CancelProcess          []string `toml:"cancel_process"`
+	PauseProcess           []string `toml:"pause_process"`
 
 	ExtractFile  []string `toml:"extract_file"  comment:"compress and extract"`
 	CompressFile []string `toml:"compress_file"`
@@ -158,21 +194,24 @@ type HotkeysType struct {
 	OpenFileWithEditor             []string `toml:"open_file_with_editor"              comment:"editor"`
 	OpenCurrentDirectoryWithEditor []string `toml:"open_current_directory_with_editor"`
 
-	PinnedDirectory []string `toml:"pinned_directory"  comment:"other"`
-	ToggleDotFile   []string `toml:"toggle_dot_file"`
-	ChangePanelMode []string `toml:"change_panel_mode"`
-	OpenHelpMenu    []string `toml:"open_help_menu"`
-	OpenCommandLine []string `toml:"open_command_line"`
-	OpenSPFPrompt   []string `toml:"open_spf_prompt"`
-	OpenZoxide      []string `toml:"open_zoxide"`
+	PinnedDirectory   []string `toml:"pinned_directory"  comment:"other"`
+	ToggleDotFile     []string `toml:"toggle_dot_file"`
+	ChangePanelMode   []string `toml:"change_panel_mode"`
+	ToggleTreeMode    []string `toml:"toggle_tree_mode"`
+	OpenHelpMenu      []string `toml:"open_help_menu"`
+	OpenCommandLine   []string `toml:"open_command_line"`
+	OpenSPFPrompt     []string `toml:"open_spf_prompt"`
+	OpenZoxide        []string `toml:"open_zoxide"`
+	OpenContentSearch []string `toml:"open_content_search"`
 
 	CopyPath []string `toml:"copy_path"`
 	CopyPWD  []string `toml:"copy_present_working_directory"`
 
 	ToggleFooter []string `toml:"toggle_footer"`
 
-	ConfirmTyping []string `toml:"confirm_typing" comment:"=================================================================================================\nTyping hotkeys (can conflict with all hotkeys)"`
-	CancelTyping  []string `toml:"cancel_typing"`
+	ConfirmTyping         []string `toml:"confirm_typing"          comment:"=================================================================================================\nTyping hotkeys (can conflict with all hotkeys)"`
+	CancelTyping          []string `toml:"cancel_typing"`
+	ToggleRecursiveSearch []string `toml:"toggle_recursive_search"`
 
 	ParentDirectory []string `toml:"parent_directory" comment:"=================================================================================================\nNormal mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
 	SearchBar       []string `toml:"search_bar"`
@@ -180,4 +219,24 @@ type HotkeysType struct {
 	FilePanelSelectModeItemsSelectDown []string `toml:"file_panel_select_mode_items_select_down" comment:"=================================================================================================\nSelect mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
 	FilePanelSelectModeItemsSelectUp   []string `toml:"file_panel_select_mode_items_select_up"`
 	FilePanelSelectAllItem             []string `toml:"file_panel_select_all_items"`
+
+	ConflictOverwrite        []string `toml:"conflict_overwrite"          comment:"=================================================================================================\nModal hotkeys (only active in their modal, can conflict with all hotkeys)"`
+	ConflictOverwriteIfNewer []string `toml:"conflict_overwrite_if_newer"`
+	ConflictSkip             []string `toml:"conflict_skip"`
+	ConflictKeepBoth         []string `toml:"conflict_keep_both"`
+	ConflictApplyToAll       []string `toml:"conflict_apply_to_all"`
+
+	PatternRenameNextField []string `toml:"pattern_rename_next_field" comment:"pattern rename"`
+	PatternRenameCycleCase []string `toml:"pattern_rename_cycle_case"`
+
+	TrashBinToggleSelect []string `toml:"trash_bin_toggle_select" comment:"trash bin"`
+	TrashBinEmpty        []string `toml:"trash_bin_empty"`
+
+	PermissionToggle        []string `toml:"permission_toggle"         comment:"permissions"`
+	PermissionNextField     []string `toml:"permission_next_field"`
+	PermissionPreviousField []string `toml:"permission_previous_field"`
+	PermissionGridLeft      []string `toml:"permission_grid_left"`
+	PermissionGridRight     []string `toml:"permission_grid_right"`
+
+	ContentSearchOpenInEditor []string `toml:"content_search_open_in_editor" comment:"content search"`
 }
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/x/ansi"
 	"github.com/pelletier/go-toml/v2"
@@ -77,8 +79,20 @@ func ValidateConfig(c *ConfigType) error {
 		}
 	}
 
-	if c.DefaultSortType < 0 || c.DefaultSortType > 4 {
-		return errors.New(LoadConfigError("default_sort_type", "Default sort type must be between 0 and 4."))
+	// NOTE: Keep in sync with sortmodel.SortOptionsStr
+	if c.DefaultSortType < 0 || c.DefaultSortType > 8 {
+		return errors.New(LoadConfigError("default_sort_type", "Default sort type must be between 0 and 8."))
+	}
+
+	// NOTE: Keep in sync with compressmodel.FormatOptionsStr
+	if !slices.Contains([]string{"zip", "tar", "tar.gz", "tar.xz", "tar.zst"}, c.DefaultCompressFormat) {
+		return errors.New(LoadConfigError("default_compress_format",
+			"Default compress format must be one of zip, tar, tar.gz, tar.xz, tar.zst."))
+	}
+
+	if c.RecursiveSearchMaxDepth < RecursiveSearchDepthMin || c.RecursiveSearchMaxDepth > RecursiveSearchDepthMax {
+		return errors.New(LoadConfigError("recursive_search_max_depth",
+			"Recursive search max depth must be between 1 and 64."))
 	}
 
 	if c.FilePanelNamePercent < FileNameRatioMin || c.FilePanelNamePercent > FileNameRatioMax {
@@ -87,6 +101,14 @@ func ValidateConfig(c *ConfigType) error {
 		)
 	}
 
+	for _, column := range c.FilePanelColumns {
+		if !slices.Contains(FilePanelColumnNames, column) {
+			return errors.New(LoadConfigError("file_panel_columns",
+				fmt.Sprintf("Unsupported column %q. Allowed values are: %s.", column,
+					strings.Join(FilePanelColumnNames, ", "))))
+		}
+	}
+
 	if ansi.StringWidth(c.BorderTop) != 1 {
 		return errors.New(LoadConfigError("border_top", "Border character must be exactly one cell wide."))
 	}
@@ -28,6 +28,8 @@ const (
 	TrashWarnContent           = "This operation will move file or directory to trash can."
 	PermanentDeleteWarnTitle   = "Are you sure you want to completely delete"
 	PermanentDeleteWarnContent = "This operation cannot be undone and your data will be completely lost."
+	PurgeTrashWarnTitle        = "Are you sure you want to delete this from the trash"
+	EmptyTrashWarnTitle        = "Are you sure you want to empty the trash"
 )
 
 const (
@@ -41,6 +43,30 @@ const (
 	ModalHeight     = 7
 )
 
+// Extra columns of the file panel, to use in file_panel_columns
+const (
+	ColumnSize             = "size"
+	ColumnModifyTime       = "modify_time"
+	ColumnPermissions      = "permissions"
+	ColumnOctalPermissions = "octal_permissions"
+	ColumnOwner            = "owner"
+	ColumnGroup            = "group"
+	ColumnInode            = "inode"
+	ColumnLinks            = "links"
+	ColumnLinkTarget       = "link_target"
+	ColumnAccessTime       = "access_time"
+	ColumnChangeTime       = "change_time"
+	ColumnBirthTime        = "birth_time"
+	ColumnItemCount        = "item_count"
+)
+
+//nolint:gochecknoglobals // Read-only list of the columns
+var FilePanelColumnNames = []string{
+	ColumnSize, ColumnModifyTime, ColumnPermissions, ColumnOctalPermissions, ColumnOwner, ColumnGroup,
+	ColumnInode, ColumnLinks, ColumnLinkTarget, ColumnAccessTime, ColumnChangeTime, ColumnBirthTime,
+	ColumnItemCount,
+}
+
 var (
 	SideBarSuperfileTitle string
 	SideBarHomeDivider    string
@@ -209,15 +209,23 @@ func IsBufferPrintable(buffer []byte) bool {
 
 // IsExtensionExtractable checks if a string is a valid compressed archive file extension.
 func IsExtensionExtractable(ext string) bool {
-	// Extensions based on the types that package: `xtractr` `ExtractFile` function handles.
+	// Extensions based on the types that `archivefs.Walk`, or else package: `xtractr`
+	// `ExtractFile` function handles.
 	validExtensions := map[string]struct{}{
 		".zip":     {},
 		".bz":      {},
+		".bz2":     {},
 		".gz":      {},
+		".xz":      {},
+		".zst":     {},
 		".iso":     {},
 		".rar":     {},
 		".7z":      {},
 		".tar":     {},
+		".tgz":     {},
+		".tbz2":    {},
+		".txz":     {},
+		".tzst":    {},
 		".tar.gz":  {},
 		".tar.bz2": {},
 	}
@@ -157,6 +157,9 @@ func TestIsExtensionExtractable(t *testing.T) {
 		{".bz", true},
 		{".gz", true},
 		{".iso", true},
+		{".xz", true},
+		{".zst", true},
+		{".tgz", true},
 	}
 
 	for _, tt := range inputs {
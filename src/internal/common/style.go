@@ -41,6 +41,27 @@ var (
 	FilePanelSelectBoxStyle        lipgloss.Style
 )
 
+var (
+	TabStyle       lipgloss.Style
+	TabActiveStyle lipgloss.Style
+)
+
+var (
+	GitModifiedStyle   lipgloss.Style
+	GitStagedStyle     lipgloss.Style
+	GitUntrackedStyle  lipgloss.Style
+	GitIgnoredStyle    lipgloss.Style
+	GitConflictedStyle lipgloss.Style
+)
+
+var (
+	CompareSameStyle      lipgloss.Style
+	CompareDifferentStyle lipgloss.Style
+	CompareOnlyHereStyle  lipgloss.Style
+	CompareNewerStyle     lipgloss.Style
+	CompareOlderStyle     lipgloss.Style
+)
+
 var (
 	ProcessErrorStyle       lipgloss.Style
 	ProcessInOperationStyle lipgloss.Style
@@ -196,6 +217,25 @@ func LoadThemeConfig() { //nolint: funlen // Variable initialization
 		Background(filePanelItemSelectedBGColor)
 	FilePanelSelectBoxStyle = lipgloss.NewStyle().Background(FilePanelBGColor)
 
+	// Tab Strip Style
+	TabStyle = lipgloss.NewStyle().Foreground(FullScreenFGColor).Background(FullScreenBGColor)
+	TabActiveStyle = lipgloss.NewStyle().Foreground(filePanelItemSelectedFGColor).
+		Background(filePanelItemSelectedBGColor).Bold(true)
+
+	// Git Status Style
+	GitModifiedStyle = lipgloss.NewStyle().Foreground(hintColor).Background(FilePanelBGColor)
+	GitStagedStyle = lipgloss.NewStyle().Foreground(correctColor).Background(FilePanelBGColor)
+	GitUntrackedStyle = lipgloss.NewStyle().Foreground(cancelColor).Background(FilePanelBGColor)
+	GitIgnoredStyle = lipgloss.NewStyle().Foreground(sidebarDividerColor).Background(FilePanelBGColor)
+	GitConflictedStyle = lipgloss.NewStyle().Foreground(errorColor).Background(FilePanelBGColor)
+
+	// Directory Comparison Style
+	CompareSameStyle = lipgloss.NewStyle().Foreground(sidebarDividerColor).Background(FilePanelBGColor)
+	CompareDifferentStyle = lipgloss.NewStyle().Foreground(errorColor).Background(FilePanelBGColor)
+	CompareOnlyHereStyle = lipgloss.NewStyle().Foreground(cancelColor).Background(FilePanelBGColor)
+	CompareNewerStyle = lipgloss.NewStyle().Foreground(correctColor).Background(FilePanelBGColor)
+	CompareOlderStyle = lipgloss.NewStyle().Foreground(hintColor).Background(FilePanelBGColor)
+
 	// Sidebar Special Style
 	SidebarDividerStyle = lipgloss.NewStyle().Foreground(sidebarDividerColor).Background(SidebarBGColor)
 	SidebarTitleStyle = lipgloss.NewStyle().Foreground(sidebarTitleColor).Background(SidebarBGColor)
@@ -182,6 +182,19 @@ func GeneratePinnedRenameTextInput(cursorPos int, defaultValue string) textinput
 	return ti
 }
 
+func GenerateTabRenameTextInput(defaultValue string, width int) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "Tab name"
+	setTextInputStyles(&ti, TabActiveStyle, TabActiveStyle)
+	ti.SetValue(defaultValue)
+	ti.CursorEnd()
+	ti.Focus()
+	ti.CharLimit = 156
+	ti.SetWidth(width)
+	return ti
+}
+
 func GenerateFooterBorder(countString string, width int) string {
 	repeatCount := width - len(countString)
 	if repeatCount < 0 {
@@ -1,5 +1,7 @@
 package common
 
+import "fmt"
+
 // Placeholder inteface for now, might later move 'model' type to commons and have
 // and add an execute(model) function to this
 type ModelAction interface {
@@ -44,3 +46,47 @@ type OpenPanelAction struct {
 func (o OpenPanelAction) String() string {
 	return "OpenPanelAction at " + o.Location
 }
+
+type OpenTrashBinAction struct{}
+
+// ShowFileAction moves the current panel to the directory of the file, with
+// the cursor on it
+type ShowFileAction struct {
+	Path string
+}
+
+func (s ShowFileAction) String() string {
+	return "ShowFileAction for " + s.Path
+}
+
+// EditFileAction opens the file in the editor, at the line if it isn't 0
+type EditFileAction struct {
+	Path string
+	Line int
+}
+
+func (e EditFileAction) String() string {
+	return fmt.Sprintf("EditFileAction for %s at line %d", e.Path, e.Line)
+}
+
+func (o OpenTrashBinAction) String() string {
+	return "OpenTrashBinAction"
+}
+
+// SaveSessionAction saves the panels as the session Name
+type SaveSessionAction struct {
+	Name string
+}
+
+func (s SaveSessionAction) String() string {
+	return "SaveSessionAction as " + s.Name
+}
+
+// LoadSessionAction replaces the panels with the ones of the session Name
+type LoadSessionAction struct {
+	Name string
+}
+
+func (l LoadSessionAction) String() string {
+	return "LoadSessionAction of " + l.Name
+}
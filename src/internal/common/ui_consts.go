@@ -27,6 +27,9 @@ const (
 	FileNameRatioMin = 25
 	FileNameRatioMax = 100
 
+	RecursiveSearchDepthMin = 1
+	RecursiveSearchDepthMax = 64
+
 	RequiredGradientColorCount = 2
 
 	// UI positioning
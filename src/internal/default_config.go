@@ -5,14 +5,24 @@ import (
 
 	"github.com/atotto/clipboard"
 
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/frecency"
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/marks"
 	"github.com/yorukot/superfile/src/internal/ui/helpmenu"
 
+	"github.com/yorukot/superfile/src/internal/ui/bulkrename"
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
+	"github.com/yorukot/superfile/src/internal/ui/contentsearch"
+	"github.com/yorukot/superfile/src/internal/ui/extractmodel"
 	"github.com/yorukot/superfile/src/internal/ui/filemodel"
+	"github.com/yorukot/superfile/src/internal/ui/permissionmodel"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 
 	"github.com/yorukot/superfile/src/internal/ui/metadata"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 	"github.com/yorukot/superfile/src/internal/ui/sidebar"
+	"github.com/yorukot/superfile/src/internal/ui/trashbin"
 
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui/prompt"
@@ -30,6 +40,7 @@ import (
 //     to prevent noise in test logs. Same with imagePreviewer
 func defaultModelConfig(toggleDotFile, toggleFooter, firstUse bool,
 	firstPanelPaths []string, zClient *zoxidelib.Client) *model {
+	frecencyDB := frecency.New(variable.FrecencyFile)
 	return &model{
 		focusPanel:      nonePanelFocus,
 		processBarModel: processbar.New(),
@@ -39,12 +50,28 @@ func defaultModelConfig(toggleDotFile, toggleFooter, firstUse bool,
 		fileModel:       filemodel.New(firstPanelPaths, toggleDotFile),
 		helpMenu:        helpmenu.New(),
 		promptModal:     prompt.DefaultModel(prompt.PromptMinHeight, prompt.PromptMinWidth),
-		zoxideModal:     zoxideui.DefaultModel(zoxideui.ZoxideMinHeight, zoxideui.ZoxideMinWidth, zClient),
+		zoxideModal: zoxideui.DefaultModel(zoxideui.ZoxideMinHeight, zoxideui.ZoxideMinWidth,
+			zClient, frecencyDB),
 		sortModal:       sortmodel.New(),
-		zClient:         zClient,
-		modelQuitState:  notQuitting,
-		toggleFooter:    toggleFooter,
-		firstUse:        firstUse,
-		hasTrash:        common.InitTrash(),
+		compressModal:   compressmodel.New(),
+		extractModal:    extractmodel.New(),
+		trashBin:        trashbin.New(trashbin.TrashBinMinHeight, trashbin.TrashBinMinWidth),
+		bulkRenameModal: bulkrename.New(bulkrename.BulkRenameMinHeight, bulkrename.BulkRenameMinWidth),
+		permissionModal: permissionmodel.New(),
+		contentSearchModal: contentsearch.New(contentsearch.ContentSearchMinHeight,
+			contentsearch.ContentSearchMinWidth),
+		zClient:        zClient,
+		frecencyDB:     frecencyDB,
+		journal:        journal.New(variable.JournalFile),
+		modelQuitState: notQuitting,
+		toggleFooter:   toggleFooter,
+		firstUse:       firstUse,
+		hasTrash:       common.InitTrash(),
+		gitStatus:      newGitStatusCache(),
+		dirSizes:       newDirSizeCache(),
+		sortMemory:     newSortMemory(),
+		sessionFile:    variable.SessionFile,
+		sessionsDir:    variable.SessionsDir,
+		marks:          marks.New(variable.MarksFile),
 	}
 }
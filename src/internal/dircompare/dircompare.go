@@ -0,0 +1,242 @@
+// Package dircompare compares two directory trees, to show what differs
+// between them in the file panels. The trees are walked in the background,
+// and the items of each tree are given a status relative to the other one.
+package dircompare
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// Status of an item relative to the item at the same path in the other tree
+type Status uint8
+
+const (
+	// Same size, and same content when compared by content
+	Same Status = iota
+	// Different content, with the same modification time. A directory is
+	// different when anything below it is
+	Different
+	// There is no item at this path in the other tree
+	OnlyHere
+	// Different content, modified after the other item
+	Newer
+	// Different content, modified before the other item
+	Older
+)
+
+func (s Status) String() string {
+	switch s {
+	case Same:
+		return "Same"
+	case Different:
+		return "Different"
+	case OnlyHere:
+		return "OnlyHere"
+	case Newer:
+		return "Newer"
+	case Older:
+		return "Older"
+	default:
+		return "Invalid"
+	}
+}
+
+var (
+	errSameDir   = errors.New("cannot compare a directory with itself")
+	errNestedDir = errors.New("cannot compare a directory with a directory inside it")
+)
+
+// Comparison is the comparison of the trees at Left and Right. It is not safe
+// for concurrent use, except for Run
+type Comparison struct {
+	Left  string
+	Right string
+	// The files of the same size are also compared by the hash of their content
+	ByContent bool
+
+	// Result of the last walk, nil until the first one is done
+	result *Result
+	// The result is outdated, and a new walk is running
+	running bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Result is the status of the items of both trees, from the side of Left
+type Result struct {
+	entries map[string]entry
+	// Count of the items that differ, the content of directories that exist
+	// on one side only left out
+	differences int
+}
+
+// Status of an item of Left, relative to the item of Right
+type entry uint8
+
+const (
+	entrySame entry = iota
+	entryDifferent
+	entryOnlyLeft
+	entryOnlyRight
+	entryLeftNewer
+	entryRightNewer
+)
+
+// New returns the comparison of the directories left and right, to start
+// with Run
+func New(left, right string, byContent bool) (*Comparison, error) {
+	left, right = filepath.Clean(left), filepath.Clean(right)
+	if left == right {
+		return nil, errSameDir
+	}
+	if isBelow(left, right) || isBelow(right, left) {
+		return nil, errNestedDir
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Comparison{
+		Left:      left,
+		Right:     right,
+		ByContent: byContent,
+		running:   true,
+		ctx:       ctx,
+		cancel:    cancel,
+	}, nil
+}
+
+// Run walks both trees. Returns an error if one of them cannot be read, or if
+// the comparison was cancelled
+func (c *Comparison) Run() (*Result, error) {
+	w := walker{
+		ctx:       c.ctx,
+		left:      c.Left,
+		right:     c.Right,
+		byContent: c.ByContent,
+		result:    &Result{entries: make(map[string]entry)},
+	}
+	if err := w.checkRoots(); err != nil {
+		return nil, err
+	}
+	if _, err := w.compareDirs("."); err != nil {
+		return nil, err
+	}
+	return w.result, nil
+}
+
+// Restart cancels the walk and returns a new comparison of the same trees,
+// that keeps showing the current result until it is done
+func (c *Comparison) Restart() *Comparison {
+	c.Cancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Comparison{
+		Left:      c.Left,
+		Right:     c.Right,
+		ByContent: c.ByContent,
+		result:    c.result,
+		running:   true,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func (c *Comparison) Cancel() {
+	c.cancel()
+}
+
+// SetResult sets the result of Run
+func (c *Comparison) SetResult(result *Result) {
+	c.result = result
+	c.running = false
+}
+
+// Running tells whether the trees are being walked. c can be nil
+func (c *Comparison) Running() bool {
+	return c != nil && c.running
+}
+
+// Contains tells whether path is in one of the trees
+func (c *Comparison) Contains(path string) bool {
+	return path == c.Left || path == c.Right || isBelow(c.Left, path) || isBelow(c.Right, path)
+}
+
+// StatusOf returns the status of the item at path, relative to the item at
+// the same path in the other tree. It is false if path is not in one of the
+// trees, or until the result of the first walk. c can be nil
+func (c *Comparison) StatusOf(path string) (Status, bool) {
+	if c == nil || c.result == nil {
+		return Same, false
+	}
+	rel, left := c.relPath(path)
+	if rel == "" {
+		return Same, false
+	}
+	// The content of an item on one side only is not listed
+	for ancestor := rel; ; ancestor = filepath.Dir(ancestor) {
+		e, ok := c.result.entries[ancestor]
+		if ok && (ancestor == rel || e == entryOnlyLeft || e == entryOnlyRight) {
+			return e.statusFrom(left), true
+		}
+		if ancestor == "." {
+			return Same, false
+		}
+	}
+}
+
+// Differences returns the count of the items that differ, or -1 until the
+// result of the first walk
+func (c *Comparison) Differences() int {
+	if c == nil || c.result == nil {
+		return -1
+	}
+	return c.result.differences
+}
+
+// relPath returns the path relative to the root of its tree, and whether it
+// is the left one. The path is empty if it is in neither
+func (c *Comparison) relPath(path string) (string, bool) {
+	for _, root := range []string{c.Left, c.Right} {
+		if path != root && !isBelow(root, path) {
+			continue
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return "", false
+		}
+		return rel, root == c.Left
+	}
+	return "", false
+}
+
+func (e entry) statusFrom(left bool) Status {
+	switch e {
+	case entrySame:
+		return Same
+	case entryOnlyLeft, entryOnlyRight:
+		// Only the items of a side are shown on that side
+		return OnlyHere
+	case entryLeftNewer:
+		if left {
+			return Newer
+		}
+		return Older
+	case entryRightNewer:
+		if left {
+			return Older
+		}
+		return Newer
+	default:
+		return Different
+	}
+}
+
+// isBelow tells whether path is strictly below dir
+func isBelow(dir, path string) bool {
+	// A root, like / or C:\, already ends with a separator
+	if filepath.Dir(dir) == dir {
+		return path != dir && strings.HasPrefix(path, dir)
+	}
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
@@ -0,0 +1,121 @@
+package dircompare
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// setupTrees creates two trees differing in every way compared
+func setupTrees(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	left := filepath.Join(root, "left")
+	right := filepath.Join(root, "right")
+	utils.SetupDirectories(t, filepath.Join(left, "same_dir"), filepath.Join(right, "same_dir"),
+		filepath.Join(left, "diff_dir"), filepath.Join(right, "diff_dir"),
+		filepath.Join(left, "only_dir", "nested"))
+
+	utils.SetupFilesWithData(t, []byte("12345"),
+		filepath.Join(left, "same_dir", "file"), filepath.Join(right, "same_dir", "file"),
+		filepath.Join(left, "only_dir", "nested", "file"), filepath.Join(right, "only_right"),
+		filepath.Join(left, "diff_dir", "same_size"), filepath.Join(right, "newer"))
+	utils.SetupFilesWithData(t, []byte("abcde"), filepath.Join(right, "diff_dir", "same_size"))
+	utils.SetupFilesWithData(t, []byte("123"), filepath.Join(left, "newer"))
+
+	older := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(right, "newer"), older, older))
+	return left, right
+}
+
+func runComparison(t *testing.T, left, right string, byContent bool) *Comparison {
+	t.Helper()
+	c, err := New(left, right, byContent)
+	require.NoError(t, err)
+	result, err := c.Run()
+	require.NoError(t, err)
+	c.SetResult(result)
+	return c
+}
+
+func TestCompare(t *testing.T) {
+	left, right := setupTrees(t)
+	testdata := []struct {
+		name           string
+		byContent      bool
+		path           string
+		expectedStatus Status
+	}{
+		{"Same directory", false, filepath.Join(left, "same_dir"), Same},
+		{"Same file", false, filepath.Join(right, "same_dir", "file"), Same},
+		{"Only on the left", false, filepath.Join(left, "only_dir"), OnlyHere},
+		{"Content of an item on one side", false, filepath.Join(left, "only_dir", "nested", "file"), OnlyHere},
+		{"Only on the right", false, filepath.Join(right, "only_right"), OnlyHere},
+		{"Newer", false, filepath.Join(left, "newer"), Newer},
+		{"Older", false, filepath.Join(right, "newer"), Older},
+		{"Same size", false, filepath.Join(left, "diff_dir", "same_size"), Same},
+		{"Same size, by content", true, filepath.Join(left, "diff_dir", "same_size"), Different},
+		{"Directory with a difference", true, filepath.Join(right, "diff_dir"), Different},
+		{"Same file, by content", true, filepath.Join(left, "same_dir", "file"), Same},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			c := runComparison(t, left, right, tt.byContent)
+			status, ok := c.StatusOf(tt.path)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+
+	c := runComparison(t, left, right, false)
+	assert.Equal(t, 3, c.Differences(), "only_dir, only_right and newer")
+	_, ok := c.StatusOf(filepath.Join(left, "missing"))
+	assert.False(t, ok)
+	_, ok = c.StatusOf(filepath.Dir(left))
+	assert.False(t, ok, "outside of the trees")
+	assert.Equal(t, 4, runComparison(t, left, right, true).Differences())
+}
+
+func TestComparisonLifecycle(t *testing.T) {
+	left, right := setupTrees(t)
+	_, err := New(left, left+string(filepath.Separator), false)
+	require.ErrorIs(t, err, errSameDir)
+	_, err = New(left, filepath.Join(left, "same_dir"), false)
+	require.ErrorIs(t, err, errNestedDir)
+	_, err = New(left+"_other", left, false)
+	require.NoError(t, err, "a sibling with the same prefix is not nested")
+
+	c, err := New(left, right, false)
+	require.NoError(t, err)
+	assert.True(t, c.Running())
+	assert.Equal(t, -1, c.Differences())
+	_, ok := c.StatusOf(filepath.Join(left, "newer"))
+	assert.False(t, ok, "no status until the first result")
+	assert.True(t, c.Contains(filepath.Join(right, "same_dir")))
+	assert.False(t, c.Contains(right+"_other"))
+
+	result, err := c.Run()
+	require.NoError(t, err)
+	c.SetResult(result)
+	assert.False(t, c.Running())
+
+	restarted := c.Restart()
+	_, err = c.Run()
+	require.ErrorIs(t, err, context.Canceled)
+	assert.True(t, restarted.Running())
+	status, ok := restarted.StatusOf(filepath.Join(left, "newer"))
+	require.True(t, ok, "the previous result is kept while running")
+	assert.Equal(t, Newer, status)
+
+	missing, err := New(left, filepath.Join(right, "missing"), false)
+	require.NoError(t, err)
+	_, err = missing.Run()
+	require.Error(t, err)
+}
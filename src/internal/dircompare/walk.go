@@ -0,0 +1,209 @@
+package dircompare
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Size of the chunks the files are hashed by, between checks of the context
+const hashChunkSize = 1 << 20
+
+type walker struct {
+	ctx       context.Context
+	left      string
+	right     string
+	byContent bool
+	result    *Result
+}
+
+func (w *walker) checkRoots() error {
+	for _, root := range []string{w.left, w.right} {
+		info, err := os.Stat(root)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", root)
+		}
+	}
+	return nil
+}
+
+// compareDirs compares the content of the directories at rel in both trees,
+// and records the status of each item. A directory that cannot be read on a
+// side is different, as its content is not known
+func (w *walker) compareDirs(rel string) (entry, error) {
+	if err := w.ctx.Err(); err != nil {
+		return entrySame, err
+	}
+	leftItems, leftErr := readDir(filepath.Join(w.left, rel))
+	rightItems, rightErr := readDir(filepath.Join(w.right, rel))
+	if leftErr != nil || rightErr != nil {
+		slog.Debug("Error while reading directories to compare", "path", rel,
+			"left error", leftErr, "right error", rightErr)
+		w.record(rel, entryDifferent)
+		return entryDifferent, nil
+	}
+
+	names := make([]string, 0, len(leftItems)+len(rightItems))
+	for name := range leftItems {
+		names = append(names, name)
+	}
+	for name := range rightItems {
+		if _, ok := leftItems[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	status := entrySame
+	for _, name := range names {
+		itemRel := filepath.Join(rel, name)
+		left, inLeft := leftItems[name]
+		right, inRight := rightItems[name]
+		var itemStatus entry
+		switch {
+		case !inRight:
+			itemStatus = entryOnlyLeft
+			w.record(itemRel, itemStatus)
+		case !inLeft:
+			itemStatus = entryOnlyRight
+			w.record(itemRel, itemStatus)
+		default:
+			var err error
+			if itemStatus, err = w.compareItems(itemRel, left, right); err != nil {
+				return entrySame, err
+			}
+		}
+		if itemStatus != entrySame {
+			status = entryDifferent
+		}
+	}
+	w.result.entries[rel] = status
+	return status, nil
+}
+
+// compareItems compares the items at rel in both trees, and records their
+// status. The links are compared by their target, and are not followed
+func (w *walker) compareItems(rel string, left, right fs.FileInfo) (entry, error) {
+	leftType, rightType := left.Mode().Type(), right.Mode().Type()
+	switch {
+	case leftType != rightType:
+		w.record(rel, entryDifferent)
+		return entryDifferent, nil
+	case left.IsDir():
+		return w.compareDirs(rel)
+	case leftType == fs.ModeSymlink:
+		leftTarget, leftErr := os.Readlink(filepath.Join(w.left, rel))
+		rightTarget, rightErr := os.Readlink(filepath.Join(w.right, rel))
+		status := entrySame
+		if leftErr != nil || rightErr != nil || leftTarget != rightTarget {
+			status = entryDifferent
+		}
+		w.record(rel, status)
+		return status, nil
+	case leftType != 0:
+		// Devices, pipes and sockets have no content to compare
+		w.record(rel, entrySame)
+		return entrySame, nil
+	}
+
+	status, err := w.compareFiles(rel, left, right)
+	if err != nil {
+		return entrySame, err
+	}
+	w.record(rel, status)
+	return status, nil
+}
+
+// compareFiles compares the files at rel by size, and by content if
+// requested. The modification times only tell which of the files that differ
+// is newer: a copy is newer than the file it was copied from
+func (w *walker) compareFiles(rel string, left, right fs.FileInfo) (entry, error) {
+	same := left.Size() == right.Size()
+	if same && w.byContent {
+		var err error
+		same, err = w.sameContent(rel)
+		if ctxErr := w.ctx.Err(); ctxErr != nil {
+			return entrySame, ctxErr
+		}
+		if err != nil {
+			slog.Debug("Error while comparing the content of files", "path", rel, "error", err)
+		}
+	}
+	switch {
+	case same:
+		return entrySame, nil
+	case left.ModTime().After(right.ModTime()):
+		return entryLeftNewer, nil
+	case left.ModTime().Before(right.ModTime()):
+		return entryRightNewer, nil
+	default:
+		return entryDifferent, nil
+	}
+}
+
+func (w *walker) sameContent(rel string) (bool, error) {
+	leftHash, err := hashFile(w.ctx, filepath.Join(w.left, rel))
+	if err != nil {
+		return false, err
+	}
+	rightHash, err := hashFile(w.ctx, filepath.Join(w.right, rel))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(leftHash, rightHash), nil
+}
+
+// record sets the status of the item at rel, and counts it if it differs
+func (w *walker) record(rel string, status entry) {
+	w.result.entries[rel] = status
+	if status != entrySame {
+		w.result.differences++
+	}
+}
+
+func readDir(path string) (map[string]fs.FileInfo, error) {
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string]fs.FileInfo, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			// Removed since it was listed
+			continue
+		}
+		items[dirEntry.Name()] = info
+	}
+	return items, nil
+}
+
+func hashFile(ctx context.Context, path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(hash, file, hashChunkSize); errors.Is(err, io.EOF) {
+			return hash.Sum(nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
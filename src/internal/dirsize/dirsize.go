@@ -0,0 +1,143 @@
+// Package dirsize computes the total size of the directories shown in the
+// file panels. The sizes are computed in the background, one directory at a
+// time for each panel, and cached by path and modification time.
+package dirsize
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Walk returns the total size of the files below path. Unreadable items are
+// left out of the total. Returns an error only if ctx is done
+func Walk(ctx context.Context, path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(itemPath string, entry os.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			slog.Debug("Error while computing directory size", "path", itemPath, "error", err)
+			return nil
+		}
+		if !entry.IsDir() {
+			if info, infoErr := entry.Info(); infoErr == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size, err
+}
+
+// Cache keeps the sizes computed, and the walks running. It is not safe for
+// concurrent use, except for Job.Run
+type Cache struct {
+	sizes map[string]entry
+	// Running walks, by the directory of the panel they are for
+	jobs map[string]*Job
+}
+
+type entry struct {
+	size int64
+	// Modification time of the directory when its size was computed
+	modTime time.Time
+}
+
+// Job is the walk of a directory, for a panel
+type Job struct {
+	// Location of the panel
+	Dir string
+	// Directory walked
+	Path    string
+	ModTime time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		sizes: make(map[string]entry),
+		jobs:  make(map[string]*Job),
+	}
+}
+
+// Size returns the size computed for the directory at path, if it has not
+// been modified since. c can be nil
+func (c *Cache) Size(path string, modTime time.Time) (int64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	cached, ok := c.sizes[path]
+	if !ok || !cached.modTime.Equal(modTime) {
+		return 0, false
+	}
+	return cached.size, true
+}
+
+// Running tells whether a walk is running for the panel at dir
+func (c *Cache) Running(dir string) bool {
+	_, ok := c.jobs[dir]
+	return ok
+}
+
+// Start registers the walk of the directory at path for the panel at dir.
+// The walk is done by calling Run, and its result given to Finish
+func (c *Cache) Start(dir, path string, modTime time.Time) *Job {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &Job{Dir: dir, Path: path, ModTime: modTime, ctx: ctx, cancel: cancel}
+	c.jobs[dir] = job
+	return job
+}
+
+// Run walks the directory. It is safe to call outside of the Update
+func (j *Job) Run() (int64, error) {
+	defer j.cancel()
+	return Walk(j.ctx, j.Path)
+}
+
+// Finish saves the result of job
+func (c *Cache) Finish(job *Job, size int64, err error) {
+	if c.jobs[job.Dir] == job {
+		delete(c.jobs, job.Dir)
+	}
+	if err != nil {
+		return
+	}
+	c.sizes[job.Path] = entry{size: size, modTime: job.ModTime}
+}
+
+// CancelExcept cancels the walks of the panels that are not at one of dirs,
+// as they navigated away
+func (c *Cache) CancelExcept(dirs []string) {
+	for dir, job := range c.jobs {
+		if !slices.Contains(dirs, dir) {
+			slog.Debug("Cancelling directory size walk", "dir", dir, "path", job.Path)
+			job.cancel()
+			delete(c.jobs, dir)
+		}
+	}
+}
+
+// Invalidate drops the sizes of the directories containing the paths
+func (c *Cache) Invalidate(paths []string) {
+	for dir := range c.sizes {
+		for _, path := range paths {
+			if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+				delete(c.sizes, dir)
+				break
+			}
+		}
+	}
+}
+
+// InvalidateAll drops all the sizes
+func (c *Cache) InvalidateAll() {
+	clear(c.sizes)
+}
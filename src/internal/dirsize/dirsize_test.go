@@ -0,0 +1,89 @@
+package dirsize
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestWalk(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	utils.SetupDirectories(t, nested)
+	utils.SetupFilesWithData(t, []byte("12345"), filepath.Join(root, "file1"), filepath.Join(nested, "file2"))
+	utils.SetupFilesWithData(t, []byte("12"), filepath.Join(root, "a", "file3"))
+
+	size, err := Walk(t.Context(), root)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), size)
+
+	size, err = Walk(t.Context(), filepath.Join(root, "missing"))
+	require.NoError(t, err, "unreadable items are left out")
+	assert.Equal(t, int64(0), size)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = Walk(ctx, root)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCache(t *testing.T) {
+	root := t.TempDir()
+	dir1 := filepath.Join(root, "dir1")
+	utils.SetupDirectories(t, dir1)
+	utils.SetupFilesWithData(t, []byte("12345"), filepath.Join(dir1, "file1"))
+	modTime := time.Now()
+
+	c := NewCache()
+	_, ok := c.Size(dir1, modTime)
+	assert.False(t, ok)
+
+	job := c.Start(root, dir1, modTime)
+	assert.True(t, c.Running(root))
+	size, err := job.Run()
+	c.Finish(job, size, err)
+	assert.False(t, c.Running(root))
+
+	size, ok = c.Size(dir1, modTime)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), size)
+	_, ok = c.Size(dir1, modTime.Add(time.Second))
+	assert.False(t, ok, "the directory was modified since")
+
+	t.Run("Invalidate", func(t *testing.T) {
+		c.Invalidate([]string{filepath.Join(root, "other")})
+		_, ok = c.Size(dir1, modTime)
+		assert.True(t, ok)
+		c.Invalidate([]string{filepath.Join(dir1, "file2")})
+		_, ok = c.Size(dir1, modTime)
+		assert.False(t, ok)
+	})
+
+	t.Run("Cancelled when navigating away", func(t *testing.T) {
+		job := c.Start(root, dir1, modTime)
+		c.CancelExcept([]string{root})
+		assert.True(t, c.Running(root))
+		c.CancelExcept([]string{dir1})
+		assert.False(t, c.Running(root))
+
+		// The result of the cancelled walk does not end the new one
+		newJob := c.Start(root, dir1, modTime)
+		size, err := job.Run()
+		require.ErrorIs(t, err, context.Canceled)
+		c.Finish(job, size, err)
+		assert.True(t, c.Running(root))
+		_, ok = c.Size(dir1, modTime)
+		assert.False(t, ok)
+		newJob.cancel()
+	})
+
+	var nilCache *Cache
+	_, ok = nilCache.Size(dir1, modTime)
+	assert.False(t, ok)
+}
@@ -1,17 +1,26 @@
 package internal
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
 
 	"github.com/yorukot/superfile/src/pkg/utils"
 
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 )
 
@@ -152,29 +161,103 @@ func TestZipSourcesInvalidTarget(t *testing.T) {
 	require.Error(t, err, "zipSources should return error for invalid target")
 }
 
-func TestGetZipArchiveName(t *testing.T) {
+func TestTarSources(t *testing.T) {
+	processBar := processbar.New()
+	processBar.ListenForChannelUpdates()
+	t.Cleanup(processBar.SendStopListeningMsgBlocking)
+
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	script := filepath.Join(contentDir, "script.sh")
+	utils.SetupDirectories(t, contentDir)
+	utils.SetupFilesWithData(t, []byte("echo"), script)
+	require.NoError(t, os.Chmod(script, 0o755))
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	require.NoError(t, os.Chtimes(script, modTime, modTime))
+	hasSymlink := runtime.GOOS != utils.OsWindows
+	if hasSymlink {
+		require.NoError(t, os.Symlink("script.sh", filepath.Join(contentDir, "link")))
+	}
+
+	decompressors := map[compressmodel.Format]func(r io.Reader) (io.Reader, error){
+		compressmodel.FormatTar: func(r io.Reader) (io.Reader, error) { return r, nil },
+		compressmodel.FormatTarGz: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+		compressmodel.FormatTarXz: func(r io.Reader) (io.Reader, error) {
+			return xz.NewReader(r)
+		},
+		compressmodel.FormatTarZst: func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r)
+		},
+	}
+	for format, decompress := range decompressors {
+		t.Run(format.String(), func(t *testing.T) {
+			target := filepath.Join(tempDir, "test"+format.Extension())
+			require.NoError(t, compressSources([]string{contentDir}, target, format, &processBar))
+
+			f, err := os.Open(target)
+			require.NoError(t, err)
+			defer f.Close()
+			r, err := decompress(f)
+			require.NoError(t, err)
+			headers := make(map[string]*tar.Header)
+			contents := make(map[string]string)
+			tr := tar.NewReader(r)
+			for {
+				hdr, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				headers[hdr.Name] = hdr
+				content, err := io.ReadAll(tr)
+				require.NoError(t, err)
+				contents[hdr.Name] = string(content)
+			}
+
+			require.Contains(t, headers, "content/")
+			require.Contains(t, headers, "content/script.sh")
+			assert.Equal(t, "echo", contents["content/script.sh"])
+			assert.True(t, modTime.Equal(headers["content/script.sh"].ModTime), "mtime is kept")
+			if hasSymlink {
+				assert.Equal(t, int64(0o755), headers["content/script.sh"].Mode&0o777, "mode bits are kept")
+				require.Contains(t, headers, "content/link")
+				assert.Equal(t, byte(tar.TypeSymlink), headers["content/link"].Typeflag)
+				assert.Equal(t, "script.sh", headers["content/link"].Linkname)
+			}
+		})
+	}
+}
+
+func TestGetArchiveName(t *testing.T) {
 	t.Run("Ordinary file with extension", func(t *testing.T) {
-		actual, err := getZipArchiveName("test.doc")
+		actual, err := getArchiveName("test.doc", ".zip")
 		require.NoError(t, err)
 		require.Equal(t, "test.zip", actual)
 	})
 	t.Run("Ordinary file without extension", func(t *testing.T) {
-		actual, err := getZipArchiveName("test")
+		actual, err := getArchiveName("test", ".zip")
 		require.NoError(t, err)
 		require.Equal(t, "test.zip", actual)
 	})
 	t.Run("Hidden file without extension", func(t *testing.T) {
-		actual, err := getZipArchiveName(".dockerignore")
+		actual, err := getArchiveName(".dockerignore", ".zip")
 		require.NoError(t, err)
 		require.Equal(t, ".dockerignore.zip", actual)
 	})
 	t.Run("Hidden file with extension", func(t *testing.T) {
-		actual, err := getZipArchiveName(".dockerignore.old")
+		actual, err := getArchiveName(".dockerignore.old", ".zip")
 		require.NoError(t, err)
 		require.Equal(t, ".dockerignore.zip", actual)
 	})
+	t.Run("Other formats", func(t *testing.T) {
+		actual, err := getArchiveName("test.doc", ".tar.gz")
+		require.NoError(t, err)
+		require.Equal(t, "test.tar.gz", actual)
+	})
 	t.Run("empty filename", func(t *testing.T) {
-		_, err := getZipArchiveName("")
+		_, err := getArchiveName("", ".zip")
 		require.ErrorContains(t, err, "empty filename to compress")
 	})
 }
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+
+	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+type testArchiveEntry struct {
+	name     string
+	content  string
+	linkname string
+}
+
+func writeTestZip(t *testing.T, path string, entries []testArchiveEntry) {
+	t.Helper()
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+	zw := zip.NewWriter(out)
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, e.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func writeTestTar(t *testing.T, path string, entries []testArchiveEntry) {
+	t.Helper()
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+	tw := tar.NewWriter(out)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.linkname != "" {
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.linkname
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = io.WriteString(tw, e.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+// Names of the items in dir, to ensure nothing is left behind
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestExtractArchive(t *testing.T) {
+	processBar := processbar.New()
+	processBar.ListenForChannelUpdates()
+	t.Cleanup(processBar.SendStopListeningMsgBlocking)
+
+	tempDir := t.TempDir()
+	archivePath := filepath.Join(tempDir, "test.tar")
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	out, err := os.Create(archivePath)
+	require.NoError(t, err)
+	tw := tar.NewWriter(out)
+	for _, hdr := range []*tar.Header{
+		{Name: "dir/", Mode: 0o755, Typeflag: tar.TypeDir, ModTime: modTime},
+		{Name: "dir/script.sh", Mode: 0o755, Size: 4, Typeflag: tar.TypeReg, ModTime: modTime},
+		{Name: "file.txt", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg, ModTime: modTime},
+		{Name: "other.txt", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg, ModTime: modTime},
+		{Name: "link", Linkname: "file.txt", Typeflag: tar.TypeSymlink, ModTime: modTime},
+	} {
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err = io.WriteString(tw, "data")
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, out.Close())
+
+	scan, err := scanArchive(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, 5, scan.entries)
+	assert.Equal(t, []string{"dir", "file.txt", "other.txt", "link"}, scan.topLevel)
+
+	dest := filepath.Join(tempDir, "dest")
+	utils.SetupDirectories(t, dest)
+	utils.SetupFilesWithData(t, []byte("old"), filepath.Join(dest, "file.txt"), filepath.Join(dest, "other.txt"))
+	assert.Equal(t, []string{"file.txt", "other.txt"}, findExtractConflicts(dest, scan.topLevel))
+
+	require.NoError(t, extractArchive(archivePath, dest, scan.entries, map[string]conflictResolution{
+		"file.txt":  conflictOverwrite,
+		"other.txt": conflictSkip,
+	}, false, &processBar))
+
+	assertFileContent(t, filepath.Join(dest, "dir", "script.sh"), "data")
+	assertFileContent(t, filepath.Join(dest, "file.txt"), "data")
+	assertFileContent(t, filepath.Join(dest, "other.txt"), "old")
+	info, err := os.Stat(filepath.Join(dest, "dir", "script.sh"))
+	require.NoError(t, err)
+	assert.True(t, modTime.Equal(info.ModTime()), "mtime is kept")
+	info, err = os.Stat(filepath.Join(dest, "dir"))
+	require.NoError(t, err)
+	assert.True(t, modTime.Equal(info.ModTime()), "mtime of directories is kept")
+	if runtime.GOOS != utils.OsWindows {
+		info, err = os.Stat(filepath.Join(dest, "dir", "script.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0o755), info.Mode().Perm(), "mode bits are kept")
+		target, err := os.Readlink(filepath.Join(dest, "link"))
+		require.NoError(t, err)
+		assert.Equal(t, "file.txt", target)
+	}
+	assert.ElementsMatch(t, []string{"dir", "file.txt", "other.txt", "link"}, dirNames(t, dest),
+		"the extraction directory is removed")
+
+	// Items without a resolution are kept as both
+	require.NoError(t, extractArchive(archivePath, dest, scan.entries, nil, false, &processBar))
+	assertFileContent(t, filepath.Join(dest, "file(1).txt"), "data")
+
+	// Only the freedesktop trash can be redirected to a test directory
+	if runtime.GOOS == utils.OsLinux {
+		t.Setenv("XDG_DATA_HOME", filepath.Join(tempDir, "data"))
+		require.NoError(t, extractArchive(archivePath, dest, scan.entries, map[string]conflictResolution{
+			"other.txt": conflictOverwrite,
+		}, true, &processBar))
+		assertFileContent(t, filepath.Join(dest, "other.txt"), "data")
+		items, err := trash.List()
+		require.NoError(t, err)
+		idx := slices.IndexFunc(items, func(item trash.Item) bool {
+			return item.OriginalPath == filepath.Join(dest, "other.txt")
+		})
+		require.NotEqual(t, -1, idx, "overwritten item should be moved to the trash")
+		assertFileContent(t, items[idx].TrashedPath, "old")
+	}
+}
+
+func TestExtractArchiveUnsafeEntries(t *testing.T) {
+	processBar := processbar.New()
+	processBar.ListenForChannelUpdates()
+	t.Cleanup(processBar.SendStopListeningMsgBlocking)
+
+	testdata := []struct {
+		name    string
+		archive string
+		entries []testArchiveEntry
+	}{
+		{
+			name:    "Parent directory",
+			archive: "slip.zip",
+			entries: []testArchiveEntry{{name: "file.txt", content: "ok"}, {name: "../evil.txt", content: "evil"}},
+		},
+		{
+			name:    "Absolute path",
+			archive: "slip.tar",
+			entries: []testArchiveEntry{{name: "/tmp/evil.txt", content: "evil"}},
+		},
+		{
+			name:    "Inside a symlink",
+			archive: "slip.tar",
+			entries: []testArchiveEntry{{name: "link", linkname: ".."}, {name: "link/evil.txt", content: "evil"}},
+		},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			dest := filepath.Join(tempDir, "dest")
+			utils.SetupDirectories(t, dest)
+			archivePath := filepath.Join(tempDir, tt.archive)
+			if filepath.Ext(tt.archive) == ".zip" {
+				writeTestZip(t, archivePath, tt.entries)
+			} else {
+				writeTestTar(t, archivePath, tt.entries)
+			}
+
+			_, err := scanArchive(archivePath)
+			require.ErrorIs(t, err, errUnsafeEntry)
+			err = extractArchive(archivePath, dest, 0, nil, false, &processBar)
+			require.ErrorIs(t, err, errUnsafeEntry)
+			assert.NoFileExists(t, filepath.Join(tempDir, "evil.txt"))
+			assert.Empty(t, dirNames(t, dest), "nothing is extracted")
+		})
+	}
+}
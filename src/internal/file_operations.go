@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -9,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/yorukot/superfile/src/internal/archivefs"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 	"github.com/yorukot/superfile/src/pkg/utils"
 )
@@ -45,7 +48,7 @@ func getDriveLetter(path string) string {
 }
 
 // moveElement moves a file or directory efficiently
-func moveElement(src, dst string) error {
+func moveElement(ctx context.Context, src, dst string) error {
 	// Check if source and destination are on the same partition
 	sameDev, err := isSamePartition(src, dst)
 	if err != nil {
@@ -61,7 +64,7 @@ func moveElement(src, dst string) error {
 	}
 
 	// If on different partitions or rename failed, fall back to copy+delete
-	err = copyElement(src, dst)
+	err = copyElement(ctx, src, dst)
 	if err != nil {
 		return fmt.Errorf("failed to copy: %w", err)
 	}
@@ -75,20 +78,23 @@ func moveElement(src, dst string) error {
 }
 
 // copyElement handles copying of both files and directories
-func copyElement(src, dst string) error {
+func copyElement(ctx context.Context, src, dst string) error {
+	if archivefs.IsVirtual(src) {
+		return archivefs.CopyOutPath(ctx, src, dst)
+	}
 	srcInfo, err := os.Stat(src)
 	if err != nil {
 		return fmt.Errorf("failed to stat source: %w", err)
 	}
 
 	if srcInfo.IsDir() {
-		return copyDir(src, dst, srcInfo)
+		return copyDir(ctx, src, dst, srcInfo)
 	}
-	return copyFile(src, dst, srcInfo)
+	return copyFile(ctx, src, dst, srcInfo)
 }
 
 // copyDir recursively copies a directory
-func copyDir(src, dst string, srcInfo os.FileInfo) error {
+func copyDir(ctx context.Context, src, dst string, srcInfo os.FileInfo) error {
 	err := os.MkdirAll(dst, srcInfo.Mode())
 	if err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
@@ -109,9 +115,9 @@ func copyDir(src, dst string, srcInfo os.FileInfo) error {
 		}
 
 		if entryInfo.IsDir() {
-			err = copyDir(srcPath, dstPath, entryInfo)
+			err = copyDir(ctx, srcPath, dstPath, entryInfo)
 		} else {
-			err = copyFile(srcPath, dstPath, entryInfo)
+			err = copyFile(ctx, srcPath, dstPath, entryInfo)
 		}
 		if err != nil {
 			return err
@@ -133,8 +139,22 @@ func isSymlink(info os.FileInfo) bool {
 	return info.Mode()&os.ModeSymlink != 0
 }
 
+// contextReader makes long copies respond to the process bar actions. It
+// blocks while the process is paused, and fails once it is cancelled
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := processbar.Checkpoint(r.ctx); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
+
 // copyFile copies a single file
-func copyFile(src, dst string, srcInfo os.FileInfo) error {
+func copyFile(ctx context.Context, src, dst string, srcInfo os.FileInfo) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return fmt.Errorf("failed to open source file: %w", err)
@@ -147,17 +167,23 @@ func copyFile(src, dst string, srcInfo os.FileInfo) error {
 	}
 	defer dstFile.Close()
 
-	if _, err := io.Copy(dstFile, srcFile); err != nil {
+	if _, err := io.Copy(dstFile, contextReader{ctx: ctx, reader: srcFile}); err != nil {
+		// Don't leave a partially copied file behind
+		_ = dstFile.Close()
+		if removeErr := os.Remove(dst); removeErr != nil {
+			slog.Error("Failed to remove partially copied file", "path", dst, "error", removeErr)
+		}
 		return fmt.Errorf("failed to copy file contents: %w", err)
 	}
 	return nil
 }
 
 // pasteDir handles directory copying with progress tracking
-func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel *processbar.Model) error {
+// It returns the actual destination path, which differs from dst if dst already existed
+func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel *processbar.Model) (string, error) {
 	dst, err := renameIfDuplicate(dst)
 	if err != nil {
-		return err
+		return dst, err
 	}
 
 	// Check if we can do a fast move within the same partition
@@ -166,7 +192,7 @@ func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel
 		// For cut operations on same partition, try fast rename first
 		err = os.Rename(src, dst)
 		if err == nil {
-			return nil
+			return dst, nil
 		}
 		// If rename fails, fall back to manual copy
 	}
@@ -185,23 +211,26 @@ func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel
 	})
 
 	if err != nil {
-		return err
+		return dst, err
 	}
 
 	// If this was a cut operation and we had to do a manual copy, remove the source
 	if cut && !sameDev {
 		err = os.RemoveAll(src)
 		if err != nil {
-			return fmt.Errorf("failed to remove source after move: %w", err)
+			return dst, fmt.Errorf("failed to remove source after move: %w", err)
 		}
 	}
 
-	return nil
+	return dst, nil
 }
 
 func actualPasteOperation(info os.FileInfo, path string, newPath string, cut bool, sameDev bool,
 	p *processbar.Process, processBarModel *processbar.Model) error {
-	var err error
+	err := processbar.Checkpoint(p.Context())
+	if err != nil {
+		return err
+	}
 	if info.IsDir() {
 		// TODO - this is likely not needed because we did
 		// dst, err := renameIfDuplicate(dst) above
@@ -221,9 +250,12 @@ func actualPasteOperation(info os.FileInfo, path string, newPath string, cut boo
 	if cut && sameDev {
 		err = os.Rename(path, newPath)
 	} else {
-		err = copyFile(path, newPath, info)
+		err = copyFile(p.Context(), path, newPath, info)
 	}
 
+	if errors.Is(err, processbar.ErrProcessCancelled) {
+		return err
+	}
 	if err != nil {
 		p.State = processbar.Failed
 		pSendErr := processBarModel.SendUpdateProcessMsg(*p, true)
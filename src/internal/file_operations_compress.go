@@ -2,6 +2,7 @@ package internal
 
 import (
 	"archive/zip"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,10 +12,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 )
 
+// zipSources is compressSources for zip archives
 func zipSources(sources []string, target string, processBar *processbar.Model) error {
+	return compressSources(sources, target, compressmodel.FormatZip, processBar)
+}
+
+func compressSources(sources []string, target string, format compressmodel.Format,
+	processBar *processbar.Model) error {
 	var err error
 
 	totalFiles := 0
@@ -24,7 +32,7 @@ func zipSources(sources []string, target string, processBar *processbar.Model) e
 		}
 		count, e := countFiles(src)
 		if e != nil {
-			slog.Error("Error while zip file count files ", "error", e)
+			slog.Error("Error while compress file count files ", "error", e)
 		}
 		totalFiles += count
 	}
@@ -48,13 +56,30 @@ func zipSources(sources []string, target string, processBar *processbar.Model) e
 	if err != nil {
 		return err
 	}
+	defer func() {
+		// Runs after the writer and file are closed. Don't leave a partial archive behind
+		if p.State == processbar.Cancelled {
+			if removeErr := os.Remove(target); removeErr != nil {
+				slog.Error("Failed to remove partial archive", "path", target, "error", removeErr)
+			}
+		}
+	}()
 	defer f.Close()
-	writer := zip.NewWriter(f)
-	defer writer.Close()
-
-	zipSourcesCore(sources, processBar, &p, writer)
+	writer, err := newArchiveWriter(f, format)
+	if err != nil {
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	} else {
+		compressSourcesCore(sources, processBar, &p, writer)
+		// Compressed formats write their last blocks on Close
+		if err = writer.Close(); err != nil && p.State == processbar.InOperation {
+			slog.Error("Error while closing archive", "error", err)
+			p.State = processbar.Failed
+			p.ErrorMsg = err.Error()
+		}
+	}
 
-	if p.State != processbar.Failed {
+	if p.State == processbar.InOperation {
 		// TODO: User p.SetSuccessful(), p.SetFailed()
 		p.State = processbar.Successful
 		p.Done = totalFiles
@@ -64,11 +89,17 @@ func zipSources(sources []string, target string, processBar *processbar.Model) e
 	if pSendErr != nil {
 		slog.Error("Error sending process update", "error", pSendErr)
 	}
+	if p.State == processbar.Cancelled {
+		return processbar.ErrProcessCancelled
+	}
+	if p.State == processbar.Failed {
+		return fmt.Errorf("cannot create %s: %s", target, p.ErrorMsg)
+	}
 	return nil
 }
 
-func zipSourcesCore(sources []string, processBar *processbar.Model,
-	p *processbar.Process, writer *zip.Writer) {
+func compressSourcesCore(sources []string, processBar *processbar.Model,
+	p *processbar.Process, writer archiveWriter) {
 	for _, src := range sources {
 		srcParentDir := filepath.Dir(src)
 		err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
@@ -76,12 +107,15 @@ func zipSourcesCore(sources []string, processBar *processbar.Model,
 			if err != nil {
 				return err
 			}
+			if err = processbar.Checkpoint(p.Context()); err != nil {
+				return err
+			}
 			relPath, err := filepath.Rel(srcParentDir, path)
 			if err != nil {
 				return err
 			}
 
-			err = writeZipFile(path, relPath, info, writer)
+			err = writer.addFile(p.Context(), path, relPath, info)
 			if err != nil {
 				return err
 			}
@@ -90,15 +124,20 @@ func zipSourcesCore(sources []string, processBar *processbar.Model,
 			processBar.TrySendingUpdateProcessMsg(*p)
 			return nil
 		})
+		if errors.Is(err, processbar.ErrProcessCancelled) {
+			p.MarkCancelled()
+			break
+		}
 		if err != nil {
-			slog.Error("Error while zip file", "error", err)
+			slog.Error("Error while compressing file", "error", err)
 			p.State = processbar.Failed
+			p.ErrorMsg = formatFileError(p.CurrentFile, err)
 			break
 		}
 	}
 }
 
-func writeZipFile(path string, relPath string, info os.FileInfo, writer *zip.Writer) error {
+func writeZipFile(ctx context.Context, path string, relPath string, info os.FileInfo, writer *zip.Writer) error {
 	header, err := zip.FileInfoHeader(info)
 	if err != nil {
 		return err
@@ -120,22 +159,24 @@ func writeZipFile(path string, relPath string, info os.FileInfo, writer *zip.Wri
 		return err
 	}
 	defer file.Close()
-	_, err = io.Copy(headerWriter, file)
+	_, err = io.Copy(headerWriter, contextReader{ctx: ctx, reader: file})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func getZipArchiveName(base string) (string, error) {
+// getArchiveName returns the name of the archive of the file base. ext is
+// the extension of the archive, like ".tar.gz"
+func getArchiveName(base string, ext string) (string, error) {
 	if len(base) == 0 {
 		return "", errors.New("empty filename to compress")
 	}
-	zipName := strings.TrimSuffix(base, filepath.Ext(base)) + ".zip"
+	archiveName := strings.TrimSuffix(base, filepath.Ext(base)) + ext
 	runes := []rune(base)
 	if runes[0] == '.' && strings.Count(base, ".") == 1 {
-		zipName = base + ".zip"
+		archiveName = base + ext
 	}
-	zipName, err := renameIfDuplicate(zipName)
-	return zipName, err
+	archiveName, err := renameIfDuplicate(archiveName)
+	return archiveName, err
 }
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
+)
+
+// archiveWriter adds the files walked by compressSourcesCore to an archive.
+// Close must be called to finish the archive, even after errors
+type archiveWriter interface {
+	addFile(ctx context.Context, path string, relPath string, info os.FileInfo) error
+	Close() error
+}
+
+func newArchiveWriter(out io.Writer, format compressmodel.Format) (archiveWriter, error) {
+	switch format {
+	case compressmodel.FormatZip:
+		return zipArchiveWriter{zip.NewWriter(out)}, nil
+	case compressmodel.FormatTar:
+		return &tarArchiveWriter{tw: tar.NewWriter(out)}, nil
+	case compressmodel.FormatTarGz:
+		return newTarArchiveWriter(gzip.NewWriter(out)), nil
+	case compressmodel.FormatTarXz:
+		w, err := xz.NewWriter(out)
+		if err != nil {
+			return nil, err
+		}
+		return newTarArchiveWriter(w), nil
+	case compressmodel.FormatTarZst:
+		w, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, err
+		}
+		return newTarArchiveWriter(w), nil
+	}
+	return nil, fmt.Errorf("unsupported compress format %d", format)
+}
+
+type zipArchiveWriter struct {
+	*zip.Writer
+}
+
+func (w zipArchiveWriter) addFile(ctx context.Context, path string, relPath string, info os.FileInfo) error {
+	return writeZipFile(ctx, path, relPath, info, w.Writer)
+}
+
+// Unlike zip, tar keeps the mode bits and symlinks of the files
+type tarArchiveWriter struct {
+	tw *tar.Writer
+	// Compresses the output of tw, nil for plain tar archives
+	compressor io.WriteCloser
+}
+
+func newTarArchiveWriter(compressor io.WriteCloser) *tarArchiveWriter {
+	return &tarArchiveWriter{tw: tar.NewWriter(compressor), compressor: compressor}
+}
+
+func (w *tarArchiveWriter) addFile(ctx context.Context, path string, relPath string, info os.FileInfo) error {
+	if info.Mode()&os.ModeSocket != 0 {
+		// Not supported by tar, and meaningless once the program serving it is gone
+		return nil
+	}
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		var err error
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(relPath)
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err = w.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w.tw, contextReader{ctx: ctx, reader: file})
+	return err
+}
+
+func (w *tarArchiveWriter) Close() error {
+	err := w.tw.Close()
+	if w.compressor != nil {
+		err = errors.Join(err, w.compressor.Close())
+	}
+	return err
+}
@@ -1,39 +1,149 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
+	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
 	"golift.io/xtractr"
 
 	"github.com/yorukot/superfile/src/pkg/utils"
 
+	"github.com/yorukot/superfile/src/internal/archivefs"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 )
 
-func extractCompressFile(src, dest string, processBar *processbar.Model) error {
-	p, err := processBar.SendAddProcessMsg(filepath.Base(src), processbar.OpExtract, 1, true)
+// Returned for the entries that would be written outside of the destination
+var errUnsafeEntry = errors.New("unsafe entry in the archive")
+
+// Prefix of the hidden directory, inside the destination, where the archive
+// is extracted before its items are moved to the destination
+const extractStagingPrefix = ".spf-extract-"
+
+// extractScan is what is known of an archive before extracting it
+type extractScan struct {
+	entries int
+	// Names of the items the archive creates in the destination
+	topLevel []string
+}
+
+// scanArchive reads the entries of the archive without extracting them. It
+// fails with errUnsafeEntry if an entry would be written outside of the destination
+func scanArchive(src string) (extractScan, error) {
+	var scan extractScan
+	checker := newEntryChecker()
+	seen := make(map[string]struct{})
+	err := archivefs.Walk(src, func(e archivefs.Entry, _ io.Reader) error {
+		name, err := checker.check(e)
+		if err != nil {
+			return err
+		}
+		scan.entries++
+		if name == "" {
+			return nil
+		}
+		top, _, _ := strings.Cut(name, "/")
+		if _, ok := seen[top]; !ok {
+			seen[top] = struct{}{}
+			scan.topLevel = append(scan.topLevel, top)
+		}
+		return nil
+	})
+	return scan, err
+}
+
+// entryChecker rejects the entries that would be written outside of the
+// destination, like "../file". The targets of symlinks are not checked, so
+// no entry is written through a symlink of the archive instead
+type entryChecker struct {
+	symlinks map[string]struct{}
+}
+
+func newEntryChecker() entryChecker {
+	return entryChecker{symlinks: make(map[string]struct{})}
+}
+
+// check returns the cleaned, slash separated, name of the entry. It is
+// empty for the entry of the root of the archive, like "./"
+func (c entryChecker) check(e archivefs.Entry) (string, error) {
+	name := path.Clean(strings.ReplaceAll(e.Name, "\\", "/"))
+	if name == "." {
+		return "", nil
+	}
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("%w: %q is outside of the destination", errUnsafeEntry, e.Name)
+	}
+	parent := path.Dir(name)
+	if e.Mode.IsDir() {
+		// The content of a directory is written in it
+		parent = name
+	}
+	for dir := parent; dir != "."; dir = path.Dir(dir) {
+		if _, ok := c.symlinks[dir]; ok {
+			return "", fmt.Errorf("%w: %q is inside the symlink %q", errUnsafeEntry, e.Name, dir)
+		}
+	}
+	if e.Mode&fs.ModeSymlink != 0 {
+		c.symlinks[name] = struct{}{}
+	}
+	return name, nil
+}
+
+// extractArchive extracts the archive src into the directory dest, with one
+// step of progress per entry. The archive is first extracted to a hidden
+// directory in dest, and its items are moved to dest once all are extracted,
+// following the resolutions of their conflicts with the existing items, keyed by
+// name. Items without a resolution are kept as both. The overwritten items are
+// moved to the trash if useTrash is set. entries is the count of entries of
+// the archive, if known
+func extractArchive(src, dest string, entries int, resolutions map[string]conflictResolution,
+	useTrash bool, processBar *processbar.Model) error {
+	p, err := processBar.SendAddProcessMsg(filepath.Base(src), processbar.OpExtract, max(entries, 1), true)
 	if err != nil {
 		return fmt.Errorf("cannot spawn process : %w", err)
 	}
 
-	x := &xtractr.XFile{
-		FilePath:  src,
-		OutputDir: dest,
-		FileMode:  utils.ExtractedFileMode,
-		DirMode:   utils.ExtractedDirMode,
+	staging, err := os.MkdirTemp(dest, extractStagingPrefix)
+	if err == nil {
+		removeStaging := true
+		if archivefs.IsArchive(src) {
+			err = extractEntries(src, staging, &p, processBar)
+		} else {
+			err = extractWithXtractr(src, staging, &p, processBar)
+			// xtractr cannot be interrupted, it removes the directory itself once done
+			removeStaging = !errors.Is(err, processbar.ErrProcessCancelled)
+		}
+		if err == nil {
+			summary := conflictSummary{}
+			err = moveExtractedItems(staging, dest, resolutions, useTrash, summary)
+			p.Summary = summary.String()
+		}
+		if removeStaging {
+			if removeErr := os.RemoveAll(staging); removeErr != nil {
+				slog.Error("Failed to remove extraction directory", "path", staging, "error", removeErr)
+			}
+		}
 	}
 
-	_, _, _, err = xtractr.ExtractFile(x)
-
-	if err != nil {
+	switch {
+	case errors.Is(err, processbar.ErrProcessCancelled):
+		p.MarkCancelled()
+	case err != nil:
 		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
 		slog.Error("Error extracting", "path", src, "error", err)
-	} else {
+	default:
 		p.State = processbar.Successful
-		p.Done = 1
+		p.Done = p.Total
 	}
 
 	p.DoneTime = time.Now()
@@ -44,3 +154,166 @@ func extractCompressFile(src, dest string, processBar *processbar.Model) error {
 
 	return err
 }
+
+// extractEntries writes the entries of the archive src into the empty directory root
+func extractEntries(src, root string, p *processbar.Process, processBar *processbar.Model) error {
+	ctx := p.Context()
+	checker := newEntryChecker()
+	// Writing the content of a directory changes its times, so they are set last
+	type extractedDir struct {
+		path    string
+		modTime time.Time
+	}
+	var dirs []extractedDir
+	err := archivefs.Walk(src, func(e archivefs.Entry, content io.Reader) error {
+		if err := processbar.Checkpoint(ctx); err != nil {
+			return err
+		}
+		name, err := checker.check(e)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			p.CurrentFile = path.Base(name)
+			target := filepath.Join(root, filepath.FromSlash(name))
+			if err := writeExtractedEntry(ctx, target, e, content); err != nil {
+				return err
+			}
+			if e.Mode.IsDir() {
+				dirs = append(dirs, extractedDir{target, e.ModTime})
+			}
+		}
+		p.Done++
+		processBar.TrySendingUpdateProcessMsg(*p)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chtimes(dirs[i].path, dirs[i].modTime, dirs[i].modTime); err != nil {
+			slog.Debug("Cannot set the times of an extracted directory", "path", dirs[i].path, "error", err)
+		}
+	}
+	return nil
+}
+
+// writeExtractedEntry writes the entry at target. An entry that was already
+// written, as archives can have several entries for the same file, is replaced
+func writeExtractedEntry(ctx context.Context, target string, e archivefs.Entry, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), utils.ExtractedDirMode); err != nil {
+		return err
+	}
+	if e.Mode.IsDir() {
+		return os.MkdirAll(target, utils.ExtractedDirMode)
+	}
+	// Never write through a symlink extracted before
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if e.Mode&fs.ModeSymlink != 0 {
+		err := os.Symlink(e.Linkname, target)
+		if err != nil && runtime.GOOS == utils.OsWindows {
+			// Creating symlinks needs privileges on Windows, the rest can still be extracted
+			slog.Warn("Cannot create an extracted symlink", "path", target, "error", err)
+			return nil
+		}
+		return err
+	}
+
+	perm := e.Mode.Perm()
+	if perm == 0 {
+		perm = utils.ExtractedFileMode
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, contextReader{ctx: ctx, reader: content})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if !e.ModTime.IsZero() {
+		return os.Chtimes(target, e.ModTime, e.ModTime)
+	}
+	return nil
+}
+
+// extractWithXtractr extracts the formats archivefs can't read, like rar, to
+// the empty directory root. xtractr checks the paths of the entries itself
+func extractWithXtractr(src, root string, p *processbar.Process, processBar *processbar.Model) error {
+	ctx := p.Context()
+	// Only updated by xtractr while it runs, and read once it's done
+	progressProcess := *p
+	x := &xtractr.XFile{
+		FilePath:  src,
+		OutputDir: root,
+		FileMode:  utils.ExtractedFileMode,
+		DirMode:   utils.ExtractedDirMode,
+		// Called synchronously while extracting, so blocking here pauses the extraction
+		Progress: func(progress xtractr.Progress) {
+			_ = processbar.Checkpoint(ctx)
+			if progress.Count > 0 {
+				progressProcess.Total = progress.Count
+			}
+			progressProcess.Done = min(progress.Files, progressProcess.Total)
+			processBar.TrySendingUpdateProcessMsg(progressProcess)
+		},
+	}
+
+	// xtractr cannot be interrupted, so on cancel we stop waiting for it and
+	// clean up the output directory once it is done
+	resultCh := make(chan error, 1)
+	go func() {
+		_, _, _, extractErr := xtractr.ExtractFile(x)
+		resultCh <- extractErr
+	}()
+
+	select {
+	case err := <-resultCh:
+		if errors.Is(err, xtractr.ErrInvalidPath) {
+			return fmt.Errorf("%w: %w", errUnsafeEntry, err)
+		}
+		p.Total, p.Done = progressProcess.Total, progressProcess.Done
+		return err
+	case <-ctx.Done():
+		go func() {
+			<-resultCh
+			if removeErr := os.RemoveAll(root); removeErr != nil {
+				slog.Error("Failed to remove cancelled extraction output", "path", root, "error", removeErr)
+			}
+		}()
+		return processbar.ErrProcessCancelled
+	}
+}
+
+// moveExtractedItems moves the items extracted in root to dest, resolving
+// their conflicts with the existing items
+func moveExtractedItems(root, dest string, resolutions map[string]conflictResolution,
+	useTrash bool, summary conflictSummary) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		src := filepath.Join(root, entry.Name())
+		dst, err := prepareConflictingDestination(src,
+			filepath.Join(dest, entry.Name()), resolutions[entry.Name()], useTrash)
+		if dst.conflict {
+			summary[dst.resolution]++
+		}
+		if err != nil {
+			return err
+		}
+		if dst.conflict && dst.resolution == conflictSkip {
+			continue
+		}
+		if err := os.Rename(src, dst.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
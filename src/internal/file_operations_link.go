@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+// How the clipboard items are linked into the panel, instead of being copied
+type linkKind int
+
+const (
+	linkSymlink linkKind = iota
+	linkRelativeSymlink
+	linkHardlink
+)
+
+var (
+	errHardlinkCrossDevice = errors.New("cannot hardlink across filesystems")
+	errHardlinkDirectory   = errors.New("cannot hardlink a directory")
+	errLinkArchiveEntry    = errors.New("cannot link an entry of an archive")
+)
+
+func (k linkKind) operation() processbar.OperationType {
+	if k == linkHardlink {
+		return processbar.OpHardlink
+	}
+	return processbar.OpSymlink
+}
+
+func (k linkKind) journalKind() journal.Kind {
+	if k == linkHardlink {
+		return journal.KindHardlink
+	}
+	return journal.KindSymlink
+}
+
+func (m *model) executeLinkOperation(processBarModel *processbar.Model,
+	panelLocation string, items []string, kind linkKind, reqID int,
+) tea.Msg {
+	if len(items) == 0 {
+		return NewLinkOperationMsg(processbar.Cancelled, reqID)
+	}
+	p, err := processBarModel.SendAddProcessMsg(filepath.Base(items[0]), kind.operation(), len(items), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		return NewLinkOperationMsg(processbar.Failed, reqID)
+	}
+	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg {
+		return NewLinkOperationMsg(state, reqID)
+	}
+	processor := makeLinkProcessor(p, processBarModel, panelLocation, kind, m.journal)
+	return m.runFileProcessor(processor, finalizer, items, reqID)
+}
+
+func makeLinkProcessor(process processbar.Process,
+	processBarModel *processbar.Model,
+	panelLocation string, kind linkKind,
+	jr *journal.Journal,
+) processbar.FileListProcessor {
+	var steps []journal.Step
+	processor := makeItemProcessor(process, processBarModel, func(_ *processbar.Process, item string) error {
+		step, err := createLink(item, panelLocation, kind)
+		if err != nil {
+			return err
+		}
+		steps = append(steps, step)
+		return nil
+	})
+	// The links created by each run, the first one or a retry after an error,
+	// are undone together
+	return func(items []string) (processbar.Process, []string) {
+		steps = nil
+		defer func() { jr.Record(kind.journalKind(), steps) }()
+		return processor(items)
+	}
+}
+
+// createLink links src into panelLocation under the same name, renamed like a
+// copy if the name is taken. It returns the journal step to undo it
+func createLink(src, panelLocation string, kind linkKind) (journal.Step, error) {
+	if archivefs.IsVirtual(src) {
+		return journal.Step{}, fmt.Errorf("%w: %s", errLinkArchiveEntry, src)
+	}
+	dst, err := renameIfDuplicate(filepath.Join(panelLocation, filepath.Base(src)))
+	if err != nil {
+		return journal.Step{}, err
+	}
+
+	switch kind {
+	case linkSymlink, linkRelativeSymlink:
+		target := src
+		if kind == linkRelativeSymlink {
+			// Relative to the directory of the link, as the target of a link is resolved from there
+			if target, err = filepath.Rel(panelLocation, src); err != nil {
+				return journal.Step{}, err
+			}
+		}
+		return journal.Step{Src: target, Dst: dst}, os.Symlink(target, dst)
+	case linkHardlink:
+		info, err := os.Lstat(src)
+		if err != nil {
+			return journal.Step{}, err
+		}
+		if info.IsDir() {
+			return journal.Step{}, fmt.Errorf("%w: %s", errHardlinkDirectory, src)
+		}
+		err = os.Link(src, dst)
+		if isCrossDeviceError(err) {
+			return journal.Step{}, fmt.Errorf("%w: %s is not on the filesystem of %s",
+				errHardlinkCrossDevice, src, panelLocation)
+		}
+		return journal.Step{Src: src, Dst: dst}, err
+	}
+	return journal.Step{}, fmt.Errorf("unknown link kind %d", kind)
+}
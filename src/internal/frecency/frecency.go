@@ -0,0 +1,236 @@
+// Package frecency keeps the directories visited by the user, ranked by how
+// often and how recently they were visited, to jump to them by a few
+// keywords. It works like zoxide, without needing it installed, and its
+// ranking and matching follow those of zoxide. The database is persisted as
+// JSON.
+package frecency
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+const (
+	// Once the ranks sum up above maxAge, they are all lowered so that the
+	// directories not visited anymore are forgotten
+	maxAge = 10000
+	// Share of maxAge the ranks sum up to once lowered
+	ageFactor = 0.9
+
+	hour = time.Hour
+	day  = 24 * hour
+	week = 7 * day
+)
+
+// Mockable in tests
+var timeNow = time.Now
+
+// Entry is a directory of the database
+type Entry struct {
+	Rank         float64   `json:"rank"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+type Result struct {
+	Path  string
+	Score float64
+}
+
+// DB is safe for concurrent use, as queries run in tea.Cmd goroutines. The
+// visits are kept in memory until Save
+type DB struct {
+	mu       sync.Mutex
+	filePath string
+	entries  map[string]Entry
+	// The entries changed since they were last saved
+	unsaved bool
+	// Serializes the writes of the file, done without holding mu
+	saveMu sync.Mutex
+}
+
+// New loads the database stored at filePath. An empty filePath keeps the
+// database in memory only
+func New(filePath string) *DB {
+	db := &DB{filePath: filePath, entries: make(map[string]Entry)}
+	if filePath == "" {
+		return db
+	}
+	if err := utils.LoadJSONFile(filePath, &db.entries); err != nil {
+		slog.Error("Error loading frecency database", "error", err)
+	}
+	return db
+}
+
+// Add records a visit of the directory at path
+func (db *DB) Add(path string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	entry := db.entries[path]
+	entry.Rank++
+	entry.LastAccessed = timeNow()
+	db.entries[path] = entry
+	db.age()
+	db.unsaved = true
+}
+
+// Import adds the entries to the database, summing the ranks of the
+// directories already in it. Returns the count of directories not in it yet
+func (db *DB) Import(entries map[string]Entry) (int, error) {
+	added := db.importEntries(entries)
+	return added, db.Save()
+}
+
+func (db *DB) importEntries(entries map[string]Entry) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	added := 0
+	for path, imported := range entries {
+		entry, ok := db.entries[path]
+		if !ok {
+			added++
+		}
+		entry.Rank += imported.Rank
+		if imported.LastAccessed.After(entry.LastAccessed) {
+			entry.LastAccessed = imported.LastAccessed
+		}
+		db.entries[path] = entry
+	}
+	db.age()
+	db.unsaved = true
+	return added
+}
+
+// Query returns the existing directories matching the keywords, by
+// decreasing score. The keywords are matched ignoring case, see matchKeywords
+func (db *DB) Query(keywords ...string) []Result {
+	lowerKeywords := make([]string, len(keywords))
+	for i := range keywords {
+		lowerKeywords[i] = strings.ToLower(keywords[i])
+	}
+	now := timeNow()
+	results := []Result{}
+	db.mu.Lock()
+	for path, entry := range db.entries {
+		if matchKeywords(path, lowerKeywords) {
+			results = append(results, Result{Path: path, Score: entry.score(now)})
+		}
+	}
+	db.mu.Unlock()
+
+	// The directories removed are kept, they may be on a drive that is not
+	// mounted right now
+	results = slices.DeleteFunc(results, func(r Result) bool {
+		info, err := os.Stat(r.Path)
+		return err != nil || !info.IsDir()
+	})
+	slices.SortFunc(results, func(a, b Result) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	return results
+}
+
+// score favors the directories visited recently
+func (e Entry) score(now time.Time) float64 {
+	duration := now.Sub(e.LastAccessed)
+	switch {
+	case duration < hour:
+		return e.Rank * 4 //nolint:mnd // Same weights as zoxide
+	case duration < day:
+		return e.Rank * 2 //nolint:mnd // Same weights as zoxide
+	case duration < week:
+		return e.Rank * 0.5 //nolint:mnd // Same weights as zoxide
+	default:
+		return e.Rank * 0.25 //nolint:mnd // Same weights as zoxide
+	}
+}
+
+// matchKeywords reports whether path contains the lowercase keywords in
+// order, ignoring case. The last keyword has to be in the last component of
+// the path, so that the results are the directories looked for rather than
+// their subdirectories
+func matchKeywords(path string, keywords []string) bool {
+	if len(keywords) == 0 {
+		return true
+	}
+	path = strings.ToLower(path)
+	last := keywords[len(keywords)-1]
+	idx := strings.LastIndex(path, last)
+	if idx < 0 || strings.ContainsAny(path[idx+len(last):], `/`+string(filepath.Separator)) {
+		return false
+	}
+	path = path[:idx]
+	for i := len(keywords) - 2; i >= 0; i-- {
+		idx = strings.LastIndex(path, keywords[i])
+		if idx < 0 {
+			return false
+		}
+		path = path[:idx]
+	}
+	return true
+}
+
+// age lowers the ranks once they sum up above maxAge, and forgets the
+// directories whose rank gets below 1. Must be called with the mutex held
+func (db *DB) age() {
+	total := 0.0
+	for _, entry := range db.entries {
+		total += entry.Rank
+	}
+	if total <= maxAge {
+		return
+	}
+	factor := ageFactor * maxAge / total
+	for path, entry := range db.entries {
+		entry.Rank *= factor
+		if entry.Rank < 1 {
+			delete(db.entries, path)
+			continue
+		}
+		db.entries[path] = entry
+	}
+}
+
+// Unsaved tells whether directories were visited since the last save
+func (db *DB) Unsaved() bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.unsaved && db.filePath != ""
+}
+
+// Save writes the database to its file, if it changed since the last save.
+// The entries are copied so that the visits are not blocked meanwhile
+func (db *DB) Save() error {
+	db.saveMu.Lock()
+	defer db.saveMu.Unlock()
+	db.mu.Lock()
+	if !db.unsaved || db.filePath == "" {
+		db.mu.Unlock()
+		return nil
+	}
+	entries := maps.Clone(db.entries)
+	db.unsaved = false
+	db.mu.Unlock()
+
+	if err := utils.SaveJSONFile(db.filePath, entries); err != nil {
+		db.mu.Lock()
+		db.unsaved = true
+		db.mu.Unlock()
+		return fmt.Errorf("error saving frecency database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package frecency
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func setNow(t *testing.T, now time.Time) {
+	t.Helper()
+	orig := timeNow
+	t.Cleanup(func() { timeNow = orig })
+	timeNow = func() time.Time { return now }
+}
+
+func resultPaths(results []Result) []string {
+	paths := make([]string, 0, len(results))
+	for _, r := range results {
+		paths = append(paths, r.Path)
+	}
+	return paths
+}
+
+func TestMatchKeywords(t *testing.T) {
+	testdata := []struct {
+		path     string
+		keywords []string
+		expected bool
+	}{
+		{"/home/user/projects/superfile", nil, true},
+		{"/home/user/projects/superfile", []string{"super"}, true},
+		{"/home/user/projects/SuperFile", []string{"superfile"}, true},
+		{"/home/user/projects/superfile", []string{"proj", "file"}, true},
+		{"/home/user/projects/superfile", []string{"file", "proj"}, false},
+		{"/home/user/projects/superfile/src", []string{"super"}, false},
+		{"/home/user/projects/superfile/src", []string{"super", "src"}, true},
+		{"/home/user/projects/superfile", []string{"other"}, false},
+	}
+	for _, tt := range testdata {
+		assert.Equal(t, tt.expected, matchKeywords(tt.path, tt.keywords), "%s %v", tt.path, tt.keywords)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	root := t.TempDir()
+	often := filepath.Join(root, "often")
+	recent := filepath.Join(root, "recent")
+	removed := filepath.Join(root, "removed")
+	utils.SetupDirectories(t, often, recent)
+	now := time.Now()
+
+	db := New("")
+	setNow(t, now.Add(-2*day))
+	for range 3 {
+		db.Add(often)
+	}
+	db.Add(removed)
+	setNow(t, now)
+	db.Add(recent)
+
+	// often : 3 * 0.5, recent : 1 * 4
+	results := db.Query()
+	assert.Equal(t, []string{recent, often}, resultPaths(results), "removed directory left out")
+	assert.InDelta(t, 4.0, results[0].Score, 0.001)
+	assert.InDelta(t, 1.5, results[1].Score, 0.001)
+
+	keywords := []string{"OFT"}
+	assert.Equal(t, []string{often}, resultPaths(db.Query(keywords...)))
+	assert.Equal(t, "OFT", keywords[0], "keywords of the caller unchanged")
+	assert.Empty(t, db.Query("none"))
+}
+
+func TestAging(t *testing.T) {
+	db := New("")
+	db.entries["/a"] = Entry{Rank: maxAge}
+	db.entries["/b"] = Entry{Rank: 1}
+	db.Add("/c")
+	assert.NotContains(t, db.entries, "/b", "forgotten once below 1")
+	assert.Contains(t, db.entries, "/a")
+	assert.Less(t, db.entries["/a"].Rank, float64(maxAge))
+}
+
+func TestPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "frecency.json")
+	dir := t.TempDir()
+	db := New(filePath)
+	db.Add(dir)
+	db.Add(dir)
+	assert.Empty(t, New(filePath).Query(), "kept in memory until saved")
+	assert.True(t, db.Unsaved())
+
+	require.NoError(t, db.Save())
+	assert.False(t, db.Unsaved())
+	loaded := New(filePath)
+	results := loaded.Query()
+	require.Len(t, results, 1)
+	assert.Equal(t, dir, results[0].Path)
+	assert.InDelta(t, 8.0, results[0].Score, 0.001)
+}
+
+func encodeZoxideDB(t *testing.T, version uint32, dirs map[string]Entry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	write := func(v any) {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, v))
+	}
+	write(version)
+	write(uint64(len(dirs)))
+	for path, entry := range dirs {
+		write(uint64(len(path)))
+		buf.WriteString(path)
+		write(math.Float64bits(entry.Rank))
+		write(uint64(entry.LastAccessed.Unix()))
+	}
+	return buf.Bytes()
+}
+
+func TestImportZoxideDB(t *testing.T) {
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+	lastAccessed := time.Unix(time.Now().Unix(), 0)
+	zoxideEntries := map[string]Entry{
+		dirA: {Rank: 5, LastAccessed: lastAccessed},
+		dirB: {Rank: 2.5, LastAccessed: lastAccessed.Add(-time.Minute)},
+	}
+	dbPath := filepath.Join(t.TempDir(), "db.zo")
+	require.NoError(t, os.WriteFile(dbPath, encodeZoxideDB(t, zoxideDBVersion, zoxideEntries), 0o600))
+
+	entries, err := ReadZoxideDB(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, zoxideEntries, entries)
+
+	db := New("")
+	db.Add(dirB)
+	added, err := db.Import(entries)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, []string{dirA, dirB}, resultPaths(db.Query()))
+	assert.InDelta(t, 3.5, db.entries[dirB].Rank, 0.001, "ranks summed")
+
+	t.Run("Invalid databases", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(dbPath, encodeZoxideDB(t, 2, zoxideEntries), 0o600))
+		_, err := ReadZoxideDB(dbPath)
+		require.ErrorIs(t, err, errZoxideDBVersion)
+
+		data := encodeZoxideDB(t, zoxideDBVersion, zoxideEntries)
+		require.NoError(t, os.WriteFile(dbPath, data[:len(data)-4], 0o600))
+		_, err = ReadZoxideDB(dbPath)
+		require.Error(t, err)
+
+		_, err = ReadZoxideDB(filepath.Join(t.TempDir(), "missing.zo"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,88 @@
+package frecency
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// Version of the format of the zoxide database that can be imported
+const zoxideDBVersion = 3
+
+var errZoxideDBVersion = errors.New("unsupported zoxide database version")
+
+// ZoxideDBPath returns the path of the database of zoxide, in the directory
+// set by _ZO_DATA_DIR like zoxide does
+func ZoxideDBPath() string {
+	dir := os.Getenv("_ZO_DATA_DIR")
+	if dir == "" {
+		dir = filepath.Join(xdg.DataHome, "zoxide")
+	}
+	return filepath.Join(dir, "db.zo")
+}
+
+// ReadZoxideDB reads the entries of the database of zoxide at filePath, so
+// they can be imported. It is the bincode encoding of the version, followed
+// by the directories with their path, rank and last access time
+func ReadZoxideDB(filePath string) (map[string]Entry, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	r := bytes.NewReader(data)
+	var version uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("error reading zoxide database %s: %w", filePath, err)
+	}
+	if version != zoxideDBVersion {
+		return nil, fmt.Errorf("%w %d in %s", errZoxideDBVersion, version, filePath)
+	}
+	entries, err := readZoxideDirs(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading zoxide database %s: %w", filePath, err)
+	}
+	return entries, nil
+}
+
+func readZoxideDirs(r *bytes.Reader) (map[string]Entry, error) {
+	var count uint64
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	entries := make(map[string]Entry)
+	for range count {
+		var pathLen uint64
+		if err := binary.Read(r, binary.LittleEndian, &pathLen); err != nil {
+			return nil, err
+		}
+		// Checked before allocating, as the length comes from the file
+		if pathLen > uint64(r.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		path := make([]byte, pathLen)
+		if _, err := io.ReadFull(r, path); err != nil {
+			return nil, err
+		}
+		var rank uint64
+		var lastAccessed uint64
+		if err := binary.Read(r, binary.LittleEndian, &rank); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &lastAccessed); err != nil {
+			return nil, err
+		}
+		entries[string(path)] = Entry{
+			Rank:         math.Float64frombits(rank),
+			LastAccessed: time.Unix(int64(lastAccessed), 0), //nolint:gosec // Seconds since the epoch
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,145 @@
+package gitstatus
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// The status of a work tree is read again after that long, as the changes
+// made by git itself, like a commit, are not seen by the filesystem watcher.
+// Same for a directory not in a work tree, as it can become one
+const refreshInterval = 10 * time.Second
+
+// Cache keeps the status of the work trees of the directories looked up. It
+// does not read them, it tells when they have to be read. It is not safe for
+// concurrent use
+type Cache struct {
+	// Work tree of the directories looked up
+	dirs map[string]*dirEntry
+	// Status of the work trees, by Root
+	repos map[string]*repoEntry
+}
+
+type dirEntry struct {
+	// Root of the work tree, empty if dir is not in one
+	root      string
+	checkedAt time.Time
+	loading   bool
+}
+
+type repoEntry struct {
+	repo *Repo
+	// When the load that read repo started
+	loadedAt      time.Time
+	invalidatedAt time.Time
+	loading       bool
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		dirs:  make(map[string]*dirEntry),
+		repos: make(map[string]*repoEntry),
+	}
+}
+
+// Get returns the status of the work tree containing dir, nil if it is not
+// in one or if it is not read yet. load tells that dir has to be loaded, and
+// Store called with the result. Get does not ask to load again until then
+func (c *Cache) Get(dir string, now time.Time) (*Repo, bool) {
+	dir = filepath.Clean(dir)
+	entry, ok := c.dirs[dir]
+	if !ok {
+		entry = &dirEntry{root: c.coveringRoot(dir), checkedAt: now}
+		c.dirs[dir] = entry
+		if entry.root == "" {
+			entry.loading = true
+			return nil, true
+		}
+	}
+	if entry.root == "" {
+		if !entry.loading && now.Sub(entry.checkedAt) > refreshInterval {
+			entry.loading = true
+			return nil, true
+		}
+		return nil, false
+	}
+
+	repo := c.repos[entry.root]
+	if !repo.loading && (repo.invalidatedAt.After(repo.loadedAt) || now.Sub(repo.loadedAt) > refreshInterval) {
+		repo.loading = true
+		return repo.repo, true
+	}
+	return repo.repo, false
+}
+
+// coveringRoot returns the Root of the deepest work tree read that covers
+// dir, or an empty string if there is none
+func (c *Cache) coveringRoot(dir string) string {
+	root := ""
+	for _, entry := range c.repos {
+		if len(entry.repo.Root) > len(root) && entry.repo.Covers(dir) {
+			root = entry.repo.Root
+		}
+	}
+	return root
+}
+
+// Store saves the result of a load of dir started at startedAt. repo is nil
+// if dir is not in a work tree, or if it could not be read
+func (c *Cache) Store(dir string, repo *Repo, startedAt time.Time) {
+	dir = filepath.Clean(dir)
+	entry, ok := c.dirs[dir]
+	if !ok {
+		entry = &dirEntry{}
+		c.dirs[dir] = entry
+	}
+	// The directory may have been in a work tree, that loads on its own
+	if old, ok := c.repos[entry.root]; ok {
+		old.loading = false
+	}
+	entry.loading = false
+	entry.checkedAt = startedAt
+	if repo == nil {
+		entry.root = ""
+		return
+	}
+	entry.root = repo.Root
+
+	stored, ok := c.repos[repo.Root]
+	if !ok {
+		stored = &repoEntry{}
+		c.repos[repo.Root] = stored
+	}
+	stored.loading = false
+	if startedAt.Before(stored.loadedAt) {
+		// A more recent load finished first
+		return
+	}
+	stored.repo = repo
+	stored.loadedAt = startedAt
+}
+
+// Invalidate makes the work trees containing the paths be read again
+func (c *Cache) Invalidate(paths []string, now time.Time) {
+	for _, entry := range c.repos {
+		for _, path := range paths {
+			if entry.repo.contains(path) {
+				entry.invalidatedAt = now
+				break
+			}
+		}
+	}
+}
+
+// InvalidateAll makes all the work trees be read again, and the directories
+// not in one be checked again
+func (c *Cache) InvalidateAll(now time.Time) {
+	for _, entry := range c.repos {
+		entry.invalidatedAt = now
+	}
+	for _, entry := range c.dirs {
+		if entry.root == "" {
+			entry.checkedAt = time.Time{}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package gitstatus
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	sub := filepath.Join(root, "sub")
+	outside := filepath.FromSlash("/outside")
+	start := time.Now()
+
+	c := NewCache()
+	repo, load := c.Get(root, start)
+	assert.Nil(t, repo)
+	require.True(t, load)
+	_, load = c.Get(root, start)
+	assert.False(t, load, "already loading")
+
+	loaded := &Repo{Root: root}
+	c.Store(root, loaded, start)
+	repo, load = c.Get(root, start)
+	assert.Same(t, loaded, repo)
+	assert.False(t, load)
+
+	// The directories of the work tree use its status
+	repo, load = c.Get(sub, start)
+	assert.Same(t, loaded, repo)
+	assert.False(t, load)
+
+	_, load = c.Get(outside, start)
+	require.True(t, load)
+	c.Store(outside, nil, start)
+	repo, load = c.Get(outside, start)
+	assert.Nil(t, repo)
+	assert.False(t, load)
+
+	// A change in the work tree makes it be read again, once
+	c.Invalidate([]string{filepath.Join(sub, "file")}, start.Add(time.Second))
+	_, load = c.Get(sub, start.Add(time.Second))
+	require.True(t, load)
+	_, load = c.Get(root, start.Add(time.Second))
+	assert.False(t, load)
+	_, load = c.Get(outside, start.Add(time.Second))
+	assert.False(t, load)
+
+	reloaded := &Repo{Root: root}
+	c.Store(sub, reloaded, start.Add(time.Second))
+	repo, _ = c.Get(root, start.Add(time.Second))
+	assert.Same(t, reloaded, repo)
+
+	// An older load finishing last is ignored
+	c.Store(root, loaded, start)
+	repo, _ = c.Get(root, start.Add(time.Second))
+	assert.Same(t, reloaded, repo)
+
+	// Everything is read again after a while
+	later := start.Add(time.Second + refreshInterval + time.Millisecond)
+	_, load = c.Get(root, later)
+	assert.True(t, load)
+	_, load = c.Get(outside, later)
+	assert.True(t, load)
+}
+
+func TestCacheInvalidateAll(t *testing.T) {
+	now := time.Now()
+	c := NewCache()
+	c.Get("/outside", now)
+	c.Store("/outside", nil, now)
+	c.InvalidateAll(now)
+	_, load := c.Get("/outside", now)
+	assert.True(t, load)
+}
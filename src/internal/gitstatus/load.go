@@ -0,0 +1,70 @@
+package gitstatus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A status taking longer is given up, the repository is probably too big
+const loadTimeout = 30 * time.Second
+
+// Load reads the status of the work tree containing dir. Returns nil, and no
+// error, if dir is not in a work tree
+func Load(ctx context.Context, dir string) (*Repo, error) {
+	ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+	defer cancel()
+
+	out, err := runGit(ctx, dir, "rev-parse", "--is-inside-work-tree", "--show-prefix")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			// Not in a repository
+			return nil, nil //nolint:nilnil // Not an error, and no repository
+		}
+		return nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(out), "\r", ""), "\n")
+	if lines[0] != "true" {
+		// In the git directory, or in a bare repository
+		return nil, nil //nolint:nilnil // Not an error, and no repository
+	}
+
+	// The root is found from dir, rather than with --show-toplevel, to keep
+	// the symlinks dir was reached with
+	root := filepath.Clean(dir)
+	if len(lines) > 1 {
+		if prefix := strings.Trim(lines[1], "/"); prefix != "" {
+			for range strings.Count(prefix, "/") + 1 {
+				root = filepath.Dir(root)
+			}
+		}
+	}
+
+	out, err = runGit(ctx, root, "status", "--porcelain=v2", "--branch", "-z", "--ignored=matching")
+	if err != nil {
+		return nil, err
+	}
+	return parseStatus(root, out)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Do not take the lock of the index to refresh it, it would make the git
+	// commands run by the user at the same time fail
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
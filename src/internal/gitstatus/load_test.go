@@ -0,0 +1,58 @@
+package gitstatus
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func runGitForTest(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)
+	cmd := exec.CommandContext(t.Context(), "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	utils.SetupDirectories(t, sub)
+	utils.SetupFilesWithData(t, []byte("content"), filepath.Join(sub, "committed.txt"))
+	utils.SetupFilesWithData(t, []byte("*.log\n"), filepath.Join(root, ".gitignore"))
+	runGitForTest(t, root, "init", "-q", "-b", "trunk")
+	runGitForTest(t, root, "add", ".")
+	runGitForTest(t, root, "commit", "-q", "-m", "initial")
+
+	utils.SetupFilesWithData(t, []byte("changed"), filepath.Join(sub, "committed.txt"))
+	utils.SetupFiles(t, filepath.Join(root, "new.txt"), filepath.Join(root, "debug.log"))
+
+	repo, err := Load(t.Context(), sub)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+	assert.Equal(t, root, repo.Root, "found from the directory")
+	assert.Equal(t, "trunk", repo.Branch)
+	assert.True(t, repo.Dirty)
+	assert.Equal(t, Modified, repo.StatusOf(filepath.Join(sub, "committed.txt")))
+	assert.Equal(t, Modified, repo.StatusOf(sub))
+	assert.Equal(t, Untracked, repo.StatusOf(filepath.Join(root, "new.txt")))
+	assert.Equal(t, Ignored, repo.StatusOf(filepath.Join(root, "debug.log")))
+	assert.Equal(t, Unmodified, repo.StatusOf(filepath.Join(root, ".gitignore")))
+
+	repo, err = Load(t.Context(), filepath.Join(root, ".git"))
+	require.NoError(t, err)
+	assert.Nil(t, repo, "the git directory is not in the work tree")
+
+	repo, err = Load(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, repo)
+}
@@ -0,0 +1,226 @@
+// Package gitstatus reads the status of the git work trees, to show the
+// status of their files in the file panels. The status is read with the git
+// binary, in the background, and cached by work tree.
+package gitstatus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Status of a file in its work tree. A higher Status takes precedence in the
+// status of a directory
+type Status uint8
+
+const (
+	Unmodified Status = iota
+	Ignored
+	Untracked
+	// Changes added to the index
+	Staged
+	// Changes not added to the index
+	Modified
+	// Unmerged, with conflicts to resolve
+	Conflicted
+)
+
+func (s Status) String() string {
+	switch s {
+	case Unmodified:
+		return "Unmodified"
+	case Ignored:
+		return "Ignored"
+	case Untracked:
+		return "Untracked"
+	case Staged:
+		return "Staged"
+	case Modified:
+		return "Modified"
+	case Conflicted:
+		return "Conflicted"
+	default:
+		return "Invalid"
+	}
+}
+
+// Repo is the status of a work tree at the time it was read
+type Repo struct {
+	// Top directory of the work tree
+	Root string
+	// Current branch, empty when the HEAD is detached
+	Branch string
+	// Commits ahead and behind the upstream branch, if there is one
+	Upstream bool
+	Ahead    int
+	Behind   int
+	// There are changes, the ignored files left out
+	Dirty bool
+
+	// Status of the changed files and directories. The untracked and ignored
+	// directories are listed as a whole, their content is not
+	files map[string]Status
+	// Status of the directories containing changes
+	dirs map[string]Status
+	// Directories that can be in another work tree: the submodules, and the
+	// untracked and ignored directories
+	nested map[string]struct{}
+}
+
+var errInvalidStatus = errors.New("invalid git status output")
+
+// parseStatus parses the output of `git status --porcelain=v2 --branch -z`.
+// The paths are relative to root
+func parseStatus(root string, output []byte) (*Repo, error) {
+	repo := &Repo{
+		Root:   root,
+		files:  make(map[string]Status),
+		dirs:   make(map[string]Status),
+		nested: make(map[string]struct{}),
+	}
+	records := bytes.Split(output, []byte{0})
+	for i := 0; i < len(records); i++ {
+		record := string(records[i])
+		if record == "" {
+			continue
+		}
+		var err error
+		switch record[0] {
+		case '#':
+			err = repo.parseHeader(record)
+		case '1':
+			err = repo.parseChange(record, 9) //nolint:mnd // fields of an ordinary change
+		case '2':
+			err = repo.parseChange(record, 10) //nolint:mnd // fields of a rename or copy
+			// Followed by the original path
+			i++
+		case 'u':
+			err = repo.parseChange(record, 11) //nolint:mnd // fields of an unmerged change
+		case '?':
+			repo.add(record[2:], Untracked)
+		case '!':
+			repo.add(record[2:], Ignored)
+		default:
+			err = fmt.Errorf("%w: unknown record %q", errInvalidStatus, record)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+func (r *Repo) parseHeader(record string) error {
+	fields := strings.Fields(record)
+	if len(fields) < 3 { //nolint:mnd // "#", the name and the value
+		return nil
+	}
+	switch fields[1] {
+	case "branch.head":
+		if fields[2] != "(detached)" {
+			r.Branch = fields[2]
+		}
+	case "branch.upstream":
+		r.Upstream = true
+	case "branch.ab":
+		if len(fields) < 4 { //nolint:mnd // "#", the name, ahead and behind
+			return fmt.Errorf("%w: %q", errInvalidStatus, record)
+		}
+		var err error
+		if r.Ahead, err = strconv.Atoi(strings.TrimPrefix(fields[2], "+")); err != nil {
+			return fmt.Errorf("%w: %q", errInvalidStatus, record)
+		}
+		if r.Behind, err = strconv.Atoi(strings.TrimPrefix(fields[3], "-")); err != nil {
+			return fmt.Errorf("%w: %q", errInvalidStatus, record)
+		}
+	}
+	return nil
+}
+
+// parseChange parses a record of a changed tracked file, made of fieldCount
+// fields, the path being the last
+func (r *Repo) parseChange(record string, fieldCount int) error {
+	fields := strings.SplitN(record, " ", fieldCount)
+	if len(fields) != fieldCount || len(fields[1]) != 2 {
+		return fmt.Errorf("%w: %q", errInvalidStatus, record)
+	}
+	path := fields[fieldCount-1]
+	if strings.HasPrefix(fields[2], "S") {
+		// A submodule, with its own work tree
+		r.nested[r.abs(path)] = struct{}{}
+	}
+
+	index, worktree := fields[1][0], fields[1][1]
+	switch {
+	case record[0] == 'u':
+		r.add(path, Conflicted)
+	case worktree != '.':
+		r.add(path, Modified)
+	case index != '.':
+		r.add(path, Staged)
+	}
+	return nil
+}
+
+func (r *Repo) add(path string, status Status) {
+	isDir := strings.HasSuffix(path, "/")
+	location := r.abs(path)
+	r.files[location] = status
+	if isDir && (status == Untracked || status == Ignored) {
+		r.nested[location] = struct{}{}
+	}
+	if status == Ignored {
+		return
+	}
+	r.Dirty = true
+	for dir := filepath.Dir(location); r.contains(dir); dir = filepath.Dir(dir) {
+		r.dirs[dir] = max(r.dirs[dir], status)
+		if dir == r.Root {
+			break
+		}
+	}
+}
+
+func (r *Repo) abs(path string) string {
+	return filepath.Join(r.Root, filepath.FromSlash(strings.TrimSuffix(path, "/")))
+}
+
+// contains tells whether path is the Root or below it
+func (r *Repo) contains(path string) bool {
+	rel, err := filepath.Rel(r.Root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// StatusOf returns the status of a file of the work tree. The status of a
+// directory is the one of the most important change inside it
+func (r *Repo) StatusOf(path string) Status {
+	if status, ok := r.files[path]; ok {
+		return status
+	}
+	if status, ok := r.dirs[path]; ok {
+		return status
+	}
+	// The content of untracked and ignored directories is not listed
+	for dir := filepath.Dir(path); dir != r.Root && r.contains(dir); dir = filepath.Dir(dir) {
+		if status, ok := r.files[dir]; ok && (status == Untracked || status == Ignored) {
+			return status
+		}
+	}
+	return Unmodified
+}
+
+// Covers tells whether dir is in the work tree, and not in a nested one
+func (r *Repo) Covers(dir string) bool {
+	if !r.contains(dir) {
+		return false
+	}
+	for ; dir != r.Root; dir = filepath.Dir(dir) {
+		if _, ok := r.nested[dir]; ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package gitstatus
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func porcelain(records ...string) []byte {
+	return []byte(strings.Join(records, "\x00") + "\x00")
+}
+
+func TestParseStatus(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	path := func(rel string) string { return filepath.Join(root, filepath.FromSlash(rel)) }
+
+	repo, err := parseStatus(root, porcelain(
+		"# branch.oid 0123456789abcdef",
+		"# branch.head main",
+		"# branch.upstream origin/main",
+		"# branch.ab +2 -1",
+		"1 .M N... 100644 100644 100644 aaaa bbbb src/modified.go",
+		"1 M. N... 100644 100644 100644 aaaa bbbb src/deep/staged file.go",
+		"2 R. N... 100644 100644 100644 aaaa bbbb R100 docs/new.md",
+		"docs/old.md",
+		"u UU N... 100644 100644 100644 100644 aaaa bbbb cccc conflict.txt",
+		"1 .M S.M. 160000 160000 160000 aaaa bbbb module",
+		"? untracked/",
+		"? notes.txt",
+		"! build/",
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, "main", repo.Branch)
+	assert.True(t, repo.Upstream)
+	assert.Equal(t, 2, repo.Ahead)
+	assert.Equal(t, 1, repo.Behind)
+	assert.True(t, repo.Dirty)
+
+	testdata := []struct {
+		path     string
+		expected Status
+	}{
+		{"src/modified.go", Modified},
+		{"src/deep/staged file.go", Staged},
+		{"docs/new.md", Staged},
+		{"docs/old.md", Unmodified},
+		{"conflict.txt", Conflicted},
+		{"notes.txt", Untracked},
+		{"untracked", Untracked},
+		{"untracked/inside/file", Untracked},
+		{"build", Ignored},
+		{"build/out.o", Ignored},
+		{"clean.txt", Unmodified},
+		// The directories take the most important status inside them
+		{"src", Modified},
+		{"src/deep", Staged},
+		{"docs", Staged},
+	}
+	for _, tt := range testdata {
+		assert.Equal(t, tt.expected, repo.StatusOf(path(tt.path)), tt.path)
+	}
+	assert.Equal(t, Conflicted, repo.StatusOf(root), "the status of the root")
+	assert.Equal(t, Unmodified, repo.StatusOf(filepath.FromSlash("/other/file")))
+
+	assert.True(t, repo.Covers(root))
+	assert.True(t, repo.Covers(path("src/deep")))
+	assert.False(t, repo.Covers(path("module")), "a submodule has its own work tree")
+	assert.False(t, repo.Covers(path("untracked/inside")), "can be another work tree")
+	assert.False(t, repo.Covers(filepath.FromSlash("/repository")))
+}
+
+func TestParseStatusClean(t *testing.T) {
+	repo, err := parseStatus(filepath.FromSlash("/repo"), porcelain(
+		"# branch.oid (initial)",
+		"# branch.head (detached)",
+		"! ignored.log",
+	))
+	require.NoError(t, err)
+	assert.Empty(t, repo.Branch)
+	assert.False(t, repo.Upstream)
+	assert.False(t, repo.Dirty, "ignored files are not changes")
+	assert.Equal(t, Unmodified, repo.StatusOf(filepath.FromSlash("/repo")))
+}
+
+func TestParseStatusInvalid(t *testing.T) {
+	_, err := parseStatus("/repo", porcelain("1 .M N... truncated"))
+	require.ErrorIs(t, err, errInvalidStatus)
+	_, err = parseStatus("/repo", porcelain("# branch.ab +x -1"))
+	require.ErrorIs(t, err, errInvalidStatus)
+}
@@ -0,0 +1,429 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/ui/bulkrename"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+const bulkRenameErrorTitle = "Cannot rename items"
+
+// Prefix of the temporary name given to an item of a rename cycle, like a→b and b→a
+const bulkRenameTempPrefix = ".spf-rename-"
+
+// renameMove renames the item src of the directory of a bulk rename to dst.
+// Both are names, not paths
+type renameMove struct {
+	src string
+	dst string
+}
+
+// bulkRenamePlan is a validated bulk rename
+type bulkRenamePlan struct {
+	dir string
+	// Renames as requested by the user, shown in the preview
+	renames []renameMove
+	// Renames to execute, in order, so that no item takes the name of another
+	// one before that one is renamed. Cycles go through a temporary name
+	moves []renameMove
+}
+
+// The directory and the names of the selected items, or of the focused one
+func (m *model) getBulkRenameTargets() (string, []string, error) {
+	panel := m.getFocusedFilePanel()
+	var items []string
+	if panel.PanelMode == filepanel.SelectMode && panel.SelectedCount() > 0 {
+		items = panel.GetSelectedLocationsSortedAsVisible()
+	} else {
+		items = []string{panel.GetFocusedItem().Location}
+	}
+
+	dir := filepath.Dir(items[0])
+	names := make([]string, len(items))
+	for i, item := range items {
+		if filepath.Dir(item) != dir {
+			return "", nil, errors.New("items of different directories cannot be renamed together")
+		}
+		names[i] = filepath.Base(item)
+	}
+	return dir, names, nil
+}
+
+// Open the names of the selected items, or of the focused one, in the editor.
+// Once the editor exits, the edited names are validated and previewed
+func (m *model) getBulkRenameCmd() tea.Cmd {
+	if m.getFocusedFilePanel().Empty() || m.isReadOnlyPanel("bulk rename") {
+		return nil
+	}
+	dir, names, err := m.getBulkRenameTargets()
+	if err == nil {
+		for _, name := range names {
+			if strings.ContainsAny(name, "\r\n") {
+				err = fmt.Errorf("%q has a line break in its name", name)
+				break
+			}
+		}
+	}
+	if err != nil {
+		m.notifyModel = notify.New(true, bulkRenameErrorTitle, err.Error(), notify.NoAction)
+		return nil
+	}
+
+	namesFile, err := writeBulkRenameFile(names)
+	if err != nil {
+		slog.Error("Error while writing the names to rename", "error", err)
+		m.notifyModel = notify.New(true, bulkRenameErrorTitle, err.Error(), notify.NoAction)
+		return nil
+	}
+
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Opening bulk rename in the editor", "id", reqID, "items cnt", len(names), "file", namesFile)
+	return tea.ExecProcess(fileEditorCommand(namesFile), func(err error) tea.Msg {
+		return NewBulkRenameEditedMsg(dir, names, namesFile, err, reqID)
+	})
+}
+
+// Write the names, one per line, to a temporary file and return its path
+func writeBulkRenameFile(names []string) (string, error) {
+	f, err := os.CreateTemp("", "spf-rename-*.txt")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.WriteString(strings.Join(names, "\n") + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// Read the names edited by the user, validate them and open the preview
+func (m *model) handleBulkRenameEdited(dir string, oldNames []string, namesFile string, editorErr error) {
+	content, err := os.ReadFile(namesFile)
+	if removeErr := os.Remove(namesFile); removeErr != nil {
+		slog.Error("Error while removing the file of names to rename", "path", namesFile, "error", removeErr)
+	}
+	if editorErr != nil {
+		err = fmt.Errorf("the editor failed: %w", editorErr)
+	}
+	var plan *bulkRenamePlan
+	if err == nil {
+		plan, err = buildBulkRenamePlan(dir, oldNames, parseBulkRenameNames(string(content)))
+	}
+	if err != nil {
+		slog.Debug("Bulk rename rejected", "error", err)
+		m.notifyModel = notify.New(true, bulkRenameErrorTitle, err.Error(), notify.NoAction)
+		return
+	}
+	if len(plan.renames) == 0 {
+		slog.Debug("Bulk rename without any changed name")
+		return
+	}
+
+	items := make([]bulkrename.Item, len(plan.renames))
+	for i, r := range plan.renames {
+		items[i] = bulkrename.Item{OldName: r.src, NewName: r.dst}
+	}
+	m.bulkRenameModal.Open(dir, items)
+}
+
+// Open the modal to rename the selected items, or the focused one, with a pattern
+func (m *model) openPatternRenameModal() {
+	if m.getFocusedFilePanel().Empty() || m.isReadOnlyPanel("pattern rename") {
+		return
+	}
+	dir, names, err := m.getBulkRenameTargets()
+	if err != nil {
+		m.notifyModel = notify.New(true, bulkRenameErrorTitle, err.Error(), notify.NoAction)
+		return
+	}
+	// All the names, including hidden items, to show the renames to a taken name
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		slog.Error("Error while listing the directory to rename items in", "dir", dir, "error", err)
+	}
+	existing := make([]string, len(entries))
+	for i, entry := range entries {
+		existing[i] = entry.Name()
+	}
+	m.bulkRenameModal.OpenPattern(dir, names, existing)
+	m.firstTextInput = true
+}
+
+// One name per line. The trailing empty lines that editors tend to add are ignored
+func parseBulkRenameNames(content string) []string {
+	lines := strings.Split(content, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// buildBulkRenamePlan checks that the items of dir named oldNames can be
+// renamed to newNames, matched by position, and orders the renames
+func buildBulkRenamePlan(dir string, oldNames, newNames []string) (*bulkRenamePlan, error) {
+	if len(newNames) != len(oldNames) {
+		return nil, fmt.Errorf("expected %d names but got %d, keep one name per line in the same order",
+			len(oldNames), len(newNames))
+	}
+
+	plan := &bulkRenamePlan{dir: dir}
+	// Final name of each item, to the item it was given to
+	finalNames := make(map[string]string, len(newNames))
+	renamed := make(map[string]struct{})
+	for i, newName := range newNames {
+		oldName := oldNames[i]
+		if err := validateBulkRenameName(newName); err != nil {
+			return nil, fmt.Errorf("cannot rename %q to %q: %w", oldName, newName, err)
+		}
+		if other, ok := finalNames[newName]; ok {
+			return nil, fmt.Errorf("%q and %q would both be named %q", other, oldName, newName)
+		}
+		finalNames[newName] = oldName
+		if newName != oldName {
+			plan.renames = append(plan.renames, renameMove{src: oldName, dst: newName})
+			renamed[oldName] = struct{}{}
+		}
+	}
+
+	// The new names must be free, or be freed by the rename of their item
+	for _, r := range plan.renames {
+		if _, ok := renamed[r.dst]; ok {
+			continue
+		}
+		free, err := isRenameTargetFree(filepath.Join(dir, r.src), filepath.Join(dir, r.dst))
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			return nil, fmt.Errorf("cannot rename %q to %q: %q already exists", r.src, r.dst, r.dst)
+		}
+	}
+
+	var err error
+	plan.moves, err = orderRenameMoves(dir, plan.renames)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func validateBulkRenameName(name string) error {
+	if name == "" {
+		return errors.New("the name is empty")
+	}
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		return errors.New("the name cannot contain a path separator")
+	}
+	return checkFileNameValidity(name)
+}
+
+// isRenameTargetFree tells whether src can be renamed to dst without replacing
+// another item. On case insensitive file systems, a rename that only changes
+// the case of the name finds src itself at dst
+func isRenameTargetFree(src, dst string) (bool, error) {
+	dstInfo, err := os.Lstat(dst)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	srcInfo, err := os.Lstat(src)
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(srcInfo, dstInfo), nil
+}
+
+// orderRenameMoves orders the renames so that each one is done once its new
+// name is free. The renames left once no more can be done are cycles, like
+// a→b and b→a, broken by moving one of their items to a temporary name first
+func orderRenameMoves(dir string, renames []renameMove) ([]renameMove, error) {
+	// Names that are taken during the renames, to never pick them as temporary names
+	taken := make(map[string]struct{}, len(renames)*2) //nolint:mnd // source and destination
+	pending := make(map[string]string, len(renames))
+	order := make([]string, 0, len(renames))
+	for _, r := range renames {
+		pending[r.src] = r.dst
+		order = append(order, r.src)
+		taken[r.src] = struct{}{}
+		taken[r.dst] = struct{}{}
+	}
+
+	moves := make([]renameMove, 0, len(renames))
+	for len(order) > 0 {
+		var blocked []string
+		for _, src := range order {
+			dst := pending[src]
+			if _, ok := pending[dst]; ok {
+				blocked = append(blocked, src)
+				continue
+			}
+			moves = append(moves, renameMove{src: src, dst: dst})
+			delete(pending, src)
+		}
+		if len(blocked) == len(order) {
+			src := blocked[0]
+			tempName, err := freeTempName(dir, taken)
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, renameMove{src: src, dst: tempName})
+			pending[tempName] = pending[src]
+			delete(pending, src)
+			blocked[0] = tempName
+		}
+		order = blocked
+	}
+	return moves, nil
+}
+
+// A name of dir that is neither in use nor taken, which it is added to
+func freeTempName(dir string, taken map[string]struct{}) (string, error) {
+	for i := 0; ; i++ {
+		name := bulkRenameTempPrefix + strconv.Itoa(i)
+		if _, ok := taken[name]; ok {
+			continue
+		}
+		_, err := os.Lstat(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			taken[name] = struct{}{}
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
+
+// Handles key inputs inside the bulk rename modal. While typing a pattern, the
+// printable keys are left to the inputs, updated via updateComponentState
+func (m *model) bulkRenameKey(msg string) tea.Cmd {
+	// Printable keys, like 'q' or 'j', are a single character
+	typing := m.bulkRenameModal.IsPatternMode() && utf8.RuneCountInString(msg) == 1
+	switch {
+	case slices.Contains(common.Hotkeys.ListUp, msg) && !typing:
+		m.bulkRenameModal.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg) && !typing:
+		m.bulkRenameModal.ListDown()
+	case slices.Contains(bulkrename.KeyNextInput(), msg):
+		return m.bulkRenameModal.NextInput()
+	case slices.Contains(bulkrename.KeyCycleCase(), msg):
+		m.bulkRenameModal.CycleCase()
+	case slices.Contains(bulkrename.KeyConfirm(), msg):
+		return m.confirmBulkRename()
+	case slices.Contains(bulkrename.KeyClose(), msg) && !typing:
+		slog.Debug("Bulk rename cancelled in the preview")
+		m.bulkRenameModal.Close()
+	}
+	return nil
+}
+
+// Validate the renames of the modal again, as the directory may have changed,
+// and execute them
+func (m *model) confirmBulkRename() tea.Cmd {
+	if m.bulkRenameModal.HasConflicts() {
+		slog.Debug("Bulk rename with conflicts cannot be confirmed")
+		return nil
+	}
+	dir := m.bulkRenameModal.GetDir()
+	items := m.bulkRenameModal.GetItems()
+	m.bulkRenameModal.Close()
+
+	oldNames := make([]string, len(items))
+	newNames := make([]string, len(items))
+	for i, item := range items {
+		oldNames[i], newNames[i] = item.OldName, item.NewName
+	}
+	plan, err := buildBulkRenamePlan(dir, oldNames, newNames)
+	if err != nil {
+		m.notifyModel = notify.New(true, bulkRenameErrorTitle, err.Error(), notify.NoAction)
+		return nil
+	}
+	if len(plan.renames) == 0 {
+		return nil
+	}
+
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting bulk rename request", "id", reqID, "dir", plan.dir, "renames cnt", len(plan.renames))
+	return func() tea.Msg {
+		return m.bulkRenameOperation(&m.processBarModel, plan, reqID)
+	}
+}
+
+// Execute the moves of the plan. All the moves done, including the ones to
+// temporary names, are recorded so that undo puts every item back
+func (m *model) bulkRenameOperation(processBarModel *processbar.Model, plan *bulkRenamePlan,
+	reqID int) tea.Msg {
+	if len(plan.moves) == 0 {
+		return NewBulkRenameOperationMsg(processbar.Cancelled, reqID)
+	}
+	p, err := processBarModel.SendAddProcessMsg(plan.renames[0].src, processbar.OpRename, len(plan.moves), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		return NewBulkRenameOperationMsg(processbar.Failed, reqID)
+	}
+
+	steps := make([]journal.Step, 0, len(plan.moves))
+	for _, move := range plan.moves {
+		src, dst := filepath.Join(plan.dir, move.src), filepath.Join(plan.dir, move.dst)
+		p.CurrentFile = move.src
+		err = processbar.Checkpoint(p.Context())
+		if err == nil {
+			err = renameIfFree(src, dst)
+		}
+		if err != nil {
+			if errors.Is(err, processbar.ErrProcessCancelled) {
+				p.MarkCancelled()
+			} else {
+				slog.Error("Error in bulk rename operation", "src", src, "dst", dst, "error", err)
+				p.State = processbar.Failed
+				p.ErrorMsg = err.Error()
+			}
+			break
+		}
+		steps = append(steps, journal.Step{Src: src, Dst: dst})
+		p.Done++
+		processBarModel.TrySendingUpdateProcessMsg(p)
+	}
+	m.journal.Record(journal.KindRename, steps)
+
+	if p.State == processbar.InOperation {
+		p.State = processbar.Successful
+	}
+	markProcessDone(p, processBarModel)
+	return NewBulkRenameOperationMsg(p.State, reqID)
+}
+
+// Rename src to dst, unless an item took the name since the plan was made
+func renameIfFree(src, dst string) error {
+	free, err := isRenameTargetFree(src, dst)
+	if err != nil {
+		return err
+	}
+	if !free {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	return os.Rename(src, dst)
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/bulkrename"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestParseBulkRenameNames(t *testing.T) {
+	assert.Equal(t, []string{"a", "b c"}, parseBulkRenameNames("a\r\nb c\n\n"))
+	assert.Equal(t, []string{"a", "", "b"}, parseBulkRenameNames("a\n\nb"), "empty lines in between are kept")
+	assert.Empty(t, parseBulkRenameNames("\n"))
+}
+
+func TestBuildBulkRenamePlan(t *testing.T) {
+	dir := t.TempDir()
+	utils.SetupFiles(t, filepath.Join(dir, "a"), filepath.Join(dir, "b"), filepath.Join(dir, "c"),
+		filepath.Join(dir, "other"))
+	oldNames := []string{"a", "b", "c"}
+
+	testdata := []struct {
+		name          string
+		newNames      []string
+		expectedErr   string
+		expectedMoves []renameMove
+	}{
+		{
+			name:          "Unchanged names are not renamed",
+			newNames:      []string{"a", "x", "c"},
+			expectedMoves: []renameMove{{"b", "x"}},
+		},
+		{
+			name:          "Chain is renamed from its end",
+			newNames:      []string{"b", "c", "d"},
+			expectedMoves: []renameMove{{"c", "d"}, {"b", "c"}, {"a", "b"}},
+		},
+		{
+			name:     "Swap goes through a temporary name",
+			newNames: []string{"b", "a", "c"},
+			expectedMoves: []renameMove{
+				{"a", bulkRenameTempPrefix + "0"}, {"b", "a"}, {bulkRenameTempPrefix + "0", "b"},
+			},
+		},
+		{
+			name:        "Missing line",
+			newNames:    []string{"a", "b"},
+			expectedErr: "expected 3 names but got 2",
+		},
+		{
+			name:        "Duplicate name",
+			newNames:    []string{"x", "x", "c"},
+			expectedErr: `"a" and "b" would both be named "x"`,
+		},
+		{
+			name:        "Duplicate of an unchanged name",
+			newNames:    []string{"a", "a", "c"},
+			expectedErr: `"a" and "b" would both be named "a"`,
+		},
+		{
+			name:        "Existing item",
+			newNames:    []string{"other", "b", "c"},
+			expectedErr: `"other" already exists`,
+		},
+		{
+			name:        "Empty name",
+			newNames:    []string{"", "b", "c"},
+			expectedErr: "the name is empty",
+		},
+		{
+			name:        "Parent directory",
+			newNames:    []string{"..", "b", "c"},
+			expectedErr: "file name cannot be '.' or '..'",
+		},
+		{
+			name:        "Path separator",
+			newNames:    []string{"dir/a", "b", "c"},
+			expectedErr: "the name cannot contain a path separator",
+		},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := buildBulkRenamePlan(dir, oldNames, tt.newNames)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMoves, plan.moves)
+		})
+	}
+}
+
+func TestOrderRenameMovesAvoidsTakenNames(t *testing.T) {
+	dir := t.TempDir()
+	utils.SetupFiles(t, filepath.Join(dir, bulkRenameTempPrefix+"0"))
+	moves, err := orderRenameMoves(dir, []renameMove{
+		{"a", "b"}, {"b", "c"}, {"c", "a"}, {"d", bulkRenameTempPrefix + "1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []renameMove{
+		{"d", bulkRenameTempPrefix + "1"},
+		{"a", bulkRenameTempPrefix + "2"},
+		{"c", "a"},
+		{"b", "c"},
+		{bulkRenameTempPrefix + "2", "b"},
+	}, moves)
+}
+
+func TestBulkRename(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir := filepath.Join(curTestDir, "dir")
+	utils.SetupDirectories(t, dir)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		utils.SetupFilesWithData(t, []byte(name), filepath.Join(dir, name))
+	}
+
+	m := defaultTestModel(dir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+
+	// What the editor returns, as the editor itself can't run in tests
+	namesFile := filepath.Join(curTestDir, "names.txt")
+	require.NoError(t, os.WriteFile(namesFile, []byte("b.txt\na.txt\nd.txt\n"), 0o600))
+	p.Send(NewBulkRenameEditedMsg(dir, []string{"a.txt", "b.txt", "c.txt"}, namesFile, nil, 0))
+
+	require.Eventually(t, p.getModel().bulkRenameModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+	assert.Equal(t, []bulkrename.Item{
+		{OldName: "a.txt", NewName: "b.txt"},
+		{OldName: "b.txt", NewName: "a.txt"},
+		{OldName: "c.txt", NewName: "d.txt"},
+	}, p.getModel().bulkRenameModal.GetItems())
+	assert.NoFileExists(t, namesFile, "the file of names is removed")
+	assertFileContent(t, filepath.Join(dir, "a.txt"), "a.txt")
+
+	p.SendKey(bulkrename.KeyConfirm()[0])
+	ensureOneProcessDone(t, m)
+	assertFileContent(t, filepath.Join(dir, "a.txt"), "b.txt")
+	assertFileContent(t, filepath.Join(dir, "b.txt"), "a.txt")
+	assertFileContent(t, filepath.Join(dir, "d.txt"), "c.txt")
+	assert.ElementsMatch(t, []string{"a.txt", "b.txt", "d.txt"}, dirNames(t, dir))
+
+	p.SendKey(common.Hotkeys.Undo[0])
+	require.Eventually(t, func() bool {
+		return m.journal.RedoCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		assertFileContent(t, filepath.Join(dir, name), name)
+	}
+	assert.ElementsMatch(t, []string{"a.txt", "b.txt", "c.txt"}, dirNames(t, dir))
+}
+
+func TestBulkRenameRejected(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir := filepath.Join(curTestDir, "dir")
+	utils.SetupDirectories(t, dir)
+	utils.SetupFiles(t, filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"))
+
+	m := defaultTestModel(dir)
+	namesFile := filepath.Join(curTestDir, "names.txt")
+	require.NoError(t, os.WriteFile(namesFile, []byte("c.txt\nc.txt\n"), 0o600))
+	TeaUpdate(m, NewBulkRenameEditedMsg(dir, []string{"a.txt", "b.txt"}, namesFile, nil, 0))
+
+	assert.False(t, m.bulkRenameModal.IsOpen())
+	assert.True(t, m.notifyModel.IsOpen())
+	assert.Equal(t, bulkRenameErrorTitle, m.notifyModel.GetTitle())
+	assert.ElementsMatch(t, []string{"a.txt", "b.txt"}, dirNames(t, dir), "nothing is renamed")
+}
+
+func TestPatternRename(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir := filepath.Join(curTestDir, "dir")
+	utils.SetupDirectories(t, dir)
+	files := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"), filepath.Join(dir, "taken")}
+	utils.SetupFiles(t, files...)
+
+	m := defaultTestModel(dir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	setupPanelModeAndSelection(t, m, true, "", files[:2])
+
+	p.SendKey(common.Hotkeys.PatternRename[0])
+	require.Eventually(t, p.getModel().bulkRenameModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+	// Keys that are hotkeys elsewhere, like 'q', are typed
+	p.SendKey(bulkrename.KeyNextInput()[0])
+	for _, key := range "q{n:2}" {
+		p.SendKey(string(key))
+	}
+	p.SendKey(bulkrename.KeyCycleCase()[0])
+	p.SendKey(bulkrename.KeyCycleCase()[0])
+	require.Eventually(t, func() bool {
+		items := p.getModel().bulkRenameModal.GetItems()
+		return len(items) == 2 && items[1].NewName == "Q02"
+	}, DefaultTestTimeout, DefaultTestTick, "%v", p.getModel().bulkRenameModal.GetItems())
+
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	ensureOneProcessDone(t, m)
+	assert.ElementsMatch(t, []string{"Q01", "Q02", "taken"}, dirNames(t, dir))
+	assert.False(t, p.getModel().bulkRenameModal.IsOpen())
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/dircompare"
+)
+
+// comparisonRestartDelay is how long an outdated comparison waits before
+// walking the trees again, so that the changes made meanwhile, like the files
+// of a copy, are compared by the same walk
+const comparisonRestartDelay = time.Second
+
+// toggleComparison compares the directory of the focused panel with the one
+// of the other panel, or stops the comparison running if it is done the same
+// way. A comparison done the other way is replaced
+func (m *model) toggleComparison(byContent bool) {
+	if m.comparison != nil {
+		sameMode := m.comparison.ByContent == byContent
+		slog.Debug("Stopping the comparison", "left", m.comparison.Left, "right", m.comparison.Right)
+		m.comparison.Cancel()
+		m.comparison = nil
+		m.comparisonPending = false
+		if sameMode {
+			return
+		}
+	}
+	m.startComparison(byContent)
+}
+
+func (m *model) startComparison(byContent bool) {
+	panel := m.getFocusedFilePanel()
+	otherPanel := m.fileModel.GetOtherFilePanel()
+	if otherPanel == nil || panel.InArchive() || otherPanel.InArchive() {
+		slog.Debug("Comparison needs two panels outside of archives")
+		return
+	}
+	comparison, err := dircompare.New(panel.Location, otherPanel.Location, byContent)
+	if err != nil {
+		slog.Warn("Cannot compare the directories", "left", panel.Location,
+			"right", otherPanel.Location, "error", err)
+		return
+	}
+	m.comparison = comparison
+	m.comparisonPending = true
+	m.comparisonOutdated = false
+	m.comparisonRestartScheduled = false
+}
+
+// getComparisonCmd gives the comparison to the panels in the compared trees,
+// and returns the Cmd walking them if they have to be compared again
+func (m *model) getComparisonCmd() tea.Cmd {
+	for _, panel := range m.fileModel.Panels() {
+		panel.Compare = nil
+		if m.comparison != nil && !panel.InArchive() && m.comparison.Contains(panel.Location) {
+			panel.Compare = m.comparison
+		}
+	}
+	if m.comparison == nil {
+		return nil
+	}
+	if m.comparisonOutdated && !m.comparison.Running() && !m.comparisonRestartScheduled {
+		m.comparisonRestartScheduled = true
+		comparison := m.comparison
+		reqID := m.nextIoReqCnt()
+		return tea.Tick(comparisonRestartDelay, func(time.Time) tea.Msg {
+			return NewComparisonRestartMsg(comparison, reqID)
+		})
+	}
+	if !m.comparisonPending {
+		return nil
+	}
+	m.comparisonPending = false
+	comparison := m.comparison
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting comparison request", "id", reqID, "left", comparison.Left,
+		"right", comparison.Right, "by content", comparison.ByContent)
+	return func() tea.Msg {
+		result, err := comparison.Run()
+		return NewComparisonMsg(comparison, result, err, reqID)
+	}
+}
+
+// invalidateComparison compares the trees again if one of the paths is in
+// them, or whatever paths are if nil. The walk running is not interrupted, so
+// that changes made all along still show its result: the trees are walked
+// again once it is done, see getComparisonCmd. The current result is shown
+// meanwhile
+func (m *model) invalidateComparison(paths []string) {
+	if m.comparison == nil || m.comparisonRestartScheduled {
+		return
+	}
+	if paths != nil && !slices.ContainsFunc(paths, m.comparison.Contains) {
+		return
+	}
+	m.comparisonOutdated = true
+}
+
+// restartComparison walks the trees of the outdated comparison again
+func (m *model) restartComparison() {
+	slog.Debug("Comparing the changed directories again", "left", m.comparison.Left, "right", m.comparison.Right)
+	m.comparison = m.comparison.Restart()
+	m.comparisonPending = true
+	m.comparisonOutdated = false
+	m.comparisonRestartScheduled = false
+}
+
+// selectDifferences selects the items of the focused panel that differ from
+// the other tree, to copy them there
+func (m *model) selectDifferences() {
+	panel := m.getFocusedFilePanel()
+	if panel.Compare == nil || panel.Compare.Differences() < 0 {
+		slog.Debug("No comparison result to select the differences of", "location", panel.Location)
+		return
+	}
+	count := panel.SelectDifferences()
+	slog.Debug("Selected the differences", "location", panel.Location, "count", count)
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dircompare"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestComparePanels(t *testing.T) {
+	curTestDir := t.TempDir()
+	left := filepath.Join(curTestDir, "left")
+	right := filepath.Join(curTestDir, "right")
+	utils.SetupDirectories(t, left, right, filepath.Join(left, "only_dir"))
+	utils.SetupFilesWithData(t, []byte("12345"), filepath.Join(left, "same"), filepath.Join(right, "same"),
+		filepath.Join(left, "newer"), filepath.Join(right, "only_file"))
+	utils.SetupFilesWithData(t, []byte("123"), filepath.Join(right, "newer"))
+	older := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(right, "newer"), older, older))
+
+	m := defaultTestModel(left, right)
+	TeaUpdate(m, nil)
+	p := NewTestTeaProgWithEventLoop(t, m)
+
+	p.SendKey(common.Hotkeys.SelectDifferences[0])
+	p.SendKey(common.Hotkeys.ComparePanels[0])
+	assert.Eventually(t, func() bool {
+		compare := p.getModel().fileModel.FilePanels[1].Compare
+		return compare != nil && !compare.Running()
+	}, DefaultTestTimeout, DefaultTestTick, "both panels get the comparison")
+	assert.Zero(t, p.getModel().getFocusedFilePanel().SelectedCount(), "nothing to select before the comparison")
+
+	compare := p.getModel().comparison
+	assert.Equal(t, 3, compare.Differences())
+	testdata := []struct {
+		path           string
+		expectedStatus dircompare.Status
+	}{
+		{filepath.Join(left, "same"), dircompare.Same},
+		{filepath.Join(left, "only_dir"), dircompare.OnlyHere},
+		{filepath.Join(left, "newer"), dircompare.Newer},
+		{filepath.Join(right, "newer"), dircompare.Older},
+		{filepath.Join(right, "only_file"), dircompare.OnlyHere},
+	}
+	for _, tt := range testdata {
+		status, ok := compare.StatusOf(tt.path)
+		require.True(t, ok, tt.path)
+		assert.Equal(t, tt.expectedStatus, status, tt.path)
+	}
+
+	p.SendKey(common.Hotkeys.SelectDifferences[0])
+	assert.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().SelectedCount() == 2
+	}, DefaultTestTimeout, DefaultTestTick, "only_dir and newer are selected")
+	panel := p.getModel().getFocusedFilePanel()
+	assert.True(t, panel.CheckSelected(filepath.Join(left, "only_dir")))
+	assert.True(t, panel.CheckSelected(filepath.Join(left, "newer")))
+
+	// The changes in the trees compare them again, once they paused
+	require.Eventually(t, func() bool {
+		return p.getModel().fileModel.FilePanels[1].Watched
+	}, DefaultTestTimeout, DefaultTestTick)
+	utils.SetupFiles(t, filepath.Join(right, "new_file"))
+	utils.SetupFiles(t, filepath.Join(left, "new_file_left"))
+	assert.Eventually(t, func() bool {
+		return p.getModel().comparison.Differences() == 5
+	}, comparisonRestartDelay+DefaultTestTimeout, DefaultTestTick)
+
+	p.SendKey(common.Hotkeys.ComparePanelsByContent[0])
+	assert.Eventually(t, func() bool {
+		compare := p.getModel().comparison
+		return compare != nil && compare.ByContent && !compare.Running()
+	}, DefaultTestTimeout, DefaultTestTick, "the other key compares the other way")
+	assert.Equal(t, 5, p.getModel().comparison.Differences())
+
+	p.SendKey(common.Hotkeys.ComparePanelsByContent[0])
+	assert.Eventually(t, func() bool {
+		return p.getModel().comparison == nil && p.getModel().fileModel.FilePanels[1].Compare == nil
+	}, DefaultTestTimeout, DefaultTestTick, "pressing the same key again stops comparing")
+}
+
+func TestCopyDifferences(t *testing.T) {
+	curTestDir := t.TempDir()
+	left := filepath.Join(curTestDir, "left")
+	right := filepath.Join(curTestDir, "right")
+	utils.SetupDirectories(t, filepath.Join(left, "both_dir"), filepath.Join(right, "both_dir"))
+	utils.SetupFilesWithData(t, []byte("12345"), filepath.Join(left, "newer"),
+		filepath.Join(left, "both_dir", "only_left"))
+	utils.SetupFilesWithData(t, []byte("123"), filepath.Join(right, "newer"),
+		filepath.Join(right, "both_dir", "only_right"))
+	older := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(right, "newer"), older, older))
+
+	m := defaultTestModel(left, right)
+	// Overwritten permanently, moving to the trash is tested with the paste
+	m.hasTrash = false
+	TeaUpdate(m, nil)
+	p := NewTestTeaProgWithEventLoop(t, m)
+
+	p.SendKey(common.Hotkeys.ComparePanels[0])
+	require.Eventually(t, func() bool {
+		compare := p.getModel().getFocusedFilePanel().Compare
+		return compare != nil && !compare.Running()
+	}, DefaultTestTimeout, DefaultTestTick)
+	p.SendKey(common.Hotkeys.SelectDifferences[0])
+	require.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().SelectedCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick)
+	panel := p.getModel().getFocusedFilePanel()
+	assert.True(t, panel.CheckSelected(filepath.Join(left, "newer")))
+	assert.False(t, panel.CheckSelected(filepath.Join(left, "both_dir")), "entered to select its differences")
+
+	p.SendKey(common.Hotkeys.CopyItems[0])
+	p.SendKey(common.Hotkeys.NextFilePanel[0])
+	p.SendKey(common.Hotkeys.PasteItems[0])
+	answerPasteConflict(t, p, common.Hotkeys.ConflictOverwrite[0])
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(filepath.Join(right, "newer"))
+		return err == nil && string(data) == "12345"
+	}, DefaultTestTimeout, DefaultTestTick)
+	assert.FileExists(t, filepath.Join(right, "both_dir", "only_right"))
+}
+
+func TestCompareSameDirectory(t *testing.T) {
+	curTestDir := t.TempDir()
+	m := defaultTestModel(curTestDir, curTestDir)
+	TeaUpdate(m, nil)
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ComparePanelsByContent[0]))
+	assert.Nil(t, m.comparison)
+	assert.Nil(t, m.getFocusedFilePanel().Compare)
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+)
+
+// conflictPrompt asks the user how to resolve the conflicts of the items of
+// an operation with the existing items, one at a time. Once all are resolved,
+// onResolved starts the operation with the resolutions, keyed by item
+type conflictPrompt[T any] struct {
+	conflicts   []T
+	resolved    int
+	resolutions map[string]conflictResolution
+	key         func(item T) string
+	// Content of the prompt for the item
+	describe func(item T) string
+	// Offer to overwrite only the items older than the new ones
+	overwriteIfNewer bool
+	onResolved       func(resolutions map[string]conflictResolution) tea.Cmd
+}
+
+// pendingConflicts is the conflictPrompt of an operation waiting for the user,
+// whatever its items are
+type pendingConflicts interface {
+	notifyModel() notify.Model
+	resolve(resolution conflictResolution, applyToAll bool) bool
+	submit() tea.Cmd
+}
+
+func (p *conflictPrompt[T]) notifyModel() notify.Model {
+	content := p.describe(p.conflicts[p.resolved])
+	if remaining := len(p.conflicts) - p.resolved - 1; remaining > 0 {
+		content += fmt.Sprintf("\n(%d more conflicts)", remaining)
+	}
+	choices := []notify.Choice{
+		{Keys: common.Hotkeys.ConflictOverwrite, Label: "Overwrite", Action: notify.ConflictOverwriteAction},
+		{Keys: common.Hotkeys.ConflictOverwriteIfNewer, Label: "Overwrite if newer",
+			Action: notify.ConflictOverwriteIfNewerAction},
+		{Keys: common.Hotkeys.ConflictSkip, Label: "Skip", Action: notify.ConflictSkipAction},
+		{Keys: common.Hotkeys.ConflictKeepBoth, Label: "Keep both", Action: notify.ConflictKeepBothAction},
+	}
+	if !p.overwriteIfNewer {
+		choices = slices.Delete(choices, 1, 2)
+	}
+	return notify.NewWithChoices("Item already exists", content, notify.ConflictKeepBothAction, choices)
+}
+
+// resolve records the resolution for the current conflict, or all the
+// remaining ones. It returns false once there are no more conflicts
+func (p *conflictPrompt[T]) resolve(resolution conflictResolution, applyToAll bool) bool {
+	if p.resolutions == nil {
+		p.resolutions = make(map[string]conflictResolution, len(p.conflicts))
+	}
+	end := p.resolved + 1
+	if applyToAll {
+		end = len(p.conflicts)
+	}
+	for ; p.resolved < end; p.resolved++ {
+		p.resolutions[p.key(p.conflicts[p.resolved])] = resolution
+	}
+	return p.resolved < len(p.conflicts)
+}
+
+func (p *conflictPrompt[T]) submit() tea.Cmd {
+	return p.onResolved(p.resolutions)
+}
+
+func conflictResolutionFromAction(action notify.ConfirmActionType) conflictResolution {
+	switch action { //nolint:exhaustive // Only the conflict actions are relevant
+	case notify.ConflictOverwriteAction:
+		return conflictOverwrite
+	case notify.ConflictOverwriteIfNewerAction:
+		return conflictOverwriteIfNewer
+	case notify.ConflictSkipAction:
+		return conflictSkip
+	default:
+		return conflictKeepBoth
+	}
+}
+
+func (m *model) openConflictPrompt(prompt pendingConflicts) {
+	m.conflictPrompt = prompt
+	m.notifyModel = prompt.notifyModel()
+}
+
+// Move to the next conflict, or start the operation once all are resolved
+func (m *model) resolveConflict(action notify.ConfirmActionType) tea.Cmd {
+	prompt := m.conflictPrompt
+	if prompt == nil {
+		slog.Error("Conflict resolved without a pending operation")
+		return nil
+	}
+	if prompt.resolve(conflictResolutionFromAction(action), m.notifyModel.IsApplyToAll()) {
+		m.notifyModel = prompt.notifyModel()
+		return nil
+	}
+	m.conflictPrompt = nil
+	return prompt.submit()
+}
+
+func (m *model) cancelPendingConflicts() {
+	slog.Debug("Operation cancelled during conflict resolution")
+	m.conflictPrompt = nil
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// openContentSearch opens the modal searching the content of the files below
+// the focused panel's directory
+func (m *model) openContentSearch() {
+	if m.isReadOnlyPanel("content search") {
+		return
+	}
+	m.contentSearchModal.Open(m.getFocusedFilePanel().Location, m.fileModel.DisplayDotFiles)
+}
+
+// showFileInFocusedPanel goes to the directory of the file in the focused
+// panel, with the cursor on the file
+func (m *model) showFileInFocusedPanel(path string) error {
+	if err := m.updateCurrentFilePanelDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	panel := m.getFocusedFilePanel()
+	panel.TargetFile = filepath.Base(path)
+	panel.UpdateElementsIfNeeded(true, m.fileModel.DisplayDotFiles)
+	return nil
+}
+
+// openFileWithEditorAtLine opens the file with the editor, at the given line
+func (m *model) openFileWithEditorAtLine(path string, line int) tea.Cmd {
+	slog.Debug("Opening file with editor at line", "path", path, "line", line)
+	return tea.ExecProcess(fileEditorCommandAtLine(path, line), func(err error) tea.Msg {
+		return editorFinishedMsg{err}
+	})
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestContentSearch(t *testing.T) {
+	curTestDir := t.TempDir()
+	deepDir := filepath.Join(curTestDir, "sub", "deep")
+	utils.SetupDirectories(t, deepDir)
+	utils.SetupFilesWithData(t, []byte("nothing here"), filepath.Join(curTestDir, "a.txt"))
+	utils.SetupFiles(t, filepath.Join(deepDir, "b.txt"))
+	utils.SetupFilesWithData(t, []byte("first\nsecond with needle\n"), filepath.Join(deepDir, "target.txt"))
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	p.SendKey(common.Hotkeys.OpenContentSearch[0])
+	require.Eventually(t, func() bool {
+		return p.getModel().contentSearchModal.IsOpen()
+	}, DefaultTestTimeout, DefaultTestTick)
+	for _, key := range "needle" {
+		p.SendKey(string(key))
+	}
+	require.Eventually(t, func() bool {
+		modal := &p.getModel().contentSearchModal
+		return !modal.IsSearching() && len(modal.GetResults()) == 1
+	}, DefaultTestTimeout, DefaultTestTick)
+	match, ok := p.getModel().contentSearchModal.GetSelected()
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(deepDir, "target.txt"), match.Path)
+	assert.Equal(t, 2, match.Line)
+
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	require.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().Location == deepDir
+	}, DefaultTestTimeout, DefaultTestTick)
+	assert.False(t, p.getModel().contentSearchModal.IsOpen())
+	assert.Equal(t, "target.txt", p.getModel().getFocusedFilePanel().GetFocusedItem().Name,
+		"the cursor is on the matching file")
+}
+
+func TestFileEditorCommandAtLine(t *testing.T) {
+	originalEditor := common.Config.Editor
+	t.Cleanup(func() { common.Config.Editor = originalEditor })
+
+	testdata := []struct {
+		editor   string
+		expected []string
+	}{
+		{"nvim", []string{"nvim", "+12", "/a/b.txt"}},
+		{"emacs -nw", []string{"emacs", "-nw", "+12", "/a/b.txt"}},
+		{"hx", []string{"hx", "/a/b.txt:12"}},
+		{"code --wait", []string{"code", "--wait", "-g", "/a/b.txt:12"}},
+		{"notepad", []string{"notepad", "/a/b.txt"}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.editor, func(t *testing.T) {
+			common.Config.Editor = tt.editor
+			assert.Equal(t, tt.expected, fileEditorCommandAtLine("/a/b.txt", 12).Args)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"log/slog"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+)
+
+// newDirSizeCache returns the cache of the directory sizes, or nil if their
+// computation is disabled
+func newDirSizeCache() *dirsize.Cache {
+	if !common.Config.DirectorySizes {
+		return nil
+	}
+	return dirsize.NewCache()
+}
+
+// getDirSizeCmd gives the panels the directory sizes, cancels the walks of
+// the panels that navigated away, and returns the Cmd computing the size of
+// the next directory of each panel
+func (m *model) getDirSizeCmd() tea.Cmd {
+	if m.dirSizes == nil {
+		return nil
+	}
+	panels := m.fileModel.Panels()
+	dirs := make([]string, 0, len(panels))
+	for _, panel := range panels {
+		if panel.InArchive() {
+			panel.DirSizes = nil
+			continue
+		}
+		panel.DirSizes = m.dirSizes
+		dirs = append(dirs, panel.Location)
+	}
+	m.dirSizes.CancelExcept(dirs)
+
+	var cmds []tea.Cmd
+	for _, panel := range panels {
+		if panel.DirSizes == nil || m.dirSizes.Running(panel.Location) {
+			continue
+		}
+		path, modTime, ok := panel.NextUnsizedDir()
+		if !ok {
+			continue
+		}
+		job := m.dirSizes.Start(panel.Location, path, modTime)
+		reqID := m.nextIoReqCnt()
+		slog.Debug("Submitting directory size request", "id", reqID, "path", path)
+		cmds = append(cmds, func() tea.Msg {
+			size, err := job.Run()
+			return NewDirSizeMsg(job, size, err, reqID)
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// invalidateDirSizes drops the sizes of the directories containing paths,
+// or all of them if paths is nil
+func (m *model) invalidateDirSizes(paths []string) {
+	if m.dirSizes == nil {
+		return
+	}
+	if paths == nil {
+		m.dirSizes.InvalidateAll()
+		return
+	}
+	m.dirSizes.Invalidate(paths)
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestDirSizes(t *testing.T) {
+	curTestDir := t.TempDir()
+	bigDir := filepath.Join(curTestDir, "a_big")
+	smallDir := filepath.Join(curTestDir, "b_small")
+	utils.SetupDirectories(t, filepath.Join(bigDir, "nested"), smallDir)
+	utils.SetupFilesWithData(t, make([]byte, 2048), filepath.Join(bigDir, "nested", "file.bin"))
+	utils.SetupFilesWithData(t, make([]byte, 10), filepath.Join(smallDir, "file.bin"),
+		filepath.Join(smallDir, "file2.bin"))
+
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := origConfig
+	cfg.DirectorySizes = true
+	common.SetConfig(cfg)
+
+	m := defaultTestModel(curTestDir)
+	require.NotNil(t, m.dirSizes)
+	// The Cmd of the walk started by the test setup is dropped
+	m.dirSizes = dirsize.NewCache()
+	panel := m.getFocusedFilePanel()
+	panel.SortKind = sortmodel.SortBySize
+	panel.UpdateElementsIfNeeded(true, m.fileModel.DisplayDotFiles)
+	require.Equal(t, "a_big", panel.GetElementAtIdx(0).Name, "no size computed yet")
+
+	p := NewTestTeaProgWithEventLoop(t, m)
+	assert.Eventually(t, func() bool {
+		panel := p.getModel().getFocusedFilePanel()
+		return panel.GetElementAtIdx(0).Name == "b_small" && panel.GetFocusedItem().Name == "a_big"
+	}, DefaultTestTimeout, DefaultTestTick, "sorted by the total sizes, the cursor following its item")
+
+	info := panel.GetElementAtIdx(1).Info
+	size, ok := m.dirSizes.Size(bigDir, info.ModTime())
+	assert.True(t, ok)
+	assert.Equal(t, int64(2048), size)
+}
+
+func TestDirSizesDisabled(t *testing.T) {
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := origConfig
+	cfg.DirectorySizes = false
+	common.SetConfig(cfg)
+
+	m := defaultTestModel(t.TempDir())
+	assert.Nil(t, m.dirSizes)
+	assert.Nil(t, m.getDirSizeCmd())
+	assert.Nil(t, m.getFocusedFilePanel().DirSizes)
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Names of the items of the archive that already exist in dest
+func findExtractConflicts(dest string, topLevel []string) []string {
+	var conflicts []string
+	for _, name := range topLevel {
+		if _, err := os.Lstat(filepath.Join(dest, name)); err == nil {
+			conflicts = append(conflicts, name)
+		}
+	}
+	return conflicts
+}
+
+func (m *model) newExtractConflictPrompt(archive, dest string, entries int,
+	conflicts []string) *conflictPrompt[string] {
+	return &conflictPrompt[string]{
+		conflicts: conflicts,
+		key:       func(name string) string { return name },
+		describe: func(name string) string {
+			return fmt.Sprintf("%q already exists in %s", name, dest)
+		},
+		overwriteIfNewer: true,
+		onResolved: func(resolutions map[string]conflictResolution) tea.Cmd {
+			reqID := m.nextIoReqCnt()
+			slog.Debug("Submitting extract request after conflict resolution", "id", reqID,
+				"archive", archive, "dest", dest)
+			return func() tea.Msg {
+				return m.executeExtractOperation(archive, dest, entries, resolutions, reqID)
+			}
+		},
+	}
+}
@@ -188,6 +188,8 @@ func TestCompressSelectedFiles(t *testing.T) {
 			}
 
 			p.SendKey(common.Hotkeys.CompressFile[0])
+			assert.Eventually(t, p.getModel().compressModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+			p.SendKey(common.Hotkeys.Confirm[0])
 
 			// This is a bit of an indirect validation, but there aren't many ways.
 			// We many add a process type later, and ensure that a process of
@@ -195,6 +197,10 @@ func TestCompressSelectedFiles(t *testing.T) {
 			ensureOneProcessDone(t, m)
 			zipFile := filepath.Join(tt.startDir, tt.expectedZipName)
 			require.FileExists(t, zipFile, "Expected zip file does not exist after compression")
+			// The panel is re-read once the filesystem watcher reports the zip file
+			require.Eventually(t, func() bool {
+				return m.getFocusedFilePanel().FindElementIndexByLocation(zipFile) != -1
+			}, DefaultTestTimeout, DefaultTestTick, "zip file should be shown in the panel")
 
 			setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), zipFile)
 
@@ -207,6 +213,8 @@ func TestCompressSelectedFiles(t *testing.T) {
 				"Panel location for extraction is a directory, expected a zip file: %s", selectedItemLocation)
 
 			p.SendKey(common.Hotkeys.ExtractFile[0])
+			assert.Eventually(t, p.getModel().extractModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+			p.SendKey(common.Hotkeys.Confirm[0])
 			// File extraction is supposedly async. So function's return doesn't means its done.
 			extractedDir := filepath.Join(tt.startDir, tt.extractedDirName)
 
@@ -236,6 +244,62 @@ func TestCompressSelectedFiles(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, entries)
 	})
+
+	t.Run("Compress with another format", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(common.Hotkeys.CompressFile[0])
+		assert.Eventually(t, m.compressModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		// zip is preselected, tar.gz is two rows below
+		p.SendKey(common.Hotkeys.ListDown[0])
+		p.SendKey(common.Hotkeys.ListDown[0])
+		p.SendKey(common.Hotkeys.Confirm[0])
+
+		ensureOneProcessDone(t, m)
+		assert.FileExists(t, filepath.Join(dir1, "file2.tar.gz"))
+		assert.False(t, m.compressModal.IsOpen())
+	})
+}
+
+func TestExtractTargets(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	zipPath := filepath.Join(dir1, "archive.zip")
+	utils.SetupDirectories(t, dir1, dir2)
+	writeTestZip(t, zipPath, []testArchiveEntry{{name: "a.txt", content: "new"}})
+
+	t.Run("Extract here over an existing file", func(t *testing.T) {
+		utils.SetupFilesWithData(t, []byte("old"), filepath.Join(dir1, "a.txt"))
+		m := defaultTestModel(dir1)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), zipPath)
+
+		p.SendKey(common.Hotkeys.ExtractFile[0])
+		assert.Eventually(t, p.getModel().extractModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		// The new directory is preselected, here is the row above
+		p.SendKey(common.Hotkeys.ListUp[0])
+		p.SendKey(common.Hotkeys.Confirm[0])
+		answerPasteConflict(t, p, common.Hotkeys.ConflictOverwrite[0])
+
+		ensureOneProcessDone(t, m)
+		assertFileContent(t, filepath.Join(dir1, "a.txt"), "new")
+		assert.NoDirExists(t, filepath.Join(dir1, "archive"))
+	})
+
+	t.Run("Extract to the other panel", func(t *testing.T) {
+		m := defaultTestModel(dir1, dir2)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), zipPath)
+
+		p.SendKey(common.Hotkeys.ExtractFile[0])
+		assert.Eventually(t, p.getModel().extractModal.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		p.SendKey(common.Hotkeys.ListDown[0])
+		p.SendKey(common.Hotkeys.Confirm[0])
+
+		ensureOneProcessDone(t, m)
+		assertFileContent(t, filepath.Join(dir2, "a.txt"), "new")
+	})
 }
 
 func TestPasteItem(t *testing.T) {
@@ -429,6 +493,7 @@ func TestPasteItem(t *testing.T) {
 
 		// Paste again to test duplicate handling
 		p.SendKey(common.Hotkeys.PasteItems[0])
+		answerPasteConflict(t, p, common.Hotkeys.ConflictKeepBoth[0])
 
 		// Verify duplicate file with different name
 		verifyDestinationFiles(t, destDir, []string{"duplicate(1).txt"})
@@ -537,3 +602,92 @@ func TestRunFileProcessorMutex(t *testing.T) {
 			assert.True(t, m.mutexErrorModal.TryLock())
 		})
 }
+
+func TestCopyFileCancelled(t *testing.T) {
+	curTestDir := t.TempDir()
+	src := filepath.Join(curTestDir, "src.txt")
+	dst := filepath.Join(curTestDir, "dst.txt")
+	utils.SetupFilesWithData(t, []byte(strings.Repeat("superfile", 1000)), src)
+	info, err := os.Stat(src)
+	require.NoError(t, err)
+
+	control := processbar.NewControl()
+	control.Cancel()
+	err = copyFile(control, src, dst, info)
+	require.ErrorIs(t, err, processbar.ErrProcessCancelled)
+	assert.NoFileExists(t, dst, "Partially copied file should be removed")
+
+	require.NoError(t, copyFile(processbar.NewControl(), src, dst, info))
+	assert.FileExists(t, dst)
+}
+
+func TestPasteLink(t *testing.T) {
+	curTestDir := t.TempDir()
+	sourceDir := filepath.Join(curTestDir, "source")
+	destDir := filepath.Join(curTestDir, "dest")
+	subDir := filepath.Join(sourceDir, "subdir")
+	file1 := filepath.Join(sourceDir, "file1.txt")
+	utils.SetupDirectories(t, sourceDir, destDir, subDir)
+	utils.SetupFiles(t, file1)
+
+	testdata := []struct {
+		name           string
+		hotkey         string
+		operation      processbar.OperationType
+		expectedTarget string
+	}{
+		{"Symlink", common.Hotkeys.PasteSymlink[0], processbar.OpSymlink, file1},
+		{"Relative symlink", common.Hotkeys.PasteRelativeSymlink[0], processbar.OpSymlink,
+			filepath.Join("..", "..", "source", "file1.txt")},
+		{"Hardlink", common.Hotkeys.PasteHardlink[0], processbar.OpHardlink, ""},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			linkDir := filepath.Join(destDir, strings.ReplaceAll(tt.name, " ", "_"))
+			utils.SetupDirectories(t, linkDir)
+			link := filepath.Join(linkDir, "file1.txt")
+			m := setupModelAndPerformOperation(t, sourceDir, false, "file1.txt", nil, true)
+			p := NewTestTeaProgWithEventLoop(t, m)
+			navigateToTargetDir(t, m, sourceDir, linkDir)
+
+			p.SendKey(tt.hotkey)
+			ensureOneProcessDone(t, m)
+			assert.Equal(t, tt.operation, m.processBarModel.GetProcessesSlice()[0].Operation)
+			assert.FileExists(t, file1, "source kept, even when cut")
+			assert.NotEmpty(t, p.getModel().clipboard.GetItems(), "clipboard kept")
+
+			linkInfo, err := os.Lstat(link)
+			require.NoError(t, err)
+			if tt.expectedTarget == "" {
+				srcInfo, err := os.Stat(file1)
+				require.NoError(t, err)
+				assert.True(t, os.SameFile(srcInfo, linkInfo))
+			} else {
+				target, err := os.Readlink(link)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedTarget, target)
+				assert.FileExists(t, link, "link resolves to the source")
+			}
+
+			// A second link is renamed like a copy, and undo removes it
+			p.SendKey(tt.hotkey)
+			assert.Eventually(t, func() bool {
+				_, err := os.Lstat(filepath.Join(linkDir, "file1(1).txt"))
+				return err == nil && m.journal.UndoCount() == 2
+			}, DefaultTestTimeout, DefaultTestTick)
+			p.SendKey(common.Hotkeys.Undo[0])
+			assert.Eventually(t, func() bool {
+				_, err := os.Lstat(filepath.Join(linkDir, "file1(1).txt"))
+				return os.IsNotExist(err)
+			}, DefaultTestTimeout, DefaultTestTick)
+			_, err = os.Lstat(link)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Hardlink a directory", func(t *testing.T) {
+		_, err := createLink(subDir, destDir, linkHardlink)
+		require.ErrorIs(t, err, errHardlinkDirectory)
+		assert.NoDirExists(t, filepath.Join(destDir, "subdir"))
+	})
+}
@@ -12,7 +12,11 @@ import (
 	"time"
 
 	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/journal"
 	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
+	"github.com/yorukot/superfile/src/internal/ui/extractmodel"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/spferror"
 	"github.com/yorukot/superfile/src/pkg/utils"
@@ -62,11 +66,67 @@ func formatFileError(filePath string, err error) string {
 	return err.Error()
 }
 
+// makeItemProcessor runs itemFunc on each item. It stops at the first error,
+// for the user to skip the item or abort, or when the process is cancelled.
+// itemFunc can update the process, like its summary
+func makeItemProcessor(process processbar.Process, processBarModel *processbar.Model,
+	itemFunc func(process *processbar.Process, item string) error) processbar.FileListProcessor {
+	processorFunction := func(items []string) (processbar.Process, []string) {
+		notProcessed := make([]string, 0)
+		if len(items) == 0 {
+			markProcessDone(process, processBarModel)
+			return process, notProcessed
+		}
+		for i, item := range items {
+			if processbar.Checkpoint(process.Context()) != nil {
+				process.MarkCancelled()
+				break
+			}
+			process.CurrentFile = filepath.Base(item)
+			if err := itemFunc(&process, item); err != nil {
+				process.State = processbar.Failed
+				slog.Error("Error in file operation", "item", item, "operation", process.Operation, "error", err)
+				process.ErrorMsg = err.Error()
+				notProcessed = items[i:]
+				break
+			}
+			process.Done++
+			processBarModel.TrySendingUpdateProcessMsg(process)
+		}
+
+		switch process.State {
+		case processbar.Cancelled:
+			markProcessDone(process, processBarModel)
+		case processbar.Failed:
+			// Wait for the user to skip or abort
+		default:
+			process.State = processbar.Successful
+			markProcessDone(process, processBarModel)
+		}
+		return process, notProcessed
+	}
+	return processorFunction
+}
+
+// isReadOnlyPanel tells whether the focused panel is browsing an archive,
+// where nothing can be changed, or opened by external programs
+func (m *model) isReadOnlyPanel(operation string) bool {
+	panel := m.getFocusedFilePanel()
+	if !panel.InArchive() {
+		return false
+	}
+	slog.Debug("Operation not allowed inside an archive", "operation", operation, "location", panel.Location)
+	return true
+}
+
 // Create a file in the currently focus file panel
 // TODO: Fix it. It doesn't creates a new file. It just opens a file model,
 // that allows you to create a file. Actual creation happens here - createItem() in handle_modal.go
 func (m *model) panelCreateNewFile() {
 	panel := m.getFocusedFilePanel()
+	if m.isReadOnlyPanel("create") {
+		return
+	}
 
 	m.typingModal.location = panel.Location
 	m.typingModal.open = true
@@ -112,12 +172,14 @@ func (m *model) warnModalForRenaming() tea.Cmd {
 // Actual rename happens at confirmRename() in handle_modal.go
 func (m *model) panelItemRename() {
 	panel := m.getFocusedFilePanel()
-	if panel.Empty() {
+	if panel.Empty() || m.isReadOnlyPanel("rename") {
 		return
 	}
 
 	cursorPos := -1
-	nameRunes := []rune(panel.GetFocusedItem().Name)
+	// The name of the results of a recursive search is a path
+	name := filepath.Base(panel.GetFocusedItem().Location)
+	nameRunes := []rune(name)
 	nameLen := len(nameRunes)
 	for i := nameLen - 1; i >= 0; i-- {
 		if nameRunes[i] == '.' {
@@ -138,7 +200,7 @@ func (m *model) panelItemRename() {
 	panel.Rename = common.GenerateRenameTextInput(
 		m.fileModel.SinglePanelWidth-common.InnerPadding,
 		cursorPos,
-		panel.GetFocusedItem().Name)
+		name)
 }
 
 func (m *model) getDeleteCmd(permDelete bool) tea.Cmd {
@@ -149,7 +211,7 @@ func (m *model) getDeleteCmd(permDelete bool) tea.Cmd {
 
 	var items []string
 	if panel.PanelMode == filepanel.SelectMode {
-		items = panel.GetSelectedLocationsSortedAsVisible()
+		items = panel.GetSelectedTopLocationsSortedAsVisible()
 	} else {
 		items = []string{panel.GetFocusedItem().Location}
 	}
@@ -173,28 +235,38 @@ func (m *model) deleteOperation(processBarModel *processbar.Model, items []strin
 		return NewDeleteOperationMsg(processbar.Failed, reqID)
 	}
 	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg { return NewDeleteOperationMsg(state, reqID) }
-	processor := makeDeleteProcessor(p, processBarModel, useTrash)
+	processor := makeDeleteProcessor(p, processBarModel, useTrash, m.journal)
 	msg := m.runFileProcessor(processor, finalizer, items, reqID)
 	return msg
 }
 
 func makeDeleteProcessor(process processbar.Process,
 	processBarModel *processbar.Model,
-	useTrash bool) processbar.FileListProcessor {
+	useTrash bool, jr *journal.Journal) processbar.FileListProcessor {
 	processorFunction := func(items []string) (processbar.Process, []string) {
 		notProcessed := make([]string, 0)
 		if len(items) == 0 {
 			markProcessDone(process, processBarModel)
 			return process, notProcessed
 		}
+		var steps []journal.Step
+		defer func() { jr.Record(journal.KindTrash, steps) }()
 		deleteFunc := os.RemoveAll
 		if useTrash {
 			deleteFunc = func(item string) error {
-				_, err := trash.Move(item)
+				result, err := trash.Move(item)
+				// Some backends don't tell where the item went. Those can't be restored
+				if err == nil && result.TrashedPath != "" {
+					steps = append(steps, journal.Step{Src: result.OriginalPath, Dst: result.TrashedPath})
+				}
 				return err
 			}
 		}
 		for i, item := range items {
+			if processbar.Checkpoint(process.Context()) != nil {
+				process.MarkCancelled()
+				break
+			}
 			err := deleteFunc(item)
 			if err != nil {
 				process.State = processbar.Failed
@@ -208,7 +280,12 @@ func makeDeleteProcessor(process processbar.Process,
 			processBarModel.TrySendingUpdateProcessMsg(process)
 		}
 
-		if process.State != processbar.Failed {
+		switch process.State {
+		case processbar.Cancelled:
+			markProcessDone(process, processBarModel)
+		case processbar.Failed:
+			// Wait for the user to skip or abort
+		default:
 			process.State = processbar.Successful
 			markProcessDone(process, processBarModel)
 		}
@@ -219,8 +296,11 @@ func makeDeleteProcessor(process processbar.Process,
 
 func (m *model) getDeleteTriggerCmd(deletePermanent bool) tea.Cmd {
 	panel := m.getFocusedFilePanel()
+	if m.isReadOnlyPanel("delete") {
+		return nil
+	}
 	if (panel.PanelMode == filepanel.SelectMode && panel.SelectedCount() == 0) ||
-		(panel.PanelMode == filepanel.BrowserMode && panel.Empty()) {
+		(panel.PanelMode != filepanel.SelectMode && panel.Empty()) {
 		return nil
 	}
 
@@ -244,6 +324,9 @@ func (m *model) getDeleteTriggerCmd(deletePermanent bool) tea.Cmd {
 // set cut to true/false accordingly
 func (m *model) copySingleItem(cut bool) {
 	panel := m.getFocusedFilePanel()
+	if cut && m.isReadOnlyPanel("cut") {
+		return
+	}
 	m.clipboard.Reset(cut)
 	if panel.Empty() {
 		return
@@ -256,17 +339,23 @@ func (m *model) copySingleItem(cut bool) {
 // Copy all selected file or directory's paths to the clipboard
 func (m *model) copyMultipleItem(cut bool) {
 	panel := m.getFocusedFilePanel()
+	if cut && m.isReadOnlyPanel("cut") {
+		return
+	}
 	m.clipboard.Reset(cut)
 	if panel.SelectedCount() == 0 {
 		return
 	}
-	items := panel.GetSelectedLocationsSortedAsVisible()
+	items := panel.GetSelectedTopLocationsSortedAsVisible()
 	slog.Debug("handle_file_operations.copyMultipleItem", "cut", cut,
 		"panel selected files", items)
 	m.clipboard.SetItems(items)
 }
 
 func (m *model) getPasteItemCmd() tea.Cmd {
+	if m.isReadOnlyPanel("paste") {
+		return nil
+	}
 	copyItems := m.clipboard.PruneInaccessibleItemsAndGet()
 	cut := m.clipboard.IsCut()
 	if len(copyItems) == 0 {
@@ -283,7 +372,32 @@ func (m *model) getPasteItemCmd() tea.Cmd {
 			return NewNotifyModalMsg(notify.New(true, "Invalid paste location", err.Error(), notify.NoAction),
 				reqID)
 		}
-		return m.executePasteOperation(&m.processBarModel, panelLocation, copyItems, cut, reqID)
+		if conflicts := findPasteConflicts(panelLocation, copyItems); len(conflicts) > 0 {
+			return NewConflictMsg(m.newPasteConflictPrompt(panelLocation, copyItems, cut, conflicts), reqID)
+		}
+		return m.executePasteOperation(&m.processBarModel, panelLocation, copyItems, cut, nil, reqID)
+	}
+}
+
+// getPasteLinkCmd links the clipboard items into the focused panel instead of
+// pasting them. The clipboard is kept, even if the items were cut, and the
+// links never overwrite an item, they are renamed like copies
+func (m *model) getPasteLinkCmd(kind linkKind) tea.Cmd {
+	if m.isReadOnlyPanel("link") {
+		return nil
+	}
+	items := m.clipboard.PruneInaccessibleItemsAndGet()
+	if len(items) == 0 {
+		return nil
+	}
+
+	reqID := m.nextIoReqCnt()
+	panelLocation := m.getFocusedFilePanel().Location
+
+	slog.Debug("Submitting pasteLink request", "id", reqID, "kind", kind,
+		"items cnt", len(items), "dest", panelLocation)
+	return func() tea.Msg {
+		return m.executeLinkOperation(&m.processBarModel, panelLocation, items, kind, reqID)
 	}
 }
 
@@ -312,30 +426,73 @@ func validatePasteOperation(panelLocation string, copyItems []string, cut bool)
 func makePasteProcessor(process processbar.Process,
 	processBarModel *processbar.Model,
 	panelLocation string, cut bool,
+	resolutions map[string]conflictResolution, useTrash bool,
+	jr *journal.Journal,
 ) processbar.FileListProcessor {
+	summary := conflictSummary{}
+	kind := journal.KindCopy
+	if cut {
+		kind = journal.KindMove
+	}
 	processorFunction := func(items []string) (processbar.Process, []string) {
 		notProcessed := make([]string, 0)
 		if len(items) == 0 {
 			markProcessDone(process, processBarModel)
 			return process, notProcessed
 		}
-		var err error
+		var steps []journal.Step
+		// A paste that removed an item for good cannot be undone
+		undoable := true
+		defer func() {
+			if undoable {
+				jr.Record(kind, steps)
+			}
+		}()
 		for i, filePath := range items {
+			if processbar.Checkpoint(process.Context()) != nil {
+				process.MarkCancelled()
+				break
+			}
 			errMessage := "cut item error"
-			if cut && !isExternalDiskPath(filePath) {
-				err = moveElement(filePath, filepath.Join(panelLocation, filepath.Base(filePath)))
+			destination, err := prepareConflictingDestination(filePath,
+				filepath.Join(panelLocation, filepath.Base(filePath)), resolutions[filePath], useTrash)
+			dst := destination.path
+			if destination.conflict {
+				summary[destination.resolution]++
+				process.Summary = summary.String()
+			}
+			if destination.conflict && destination.resolution == conflictOverwrite && destination.trashed == "" {
+				undoable = false
+			}
+			if err != nil {
+				errMessage = "paste conflict error"
+			} else if destination.conflict && destination.resolution == conflictSkip {
+				process.CurrentFile = filepath.Base(filePath)
+				processBarModel.TrySendingUpdateProcessMsg(process)
+				continue
+			} else if archivefs.IsVirtual(filePath) {
+				// Entries of archives are only copied, as the archives are read-only
+				err = archivefs.CopyOutPath(process.Context(), filePath, dst)
+				if err == nil {
+					process.Done++
+				}
+			} else if cut && !isExternalDiskPath(filePath) {
+				err = moveElement(process.Context(), filePath, dst)
 			} else {
 				// TODO : These error cases are hard to test. We have to somehow make the paste operations fail,
 				// which is time consuming and manual. We should test these with automated testcases
 				// UPD: use "chattr +i" for target catalog to fail past opeations
-				err = pasteDir(filePath, filepath.Join(panelLocation, filepath.Base(filePath)),
-					&process, cut, processBarModel)
+				dst, err = pasteDir(filePath, dst, &process, cut, processBarModel)
 				if err != nil {
 					errMessage = "paste item error"
 				}
 			}
 
 			process.CurrentFile = filepath.Base(filePath)
+			if errors.Is(err, processbar.ErrProcessCancelled) {
+				process.MarkCancelled()
+				break
+			}
 			if err != nil {
 				process.State = processbar.Failed
 				slog.Error(errMessage, "error", err)
@@ -345,9 +502,15 @@ func makePasteProcessor(process processbar.Process,
 				notProcessed = items[i:]
 				break
 			}
+			steps = append(steps, journal.Step{Src: filePath, Dst: dst, Trashed: destination.trashed})
 			processBarModel.TrySendingUpdateProcessMsg(process)
 		}
-		if process.State != processbar.Failed {
+		switch process.State {
+		case processbar.Cancelled:
+			markProcessDone(process, processBarModel)
+		case processbar.Failed:
+			// Wait for the user to skip or abort
+		default:
 			process.State = processbar.Successful
 			process.Done = process.Total
 			markProcessDone(process, processBarModel)
@@ -358,7 +521,8 @@ func makePasteProcessor(process processbar.Process,
 }
 
 func (m *model) executePasteOperation(processBarModel *processbar.Model,
-	panelLocation string, items []string, cut bool, reqID int,
+	panelLocation string, items []string, cut bool,
+	resolutions map[string]conflictResolution, reqID int,
 ) tea.Msg {
 	if len(items) == 0 {
 		return NewPasteOperationMsg(processbar.Cancelled, reqID)
@@ -379,7 +543,9 @@ func (m *model) executePasteOperation(processBarModel *processbar.Model,
 		return NewPasteOperationMsg(processbar.Failed, reqID)
 	}
 	finalizer := func(state processbar.ProcessState, reqId int) tea.Msg { return NewPasteOperationMsg(state, reqId) }
-	processor := makePasteProcessor(p, processBarModel, panelLocation, cut)
+	// The overwritten items go to the trash, so that the paste can be undone
+	useTrash := m.hasTrash && trash.Available(panelLocation)
+	processor := makePasteProcessor(p, processBarModel, panelLocation, cut, resolutions, useTrash, m.journal)
 	msg := m.runFileProcessor(processor, finalizer, items, reqID)
 	return msg
 }
@@ -399,6 +565,11 @@ func getTotalFilesCnt(copyItems []string) int {
 		// instead, we could just track progress based on total items in
 		// copyItems
 		// efficiency should be prioritized over more detailed feedback.
+		if archivefs.IsVirtual(folderPath) {
+			// Copied out of the archive as a single step
+			totalFiles++
+			continue
+		}
 		count, err := countFiles(folderPath)
 		if err != nil {
 			slog.Error("Error in countFiles", "error", err)
@@ -409,54 +580,106 @@ func getTotalFilesCnt(copyItems []string) int {
 	return totalFiles
 }
 
-// Extract compressed file
-// TODO : err should be returned and properly handled by the caller
-func (m *model) getExtractFileCmd() tea.Cmd {
+// Ask where to extract the archive, it is extracted once it's confirmed
+func (m *model) openExtractModal() {
 	panel := m.getFocusedFilePanel()
-	if panel.Empty() {
-		return nil
+	if panel.Empty() || m.isReadOnlyPanel("extract") {
+		return
 	}
-
 	item := panel.GetFocusedItem().Location
-
 	ext := strings.ToLower(filepath.Ext(item))
 	if !common.IsExtensionExtractable(ext) {
 		slog.Error("Error unexpected file", "extension type", ext, "item", item, "error", errors.ErrUnsupported)
+		return
+	}
+	otherPanel := m.fileModel.GetOtherFilePanel()
+	m.extractModal.Open(filepath.Base(common.FileNameWithoutExtension(item)),
+		otherPanel != nil && !otherPanel.InArchive())
+}
+
+// Extract compressed file
+func (m *model) getExtractFileCmd(target extractmodel.Target) tea.Cmd {
+	panel := m.getFocusedFilePanel()
+	if panel.Empty() || m.isReadOnlyPanel("extract") {
 		return nil
 	}
+
+	item := panel.GetFocusedItem().Location
+	dest := panel.Location
+	switch target {
+	case extractmodel.TargetHere:
+	case extractmodel.TargetNewDir:
+		dest = common.FileNameWithoutExtension(item)
+	case extractmodel.TargetOtherPanel:
+		otherPanel := m.fileModel.GetOtherFilePanel()
+		if otherPanel == nil {
+			slog.Error("Extracting to the other panel without one")
+			return nil
+		}
+		dest = otherPanel.Location
+	}
 	reqID := m.nextIoReqCnt()
 
-	slog.Debug("Submitting Extract file request", "reqID", reqID, "item", item)
+	slog.Debug("Submitting Extract file request", "reqID", reqID, "item", item, "dest", dest)
 
 	return func() tea.Msg {
-		outputDir := common.FileNameWithoutExtension(item)
-		outputDir, err := renameIfDuplicate(outputDir)
+		// Archives that can't be read, or have unsafe entries, fail in the process bar
+		scan, scanErr := scanArchive(item)
+		if target != extractmodel.TargetNewDir {
+			if conflicts := findExtractConflicts(dest, scan.topLevel); scanErr == nil && len(conflicts) > 0 {
+				return NewConflictMsg(m.newExtractConflictPrompt(item, dest, scan.entries, conflicts), reqID)
+			}
+			return m.executeExtractOperation(item, dest, scan.entries, nil, reqID)
+		}
+
+		newDir, err := renameIfDuplicate(dest)
 		if err != nil {
 			slog.Error("Error while renaming for duplicates", "error", err)
 			return NewExtractOperationMsg(processbar.Failed, reqID)
 		}
-
-		err = os.MkdirAll(
-			outputDir,
-			utils.ExtractedDirMode,
-		)
+		err = os.MkdirAll(newDir, utils.ExtractedDirMode)
 		if err != nil {
 			slog.Error("Error while making directory for extracting files", "error", err)
 			return NewExtractOperationMsg(processbar.Failed, reqID)
 		}
-		err = extractCompressFile(item, outputDir, &m.processBarModel)
-		if err != nil {
-			slog.Error("Error extract file", "error", err)
-			return NewExtractOperationMsg(processbar.Failed, reqID)
+		msg := m.executeExtractOperation(item, newDir, scan.entries, nil, reqID)
+		if msg.state != processbar.Successful {
+			// Only removed if nothing was extracted in it
+			if removeErr := os.Remove(newDir); removeErr != nil {
+				slog.Debug("Directory of the extraction kept", "path", newDir, "error", removeErr)
+			}
 		}
-		return NewExtractOperationMsg(processbar.Successful, reqID)
+		return msg
+	}
+}
+
+// executeExtractOperation extracts the archive to dest, see extractArchive
+func (m *model) executeExtractOperation(item string, dest string, entries int,
+	resolutions map[string]conflictResolution, reqID int) ExtractOperationMsg {
+	useTrash := m.hasTrash && trash.Available(dest)
+	err := extractArchive(item, dest, entries, resolutions, useTrash, &m.processBarModel)
+	if errors.Is(err, processbar.ErrProcessCancelled) {
+		return NewExtractOperationMsg(processbar.Cancelled, reqID)
+	}
+	if err != nil {
+		slog.Error("Error extract file", "error", err)
+		return NewExtractOperationMsg(processbar.Failed, reqID)
+	}
+	return NewExtractOperationMsg(processbar.Successful, reqID)
+}
+
+// Ask for the format of the archive, the files are compressed once it's confirmed
+func (m *model) openCompressModal() {
+	if m.getFocusedFilePanel().Empty() || m.isReadOnlyPanel("compress") {
+		return
 	}
+	m.compressModal.Open(compressmodel.ParseFormat(common.Config.DefaultCompressFormat))
 }
 
-func (m *model) getCompressSelectedFilesCmd() tea.Cmd {
+func (m *model) getCompressSelectedFilesCmd(format compressmodel.Format) tea.Cmd {
 	panel := m.getFocusedFilePanel()
 
-	if panel.Empty() {
+	if panel.Empty() || m.isReadOnlyPanel("compress") {
 		return nil
 	}
 	var filesToCompress []string
@@ -467,20 +690,23 @@ func (m *model) getCompressSelectedFilesCmd() tea.Cmd {
 		filesToCompress = append(filesToCompress, firstFile)
 	} else {
 		firstFile = panel.GetFirstSelectedLocation()
-		filesToCompress = panel.GetSelectedLocationsSortedAsVisible()
+		filesToCompress = panel.GetSelectedTopLocationsSortedAsVisible()
 	}
 
 	reqID := m.nextIoReqCnt()
 
 	return func() tea.Msg {
-		zipName, err := getZipArchiveName(filepath.Base(firstFile))
+		archiveName, err := getArchiveName(filepath.Base(firstFile), format.Extension())
 		if err != nil {
-			slog.Error("Error in getZipArchiveName", "error", err)
+			slog.Error("Error in getArchiveName", "error", err)
 			return NewCompressOperationMsg(processbar.Failed, reqID)
 		}
-		zipPath := filepath.Join(panel.Location, zipName)
-		if err := zipSources(filesToCompress, zipPath, &m.processBarModel); err != nil {
-			slog.Error("Error in zipping files", "error", err)
+		archivePath := filepath.Join(panel.Location, archiveName)
+		if err := compressSources(filesToCompress, archivePath, format, &m.processBarModel); err != nil {
+			if errors.Is(err, processbar.ErrProcessCancelled) {
+				return NewCompressOperationMsg(processbar.Cancelled, reqID)
+			}
+			slog.Error("Error in compressing files", "error", err)
 			return NewCompressOperationMsg(processbar.Failed, reqID)
 		}
 		return NewCompressOperationMsg(processbar.Successful, reqID)
@@ -501,7 +727,7 @@ func (m *model) chooserFileWriteAndQuit(path string) error {
 func (m *model) openFileWithEditor() tea.Cmd {
 	panel := m.getFocusedFilePanel()
 	// Check if panel is empty
-	if panel.Empty() {
+	if panel.Empty() || m.isReadOnlyPanel("open with editor") {
 		return nil
 	}
 
@@ -514,6 +740,47 @@ func (m *model) openFileWithEditor() tea.Cmd {
 		slog.Error("Error while writing to chooser file, continuing with open via file editor", "error", err)
 	}
 
+	return tea.ExecProcess(fileEditorCommand(panel.GetFocusedItem().Location), func(err error) tea.Msg {
+		return editorFinishedMsg{err}
+	})
+}
+
+// Command that opens the file with the configured editor, then $EDITOR
+func fileEditorCommand(path string) *exec.Cmd {
+	parts := fileEditorParts()
+	cmd := parts[0]
+
+	//nolint:gocritic // appendAssign: intentionally creating a new slice
+	args := append(parts[1:], path)
+
+	return exec.Command(cmd, args...) //nolint:gosec // Editor command is intentionally user-configurable.
+}
+
+// Command that opens the file at the given line with the configured editor.
+// The line is passed the way the well known editors expect it. Editors that
+// cannot go to a line just open the file
+func fileEditorCommandAtLine(path string, line int) *exec.Cmd {
+	parts := fileEditorParts()
+	cmd := parts[0]
+	args := parts[1:]
+
+	switch strings.TrimSuffix(strings.ToLower(filepath.Base(cmd)), ".exe") {
+	case "notepad":
+		args = append(args, path)
+	case "code", "codium", "code-insiders":
+		args = append(args, "-g", fmt.Sprintf("%s:%d", path, line))
+	case "hx", "helix", "subl", "zed":
+		args = append(args, fmt.Sprintf("%s:%d", path, line))
+	default:
+		// vi, vim, nvim, nano, emacs, micro, kak...
+		args = append(args, fmt.Sprintf("+%d", line), path)
+	}
+
+	return exec.Command(cmd, args...) //nolint:gosec // Editor command is intentionally user-configurable.
+}
+
+// The configured editor, then $EDITOR, split into command and arguments
+func fileEditorParts() []string {
 	editor := common.Config.Editor
 	if editor == "" {
 		editor = os.Getenv("EDITOR")
@@ -529,21 +796,14 @@ func (m *model) openFileWithEditor() tea.Cmd {
 	}
 
 	// Split the editor command into command and arguments
-	parts := strings.Fields(editor)
-	cmd := parts[0]
-
-	//nolint:gocritic // appendAssign: intentionally creating a new slice
-	args := append(parts[1:], panel.GetFocusedItem().Location)
-
-	c := exec.Command(cmd, args...) //nolint:gosec // Editor command is intentionally user-configurable.
-
-	return tea.ExecProcess(c, func(err error) tea.Msg {
-		return editorFinishedMsg{err}
-	})
+	return strings.Fields(editor)
 }
 
 // Open directory with default editor
 func (m *model) openDirectoryWithEditor() tea.Cmd {
+	if m.isReadOnlyPanel("open with editor") {
+		return nil
+	}
 	if variable.ChooserFile != "" {
 		err := m.chooserFileWriteAndQuit(m.getFocusedFilePanel().Location)
 		if err == nil {
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"log/slog"
+	"os/exec"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+)
+
+// newGitStatusCache returns the cache of the git status, or nil if the
+// git_status plugin is disabled or if git is not installed
+func newGitStatusCache() *gitstatus.Cache {
+	if !common.Config.GitStatus {
+		return nil
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		slog.Warn("The git_status plugin is enabled, but git is not installed", "error", err)
+		return nil
+	}
+	return gitstatus.NewCache()
+}
+
+// getGitStatusCmd gives the panels the status of their work tree, and
+// returns the Cmd reading the ones missing or outdated
+func (m *model) getGitStatusCmd() tea.Cmd {
+	if m.gitStatus == nil {
+		return nil
+	}
+	now := time.Now()
+	var cmds []tea.Cmd
+	for _, panel := range m.fileModel.Panels() {
+		if panel.InArchive() {
+			panel.Git = nil
+			continue
+		}
+		repo, load := m.gitStatus.Get(panel.Location, now)
+		panel.Git = repo
+		if load {
+			reqID := m.nextIoReqCnt()
+			slog.Debug("Submitting git status request", "id", reqID, "location", panel.Location)
+			cmds = append(cmds, loadGitStatus(panel.Location, now, reqID))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func loadGitStatus(dir string, startedAt time.Time, reqID int) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := gitstatus.Load(context.Background(), dir)
+		if err != nil {
+			slog.Error("Error while reading the git status", "dir", dir, "error", err)
+		}
+		return NewGitStatusMsg(dir, repo, startedAt, reqID)
+	}
+}
+
+// invalidateGitStatus makes the work trees containing paths be read again,
+// or all of them if paths is nil
+func (m *model) invalidateGitStatus(paths []string) {
+	if m.gitStatus == nil {
+		return
+	}
+	if paths == nil {
+		m.gitStatus.InvalidateAll(time.Now())
+		return
+	}
+	m.gitStatus.Invalidate(paths, time.Now())
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestGitStatus(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	curTestDir := t.TempDir()
+	tracked := filepath.Join(curTestDir, "tracked.txt")
+	untracked := filepath.Join(curTestDir, "untracked.txt")
+	utils.SetupFiles(t, tracked)
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "tracked.txt"},
+		{"commit", "-q", "-m", "init"},
+	} {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com",
+			"-c", "commit.gpgsign=false"}, args...)
+		cmd := exec.CommandContext(t.Context(), "git", args...)
+		cmd.Dir = curTestDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	utils.SetupFiles(t, untracked)
+
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := origConfig
+	cfg.GitStatus = true
+	common.SetConfig(cfg)
+
+	m := defaultTestModel(curTestDir)
+	require.NotNil(t, m.gitStatus)
+	// The Cmd of the load asked by the test setup is dropped
+	m.gitStatus = gitstatus.NewCache()
+	p := NewTestTeaProgWithEventLoop(t, m)
+	assert.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().Git != nil
+	}, DefaultTestTimeout, DefaultTestTick)
+
+	repo := p.getModel().getFocusedFilePanel().Git
+	assert.Equal(t, "main", repo.Branch)
+	assert.True(t, repo.Dirty)
+	assert.Equal(t, gitstatus.Unmodified, repo.StatusOf(tracked))
+	assert.Equal(t, gitstatus.Untracked, repo.StatusOf(untracked))
+}
+
+func TestGitStatusDisabled(t *testing.T) {
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := origConfig
+	cfg.GitStatus = false
+	common.SetConfig(cfg)
+
+	m := defaultTestModel(t.TempDir())
+	assert.Nil(t, m.gitStatus)
+	assert.Nil(t, m.getGitStatusCmd())
+	assert.Nil(t, m.getFocusedFilePanel().Git)
+}
@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Reverse the most recent file operation recorded in the journal
+func (m *model) getUndoCmd() tea.Cmd {
+	entry, ok := m.journal.PopUndo()
+	if !ok {
+		slog.Debug("Nothing to undo")
+		return nil
+	}
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting undo request", "id", reqID, "kind", entry.Kind, "steps cnt", len(entry.Steps))
+	return func() tea.Msg {
+		return m.journalOperation(&m.processBarModel, entry, true, reqID)
+	}
+}
+
+// Perform again the most recently undone file operation
+func (m *model) getRedoCmd() tea.Cmd {
+	entry, ok := m.journal.PopRedo()
+	if !ok {
+		slog.Debug("Nothing to redo")
+		return nil
+	}
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting redo request", "id", reqID, "kind", entry.Kind, "steps cnt", len(entry.Steps))
+	return func() tea.Msg {
+		return m.journalOperation(&m.processBarModel, entry, false, reqID)
+	}
+}
+
+// Undo or redo the entry. The entry was already popped from the journal.
+// On failure, the steps that were done are moved to the opposite history, and the
+// rest are put back, so that the journal stays consistent with the file system
+func (m *model) journalOperation(processBarModel *processbar.Model, entry journal.Entry,
+	undo bool, reqID int) tea.Msg {
+	if len(entry.Steps) == 0 {
+		return NewJournalOperationMsg(processbar.Cancelled, reqID)
+	}
+	operation := processbar.OpRedo
+	stepFunc := redoStep
+	pushDone, pushRemaining := m.journal.PushUndo, m.journal.PushRedo
+	if undo {
+		operation = processbar.OpUndo
+		stepFunc = undoStep
+		pushDone, pushRemaining = m.journal.PushRedo, m.journal.PushUndo
+	}
+
+	p, err := processBarModel.SendAddProcessMsg(
+		filepath.Base(entry.Steps[0].Dst), operation, len(entry.Steps), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		pushRemaining(entry)
+		return NewJournalOperationMsg(processbar.Failed, reqID)
+	}
+
+	// Undo has to happen in the reverse order of the original operation
+	order := make([]int, len(entry.Steps))
+	for i := range order {
+		order[i] = i
+		if undo {
+			order[i] = len(entry.Steps) - 1 - i
+		}
+	}
+
+	done := make([]journal.Step, 0, len(entry.Steps))
+	var remaining []journal.Step
+	for i, idx := range order {
+		step := entry.Steps[idx]
+		p.CurrentFile = filepath.Base(step.Dst)
+		err = processbar.Checkpoint(p.Context())
+		if err == nil {
+			step, err = stepFunc(p.Context(), entry.Kind, step)
+		}
+		if err != nil {
+			if errors.Is(err, processbar.ErrProcessCancelled) {
+				p.MarkCancelled()
+			} else {
+				slog.Error("Error in undo/redo operation", "kind", entry.Kind, "undo", undo, "error", err)
+				p.State = processbar.Failed
+				p.ErrorMsg = err.Error()
+			}
+			for _, rIdx := range order[i:] {
+				remaining = append(remaining, entry.Steps[rIdx])
+			}
+			break
+		}
+		done = append(done, step)
+		p.Done++
+		processBarModel.TrySendingUpdateProcessMsg(p)
+	}
+
+	if len(done) > 0 {
+		pushDone(journal.Entry{Kind: entry.Kind, Steps: stepsInOriginalOrder(done, undo), Time: entry.Time})
+	}
+	if len(remaining) > 0 {
+		pushRemaining(journal.Entry{Kind: entry.Kind, Steps: stepsInOriginalOrder(remaining, undo), Time: entry.Time})
+	}
+
+	if p.State == processbar.InOperation {
+		p.State = processbar.Successful
+	}
+	markProcessDone(p, processBarModel)
+	return NewJournalOperationMsg(p.State, reqID)
+}
+
+func stepsInOriginalOrder(steps []journal.Step, reversed bool) []journal.Step {
+	if !reversed {
+		return steps
+	}
+	result := make([]journal.Step, len(steps))
+	for i, step := range steps {
+		result[len(steps)-1-i] = step
+	}
+	return result
+}
+
+func undoStep(ctx context.Context, kind journal.Kind, step journal.Step) (journal.Step, error) {
+	switch kind {
+	case journal.KindMove:
+		if err := moveElementIfFree(ctx, step.Dst, step.Src); err != nil {
+			return step, err
+		}
+		return step, restoreOverwritten(step)
+	case journal.KindRename:
+		return step, moveElementIfFree(ctx, step.Dst, step.Src)
+	case journal.KindCopy:
+		if err := os.RemoveAll(step.Dst); err != nil {
+			return step, err
+		}
+		return step, restoreOverwritten(step)
+	case journal.KindCreate, journal.KindSymlink, journal.KindHardlink:
+		// os.Remove refuses to delete directories that got some content after creation
+		return step, os.Remove(step.Dst)
+	case journal.KindTrash:
+		return step, trash.Restore(step.Dst, step.Src)
+	default:
+		return step, fmt.Errorf("unknown journal entry kind %q", kind)
+	}
+}
+
+func redoStep(ctx context.Context, kind journal.Kind, step journal.Step) (journal.Step, error) {
+	switch kind {
+	case journal.KindMove:
+		var err error
+		if step, err = trashOverwritten(step); err != nil {
+			return step, err
+		}
+		return step, moveElementIfFree(ctx, step.Src, step.Dst)
+	case journal.KindRename:
+		return step, moveElementIfFree(ctx, step.Src, step.Dst)
+	case journal.KindCopy:
+		var err error
+		if step, err = trashOverwritten(step); err != nil {
+			return step, err
+		}
+		if err := ensurePathFree(step.Dst); err != nil {
+			return step, err
+		}
+		return step, copyElement(ctx, step.Src, step.Dst)
+	case journal.KindCreate:
+		if step.IsDir {
+			return step, os.Mkdir(step.Dst, utils.UserDirPerm)
+		}
+		f, err := os.OpenFile(step.Dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, utils.UserFilePerm)
+		if err != nil {
+			return step, err
+		}
+		return step, f.Close()
+	case journal.KindSymlink:
+		return step, os.Symlink(step.Src, step.Dst)
+	case journal.KindHardlink:
+		return step, os.Link(step.Src, step.Dst)
+	case journal.KindTrash:
+		result, err := trash.Move(step.Src)
+		if err == nil && result.TrashedPath == "" {
+			err = fmt.Errorf("trash did not report where %s was moved", step.Src)
+		}
+		step.Dst = result.TrashedPath
+		return step, err
+	default:
+		return step, fmt.Errorf("unknown journal entry kind %q", kind)
+	}
+}
+
+// restoreOverwritten puts back the item that a paste overwrote, once the
+// pasted item is gone
+func restoreOverwritten(step journal.Step) error {
+	if step.Trashed == "" {
+		return nil
+	}
+	return trash.Restore(step.Trashed, step.Dst)
+}
+
+// trashOverwritten moves again to the trash the item that a paste overwrote,
+// before pasting again
+func trashOverwritten(step journal.Step) (journal.Step, error) {
+	if step.Trashed == "" {
+		return step, nil
+	}
+	result, err := trash.Move(step.Dst)
+	if err == nil && result.TrashedPath == "" {
+		err = fmt.Errorf("trash did not report where %s was moved", step.Dst)
+	}
+	step.Trashed = result.TrashedPath
+	return step, err
+}
+
+// Unlike a plain move, undo and redo must never overwrite files
+// that were created after the journal entry was recorded
+func moveElementIfFree(ctx context.Context, src, dst string) error {
+	if err := ensurePathFree(dst); err != nil {
+		return err
+	}
+	return moveElement(ctx, src, dst)
+}
+
+func ensurePathFree(path string) error {
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/yorukot/superfile/src/internal/marks"
+)
+
+var errNoMark = errors.New("no mark named so")
+
+// markKey handles the key pressed after the key to set or jump to a mark.
+// A key that is not a letter cancels it
+func (m *model) markKey(msg string) {
+	pending := m.pendingMark
+	m.pendingMark = noPendingMark
+	if !marks.ValidName(msg) {
+		return
+	}
+	var err error
+	if pending == pendingSetMark {
+		err = m.setMark(msg)
+	} else {
+		err = m.jumpToMark(msg)
+	}
+	if errors.Is(err, errNoMark) {
+		slog.Debug("Mark is not set", "mark", msg)
+	} else if err != nil {
+		slog.Error("Error while using mark", "mark", msg, "error", err)
+	}
+}
+
+// setMark marks the directory of the focused panel, and the item under its
+// cursor, with name
+func (m *model) setMark(name string) error {
+	panel := m.getFocusedFilePanel()
+	mark := marks.Mark{Location: panel.Location}
+	if !panel.EmptyOrInvalid() {
+		mark.Target = panel.GetFocusedItem().Name
+	}
+	return m.marks.Set(name, mark)
+}
+
+// jumpToMark opens the directory marked with name in the focused panel, with
+// the cursor on the item marked with it
+func (m *model) jumpToMark(name string) error {
+	mark, ok := m.marks.Get(name)
+	if !ok {
+		return fmt.Errorf("%w: %q", errNoMark, name)
+	}
+	panel := m.getFocusedFilePanel()
+	previousLocation := panel.Location
+	if err := m.updateCurrentFilePanelDir(mark.Location); err != nil {
+		return err
+	}
+	if panel.Location != previousLocation && mark.Target != "" {
+		panel.TargetFile = mark.Target
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/marks"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMarks(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "a.txt"), filepath.Join(dir1, "b.txt"), filepath.Join(dir2, "x.txt"))
+
+	marksFile := filepath.Join(t.TempDir(), "marks.json")
+	m := defaultTestModel(dir1)
+	m.marks = marks.New(marksFile)
+	TeaUpdate(m, nil)
+	m.getFocusedFilePanel().ListDown()
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.SetMark[0]))
+	TeaUpdate(m, utils.TeaRuneKeyMsg("q"))
+	assert.Equal(t, noPendingMark, m.pendingMark)
+	require.NoError(t, m.updateCurrentFilePanelDir(dir2))
+	TeaUpdate(m, nil)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.JumpToMark[0]))
+	TeaUpdate(m, utils.TeaRuneKeyMsg("q"))
+	TeaUpdate(m, nil)
+	assert.Equal(t, dir1, m.getFocusedFilePanel().Location)
+	assert.Equal(t, "b.txt", m.getFocusedFilePanel().GetFocusedItem().Name, "cursor on the marked item")
+
+	t.Run("Kept across restarts", func(t *testing.T) {
+		restarted := defaultTestModel(dir2)
+		restarted.marks = marks.New(marksFile)
+		TeaUpdate(restarted, utils.TeaRuneKeyMsg(common.Hotkeys.JumpToMark[0]))
+		TeaUpdate(restarted, utils.TeaRuneKeyMsg("q"))
+		assert.Equal(t, dir1, restarted.getFocusedFilePanel().Location)
+	})
+
+	t.Run("Unset mark or other key", func(t *testing.T) {
+		require.NoError(t, m.updateCurrentFilePanelDir(dir2))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.JumpToMark[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg("Q"))
+		assert.Equal(t, dir2, m.getFocusedFilePanel().Location, "marks are case sensitive")
+
+		// The key after the mark key is not handled as a hotkey
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.JumpToMark[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+		assert.Equal(t, noPendingMark, m.pendingMark)
+		assert.Equal(t, dir2, m.getFocusedFilePanel().Location)
+	})
+}
+
+func TestNavigationHistoryKeys(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	subDir := filepath.Join(dir1, "sub")
+	utils.SetupDirectories(t, dir1, subDir)
+	utils.SetupFiles(t, filepath.Join(dir1, "a.txt"), filepath.Join(subDir, "x.txt"))
+
+	m := defaultTestModel(dir1)
+	TeaUpdate(m, nil)
+	// Enter sub, then go up to dir1 and curTestDir
+	require.Equal(t, "sub", m.getFocusedFilePanel().GetFocusedItem().Name)
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.Confirm[0]))
+	require.Equal(t, subDir, m.getFocusedFilePanel().Location)
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	require.Equal(t, curTestDir, m.getFocusedFilePanel().Location)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.HistoryBack[0]))
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.HistoryBack[0]))
+	TeaUpdate(m, nil)
+	assert.Equal(t, subDir, m.getFocusedFilePanel().Location)
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.HistoryBack[0]))
+	TeaUpdate(m, nil)
+	assert.Equal(t, dir1, m.getFocusedFilePanel().Location)
+	assert.Equal(t, "sub", m.getFocusedFilePanel().GetFocusedItem().Name)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.HistoryForward[0]))
+	assert.Equal(t, subDir, m.getFocusedFilePanel().Location)
+
+	t.Run("Independent per panel", func(t *testing.T) {
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CreateNewFilePanel[0]))
+		location := m.getFocusedFilePanel().Location
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.HistoryBack[0]))
+		assert.Equal(t, location, m.getFocusedFilePanel().Location)
+		assert.Equal(t, subDir, m.fileModel.FilePanels[0].Location)
+	})
+}
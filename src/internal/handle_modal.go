@@ -8,6 +8,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/yorukot/superfile/src/internal/journal"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 	"github.com/yorukot/superfile/src/pkg/utils"
@@ -20,32 +21,33 @@ func (m *model) cancelTypingModal() {
 }
 
 // Confirm to create file or directory
-func createItem(location, item string) error {
+// It returns the path of the created item
+func createItem(location, item string) (string, error) {
 	if err := checkFileNameValidity(item); err != nil {
 		slog.Error("Errow while createItem during item creation", "error", err)
-		return err
+		return "", err
 	}
 	path := filepath.Join(location, item)
 	if !strings.HasSuffix(item, string(filepath.Separator)) {
 		path, _ = renameIfDuplicate(path)
 		if err := os.MkdirAll(filepath.Dir(path), utils.UserDirPerm); err != nil {
 			slog.Error("Error while createItem during directory creation", "error", err)
-			return err
+			return "", err
 		}
 		f, err := os.Create(path)
 		if err != nil {
 			slog.Error("Error while createItem during file creation", "error", err)
-			return err
+			return "", err
 		}
 		defer f.Close()
 	} else {
 		err := os.MkdirAll(path, utils.UserDirPerm)
 		if err != nil {
 			slog.Error("Error while createItem during directory creation", "error", err)
-			return err
+			return "", err
 		}
 	}
-	return nil
+	return path, nil
 }
 
 func (m *model) getCreateCmd() tea.Cmd {
@@ -81,14 +83,15 @@ func (m *model) createOperation(
 	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg {
 		return NewCreateOperationMsg(state, reqID)
 	}
-	processor := makeCreateProcessor(location, p, processBarModel)
+	processor := makeCreateProcessor(location, p, processBarModel, m.journal)
 	msg := m.runFileProcessor(processor, finalizer, items, reqID)
 	return msg
 }
 
 func makeCreateProcessor(location string,
 	process processbar.Process,
-	processBarModel *processbar.Model) processbar.FileListProcessor {
+	processBarModel *processbar.Model,
+	jr *journal.Journal) processbar.FileListProcessor {
 	processorFunction := func(items []string) (processbar.Process, []string) {
 		notProcessed := make([]string, 0)
 		if len(items) == 0 {
@@ -96,8 +99,10 @@ func makeCreateProcessor(location string,
 			return process, notProcessed
 		}
 
+		var steps []journal.Step
+		defer func() { jr.Record(journal.KindCreate, steps) }()
 		for i, item := range items {
-			err := createItem(location, item)
+			path, err := createItem(location, item)
 			if err != nil {
 				process.State = processbar.Failed
 				slog.Error("Error in create operation", "item", item, "error", err)
@@ -105,6 +110,10 @@ func makeCreateProcessor(location string,
 				notProcessed = items[i:]
 				break
 			}
+			steps = append(steps, journal.Step{
+				Dst:   path,
+				IsDir: strings.HasSuffix(item, string(filepath.Separator)),
+			})
 			process.CurrentFile = filepath.Base(item)
 			process.Done++
 			processBarModel.TrySendingUpdateProcessMsg(process)
@@ -139,13 +148,15 @@ func (m *model) confirmRename() {
 	}
 
 	oldPath := panel.GetFocusedItem().Location
-	newPath := filepath.Join(panel.Location, panel.Rename.Value())
+	newPath := filepath.Join(filepath.Dir(oldPath), panel.Rename.Value())
 
 	// Rename the file
 	err := os.Rename(oldPath, newPath)
 	if err != nil {
 		slog.Error("Error while confirmRename during rename", "error", err)
 		// Dont return. We have to also reset the panel and model information
+	} else if oldPath != newPath {
+		m.journal.Record(journal.KindRename, []journal.Step{{Src: oldPath, Dst: newPath}})
 	}
 	m.fileModel.Renaming = false
 	panel.Rename.Blur()
@@ -154,7 +165,10 @@ func (m *model) confirmRename() {
 
 func (m *model) confirmSortOptions() {
 	panel := m.getFocusedFilePanel()
-	panel.SortKind = m.sortModal.GetSelectedKind()
+	opts := panel.SortOptions()
+	opts.Kind = m.sortModal.GetSelectedKind()
+	panel.SetSortOptions(opts)
+	m.rememberSort()
 	m.sortModal.Close()
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/archivefs"
 	"github.com/yorukot/superfile/src/internal/common"
 )
 
@@ -32,6 +33,10 @@ func (m *model) enterPanel() {
 	if panel.Empty() {
 		return
 	}
+	if panel.InRecursiveResults() {
+		m.openRecursiveSearchResult()
+		return
+	}
 	selectedItem := panel.GetFocusedItem()
 	if selectedItem.Directory {
 		targetPath := selectedItem.Location
@@ -56,6 +61,10 @@ func (m *model) enterPanel() {
 		return
 	}
 
+	if panel.InArchive() {
+		slog.Debug("Files inside an archive cannot be opened", "file", selectedItem.Location)
+		return
+	}
 	if variable.ChooserFile != "" {
 		chooserErr := m.chooserFileWriteAndQuit(panel.GetFocusedItem().Location)
 		if chooserErr == nil {
@@ -64,6 +73,14 @@ func (m *model) enterPanel() {
 		// Continue with preview if file is not writable
 		slog.Error("Error while writing to chooser file, continuing with file open", "error", chooserErr)
 	}
+	if archivefs.IsArchive(selectedItem.Location) {
+		err := m.updateCurrentFilePanelDir(selectedItem.Location)
+		if err == nil {
+			return
+		}
+		// Open it like any other file, f.e. if it is corrupted
+		slog.Error("Error while browsing archive", "error", err, "archive", selectedItem.Location)
+	}
 	m.executeOpenCommand()
 }
 
@@ -20,6 +20,7 @@ func (m *model) focusOnSideBar() {
 	if common.Config.SidebarWidth == 0 {
 		return
 	}
+	m.fileModel.SetParentColumnFocused(false)
 	if m.focusPanel == sidebarFocus {
 		m.focusPanel = nonePanelFocus
 		m.getFocusedFilePanel().IsFocused = true
@@ -35,6 +36,7 @@ func (m *model) focusOnProcessBar() {
 		return
 	}
 
+	m.fileModel.SetParentColumnFocused(false)
 	if m.focusPanel == processBarFocus {
 		m.focusPanel = nonePanelFocus
 		m.getFocusedFilePanel().IsFocused = true
@@ -50,6 +52,7 @@ func (m *model) focusOnMetadata() {
 		return
 	}
 
+	m.fileModel.SetParentColumnFocused(false)
 	if m.focusPanel == metadataFocus {
 		m.focusPanel = nonePanelFocus
 		m.getFocusedFilePanel().IsFocused = true
@@ -58,3 +61,15 @@ func (m *model) focusOnMetadata() {
 		m.getFocusedFilePanel().IsFocused = false
 	}
 }
+
+// Focus on the parent column of the miller columns
+func (m *model) focusOnParentColumn() {
+	if m.fileModel.GetParentColumn() == nil {
+		return
+	}
+	if m.focusPanel != nonePanelFocus {
+		m.focusPanel = nonePanelFocus
+		m.getFocusedFilePanel().IsFocused = true
+	}
+	m.fileModel.SetParentColumnFocused(!m.fileModel.IsParentColumnFocused())
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/trash"
+)
+
+// How to paste an item whose destination already exists
+type conflictResolution int
+
+const (
+	// The zero value, as it was the only behaviour before the user could pick one
+	conflictKeepBoth conflictResolution = iota
+	conflictOverwrite
+	conflictOverwriteIfNewer
+	conflictSkip
+)
+
+// Items which would overwrite an existing item when pasted to panelLocation.
+// Items that would replace themselves, or one of their parents, are left out. They
+// can only be kept as both, like before
+func findPasteConflicts(panelLocation string, items []string) []string {
+	var conflicts []string
+	for _, item := range items {
+		dst := filepath.Join(panelLocation, filepath.Base(item))
+		if isAncestor(dst, item) {
+			continue
+		}
+		if _, err := os.Lstat(dst); err == nil {
+			conflicts = append(conflicts, item)
+		}
+	}
+	return conflicts
+}
+
+func (m *model) newPasteConflictPrompt(panelLocation string, items []string, cut bool,
+	conflicts []string) *conflictPrompt[string] {
+	return &conflictPrompt[string]{
+		conflicts: conflicts,
+		key:       func(item string) string { return item },
+		describe: func(item string) string {
+			return fmt.Sprintf("%q already exists in %s", filepath.Base(item), panelLocation)
+		},
+		overwriteIfNewer: true,
+		onResolved: func(resolutions map[string]conflictResolution) tea.Cmd {
+			reqID := m.nextIoReqCnt()
+			slog.Debug("Submitting pasteItems request after conflict resolution", "id", reqID,
+				"items cnt", len(items), "dest", panelLocation)
+			return func() tea.Msg {
+				return m.executePasteOperation(&m.processBarModel, panelLocation, items, cut, resolutions, reqID)
+			}
+		},
+	}
+}
+
+// pasteDestination is where an item is pasted, once its conflict with the
+// existing item, if any, is resolved
+type pasteDestination struct {
+	path string
+	// The resolution actually applied, which is conflictSkip if the item must
+	// not be pasted
+	resolution conflictResolution
+	conflict   bool
+	// Path inside the trash of the overwritten item. Empty if nothing was
+	// overwritten, or if the item was removed for good
+	trashed string
+}
+
+// prepareConflictingDestination applies the resolution picked by the user when
+// dst exists. The overwritten item is moved to the trash if useTrash is set,
+// so that the paste can be undone
+func prepareConflictingDestination(src, dst string, resolution conflictResolution,
+	useTrash bool) (pasteDestination, error) {
+	result := pasteDestination{path: dst, resolution: resolution}
+	dstInfo, err := os.Lstat(dst)
+	if os.IsNotExist(err) {
+		return result, nil
+	} else if err != nil {
+		return result, err
+	}
+	result.conflict = true
+	// Never remove the item being pasted, or a directory containing it
+	if isAncestor(dst, src) {
+		result.resolution = conflictKeepBoth
+	}
+
+	if result.resolution == conflictOverwriteIfNewer {
+		srcInfo, err := lstatPasteSource(src)
+		if err != nil {
+			return result, err
+		}
+		result.resolution = conflictSkip
+		if srcInfo.ModTime().After(dstInfo.ModTime()) {
+			result.resolution = conflictOverwrite
+		}
+	}
+
+	switch result.resolution {
+	case conflictOverwrite:
+		if !useTrash {
+			return result, os.RemoveAll(dst)
+		}
+		trashResult, err := trash.Move(dst)
+		result.trashed = trashResult.TrashedPath
+		return result, err
+	case conflictSkip:
+		return result, nil
+	case conflictKeepBoth, conflictOverwriteIfNewer:
+	}
+	result.resolution = conflictKeepBoth
+	result.path, err = renameIfDuplicate(dst)
+	return result, err
+}
+
+// The item being pasted can be an entry of an archive
+func lstatPasteSource(src string) (os.FileInfo, error) {
+	if archivefs.IsVirtual(src) {
+		return archivefs.Lstat(src)
+	}
+	return os.Lstat(src)
+}
+
+// conflictSummary counts how the conflicts of a paste were resolved
+type conflictSummary map[conflictResolution]int
+
+func (s conflictSummary) String() string {
+	var parts []string
+	for _, r := range []struct {
+		resolution conflictResolution
+		text       string
+	}{
+		{conflictOverwrite, "overwritten"},
+		{conflictSkip, "skipped"},
+		{conflictKeepBoth, "kept both"},
+	} {
+		if s[r.resolution] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", s[r.resolution], r.text))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/internal/ui/permissionmodel"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+// Open the modal to change the permissions and the ownership of the selected
+// items, or the focused one. It starts with those of the focused item
+func (m *model) openPermissionModal() {
+	panel := m.getFocusedFilePanel()
+	if panel.EmptyOrInvalid() || m.isReadOnlyPanel("change permissions") {
+		return
+	}
+	items := []string{panel.GetFocusedItem().Location}
+	if panel.SelectedCount() > 0 {
+		items = panel.GetSelectedTopLocationsSortedAsVisible()
+	}
+	info, err := os.Stat(panel.GetFocusedItem().Location)
+	if err != nil {
+		slog.Error("Error while getting the permissions of the focused item", "error", err)
+		return
+	}
+	owner, group := filepanel.FileOwnership(info)
+	hasDir := slices.ContainsFunc(items, func(item string) bool {
+		itemInfo, err := os.Stat(item)
+		return err == nil && itemInfo.IsDir()
+	})
+	m.permissionModal.Open(items, info.Mode(), owner, group, hasDir)
+}
+
+// Handles the keys to confirm or close the permission modal. The others are
+// handled by the modal, via updateComponentState
+func (m *model) permissionKey(msg string) tea.Cmd {
+	// Printable keys, like 'q', are typed in the text fields
+	typing := m.permissionModal.IsTyping() && utf8.RuneCountInString(msg) == 1
+	switch {
+	case slices.Contains(permissionmodel.KeyConfirm(), msg):
+		return m.confirmPermissionChange()
+	case slices.Contains(permissionmodel.KeyClose(), msg) && !typing:
+		m.permissionModal.Close()
+	}
+	return nil
+}
+
+// The change is only confirmed once all the fields are valid, the errors are
+// shown in the modal meanwhile
+func (m *model) confirmPermissionChange() tea.Cmd {
+	change, err := m.permissionModal.GetChange()
+	if err != nil {
+		slog.Debug("Permission change with invalid fields cannot be confirmed", "error", err)
+		return nil
+	}
+	m.permissionModal.Close()
+	if !change.SetMode && change.UID == -1 && change.GID == -1 {
+		return nil
+	}
+
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting permission change request", "id", reqID, "items cnt", len(change.Items),
+		"mode", permissionmodel.FormatMode(change.Mode), "set mode", change.SetMode,
+		"uid", change.UID, "gid", change.GID, "recursive", change.Recursive)
+	return func() tea.Msg {
+		return m.permissionOperation(&m.processBarModel, change, reqID)
+	}
+}
+
+func (m *model) permissionOperation(processBarModel *processbar.Model, change permissionmodel.Change,
+	reqID int) tea.Msg {
+	targets := permissionTargets(change)
+	if len(targets) == 0 {
+		return NewPermissionOperationMsg(processbar.Cancelled, reqID)
+	}
+	p, err := processBarModel.SendAddProcessMsg(filepath.Base(targets[0]), processbar.OpPermissions,
+		len(targets), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		return NewPermissionOperationMsg(processbar.Failed, reqID)
+	}
+	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg {
+		return NewPermissionOperationMsg(state, reqID)
+	}
+	return m.runFileProcessor(makePermissionProcessor(p, processBarModel, change), finalizer, targets, reqID)
+}
+
+// The items to change, with the content of the directories for a recursive
+// change, each directory before its content. A directory whose content cannot
+// be read is still changed, as its mode may be what prevents reading it
+func permissionTargets(change permissionmodel.Change) []string {
+	if !change.Recursive {
+		return change.Items
+	}
+	var targets []string
+	for _, item := range change.Items {
+		err := filepath.WalkDir(item, func(path string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				slog.Error("Error while listing the items to change permissions of", "path", path, "error", err)
+				// Called a second time for a directory whose content cannot be read
+				if len(targets) > 0 && targets[len(targets)-1] == path {
+					return nil
+				}
+			}
+			targets = append(targets, path)
+			return nil
+		})
+		if err != nil {
+			slog.Error("Error while walking the items to change permissions of", "item", item, "error", err)
+		}
+	}
+	return targets
+}
+
+// Each target is changed on its own, so that the user can skip the ones that
+// fail, like the items of another user, and go on with the others
+func makePermissionProcessor(process processbar.Process, processBarModel *processbar.Model,
+	change permissionmodel.Change) processbar.FileListProcessor {
+	return makeItemProcessor(process, processBarModel, func(_ *processbar.Process, item string) error {
+		return applyPermissionChange(item, change)
+	})
+}
+
+// Like chmod and chown, the links are not followed inside the directories
+// changed recursively: their mode cannot be changed and their own ownership is
+func applyPermissionChange(path string, change permissionmodel.Change) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	isLink := info.Mode()&fs.ModeSymlink != 0
+	if isLink && !slices.Contains(change.Items, path) {
+		if change.UID == -1 && change.GID == -1 {
+			return nil
+		}
+		return os.Lchown(path, change.UID, change.GID)
+	}
+	if isLink {
+		if info, err = os.Stat(path); err != nil {
+			return err
+		}
+	}
+	// The ownership first, as changing it clears the setuid and setgid bits
+	var errs []error
+	if change.UID != -1 || change.GID != -1 {
+		errs = append(errs, os.Chown(path, change.UID, change.GID))
+	}
+	if change.SetMode {
+		errs = append(errs, os.Chmod(path, change.ModeFor(info.IsDir())))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func fileModeEventually(t *testing.T, path string, expected fs.FileMode) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		info, err := os.Stat(path)
+		return err == nil && info.Mode().Perm() == expected
+	}, DefaultTestTimeout, DefaultTestTick, "mode of %s should become %o", path, expected)
+}
+
+func TestPermissionChange(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Skipping for windows")
+	}
+	curTestDir := t.TempDir()
+	file := filepath.Join(curTestDir, "a.txt")
+	dir := filepath.Join(curTestDir, "d")
+	subDir := filepath.Join(dir, "sub")
+	subFile := filepath.Join(dir, "x.txt")
+	utils.SetupDirectories(t, dir, subDir)
+	utils.SetupFiles(t, file, subFile)
+	require.NoError(t, os.Chmod(file, 0o644))
+	require.NoError(t, os.Chmod(subFile, 0o600))
+	require.NoError(t, os.Chmod(subDir, 0o700))
+
+	t.Run("Grid", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		TeaUpdate(m, nil)
+		setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "a.txt")
+		p := NewTestTeaProgWithEventLoop(t, m)
+
+		p.SendKey(common.Hotkeys.ChangePermissions[0])
+		assert.Eventually(t, func() bool {
+			return p.getModel().permissionModal.IsOpen()
+		}, DefaultTestTimeout, DefaultTestTick)
+		// User execute
+		p.Send(tea.KeyPressMsg{Code: tea.KeyRight}, tea.KeyPressMsg{Code: tea.KeyRight},
+			tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}, tea.KeyPressMsg{Code: tea.KeyEnter})
+		fileModeEventually(t, file, 0o744)
+		assert.False(t, p.getModel().permissionModal.IsOpen())
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		TeaUpdate(m, nil)
+		setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "d")
+		p := NewTestTeaProgWithEventLoop(t, m)
+
+		p.SendKey(common.Hotkeys.ChangePermissions[0])
+		for range 6 {
+			p.Send(tea.KeyPressMsg{Code: tea.KeyDown})
+		}
+		// The default masks keep the execute bits of the directories only
+		p.Send(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}, tea.KeyPressMsg{Code: tea.KeyEnter})
+		fileModeEventually(t, subFile, 0o644)
+		fileModeEventually(t, subDir, 0o755)
+		fileModeEventually(t, dir, 0o755)
+	})
+
+	t.Run("Invalid owner", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		TeaUpdate(m, nil)
+		setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "a.txt")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ChangePermissions[0]))
+		require.True(t, m.permissionModal.IsOpen())
+		for range 4 {
+			TeaUpdate(m, tea.KeyPressMsg{Code: tea.KeyDown})
+		}
+		for _, r := range "superfile-no-such-user" {
+			TeaUpdate(m, utils.TeaRuneKeyMsg(string(r)))
+		}
+		TeaUpdate(m, tea.KeyPressMsg{Code: tea.KeyEnter})
+		assert.True(t, m.permissionModal.IsOpen(), "not applied with an unknown owner")
+		TeaUpdate(m, tea.KeyPressMsg{Code: tea.KeyEscape})
+		assert.False(t, m.permissionModal.IsOpen())
+		assert.Empty(t, m.processBarModel.GetProcessesSlice())
+	})
+}
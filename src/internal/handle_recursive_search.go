@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"log/slog"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+)
+
+// getRecursiveSearchCmd starts the recursive searches whose query changed,
+// and returns the Cmd listening for their results
+func (m *model) getRecursiveSearchCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.fileModel.FilePanels {
+		results := m.fileModel.FilePanels[i].SyncRecursiveSearch(m.fileModel.DisplayDotFiles,
+			common.Config.RecursiveSearchMaxDepth)
+		if results != nil {
+			reqID := m.nextIoReqCnt()
+			slog.Debug("Submitting recursive search request", "id", reqID,
+				"location", m.fileModel.FilePanels[i].Location)
+			cmds = append(cmds, listenRecursiveSearch(results, reqID))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// listenRecursiveSearch waits for the next batch of results of a search
+func listenRecursiveSearch(results <-chan filepanel.RecursiveSearchBatch, reqID int) tea.Cmd {
+	return func() tea.Msg {
+		batch, ok := <-results
+		if !ok {
+			// Cancelled, nothing more is coming
+			return nil
+		}
+		return NewRecursiveSearchMsg(batch, results, reqID)
+	}
+}
+
+// openRecursiveSearchResult goes to the directory of the focused result of
+// the recursive search, with the cursor on it
+func (m *model) openRecursiveSearchResult() {
+	panel := m.getFocusedFilePanel()
+	if panel.EmptyOrInvalid() {
+		return
+	}
+	location := panel.GetFocusedItem().Location
+	panel.LeaveRecursiveResults()
+	if err := m.showFileInFocusedPanel(location); err != nil {
+		slog.Error("Error while going to a recursive search result", "error", err, "target", location)
+	}
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestRecursiveSearch(t *testing.T) {
+	curTestDir := t.TempDir()
+	deepDir := filepath.Join(curTestDir, "sub", "deep")
+	utils.SetupDirectories(t, deepDir)
+	utils.SetupFiles(t, filepath.Join(curTestDir, "other.txt"), filepath.Join(deepDir, "other.txt"),
+		filepath.Join(deepDir, "target.txt"))
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	p.SendKey(common.Hotkeys.SearchBar[0])
+	// Sent as a real terminal does, without text, so it isn't typed
+	p.Send(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	for _, key := range "target" {
+		p.SendKey(string(key))
+	}
+	require.Eventually(t, func() bool {
+		panel := p.getModel().getFocusedFilePanel()
+		return !panel.RecursiveSearchRunning() && panel.ElemCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick)
+	panel := p.getModel().getFocusedFilePanel()
+	assert.True(t, panel.IsRecursiveSearch())
+	assert.Equal(t, filepath.Join("sub", "deep", "target.txt"), panel.GetFocusedItem().Name)
+
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	p.SendKey(common.Hotkeys.Confirm[0])
+	require.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().Location == deepDir
+	}, DefaultTestTimeout, DefaultTestTick)
+	panel = p.getModel().getFocusedFilePanel()
+	assert.Equal(t, "target.txt", panel.GetFocusedItem().Name, "the cursor is on the result")
+	assert.Empty(t, panel.SearchBar.Value())
+	assert.Equal(t, 2, panel.ElemCount())
+}
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/session"
+	"github.com/yorukot/superfile/src/internal/ui/filemodel"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+)
+
+var errNoSessionPanel = errors.New("none of the directories of the session exist anymore")
+
+// sessionState returns the layout of the tabs and of their panels, to be
+// saved as a session
+func (m *model) sessionState() session.Session {
+	s := session.Session{
+		ActiveTab:   m.fileModel.ActiveTab(),
+		PreviewOpen: m.fileModel.FilePreview.IsOpen(),
+		FooterOpen:  m.toggleFooter,
+	}
+	for _, tab := range m.fileModel.Tabs() {
+		tabState := session.Tab{Name: tab.Name, FocusedPanel: tab.FocusedPanelIndex}
+		for i := range tab.FilePanels {
+			tabState.Panels = append(tabState.Panels, tab.FilePanels[i].SessionState())
+		}
+		s.Tabs = append(s.Tabs, tabState)
+	}
+	return s
+}
+
+// restoreSession opens the tabs of s in place of the current ones. The
+// panels whose directory does not exist anymore are left out, and so are the
+// tabs left without panels
+func (m *model) restoreSession(s session.Session) (tea.Cmd, error) {
+	tabs := []filemodel.Tab{}
+	activeIndex := 0
+	for i, tabState := range s.Tabs {
+		tab := filemodel.Tab{Name: tabState.Name}
+		for j, state := range tabState.Panels {
+			panel, err := filepanel.FromSession(state, false)
+			if err != nil {
+				slog.Error("Skipping panel of session", "location", state.Location, "error", err)
+				continue
+			}
+			if j == tabState.FocusedPanel {
+				tab.FocusedPanelIndex = len(tab.FilePanels)
+			}
+			tab.FilePanels = append(tab.FilePanels, panel)
+		}
+		if len(tab.FilePanels) == 0 {
+			continue
+		}
+		if i == s.ActiveTab {
+			activeIndex = len(tabs)
+		}
+		tabs = append(tabs, tab)
+	}
+	if len(tabs) == 0 {
+		return nil, errNoSessionPanel
+	}
+	m.focusPanel = nonePanelFocus
+	cmd := m.fileModel.SetTabs(tabs, activeIndex)
+
+	if m.fullHeight == 0 {
+		// Before the first resize, the dimensions are computed with the layout
+		m.fileModel.FilePreview.SetOpen(s.PreviewOpen)
+		m.toggleFooter = s.FooterOpen
+		return cmd, nil
+	}
+	cmd = tea.Batch(cmd, m.fileModel.SetFilePreviewOpen(s.PreviewOpen))
+	if s.FooterOpen != m.toggleFooter {
+		// Also updates the layout for the tab strip
+		return tea.Batch(cmd, m.toggleFooterController()), nil
+	}
+	return tea.Batch(cmd, m.updateTabStripLayout()), nil
+}
+
+// restoreLastSession restores the session saved when superfile last quit
+func (m *model) restoreLastSession() {
+	s, err := session.Load(m.sessionFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Error("Error loading the last session", "error", err)
+		}
+		return
+	}
+	if _, err := m.restoreSession(s); err != nil {
+		slog.Error("Error restoring the last session", "error", err)
+	}
+}
+
+// saveLastSession saves the session, to be restored when superfile starts
+func (m *model) saveLastSession() {
+	if m.sessionFile == "" {
+		return
+	}
+	if err := session.Save(m.sessionFile, m.sessionState()); err != nil {
+		slog.Error("Error saving the session", "error", err)
+	}
+}
+
+func (m *model) saveNamedSession(name string) error {
+	filePath, err := session.NamedFile(m.sessionsDir, name)
+	if err != nil {
+		return err
+	}
+	return session.Save(filePath, m.sessionState())
+}
+
+func (m *model) loadNamedSession(name string) (tea.Cmd, error) {
+	filePath, err := session.NamedFile(m.sessionsDir, name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := session.Load(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		names := session.Names(m.sessionsDir)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no session named %q, no session is saved", name)
+		}
+		return nil, fmt.Errorf("no session named %q, saved sessions: %s", name, strings.Join(names, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.restoreSession(s)
+}
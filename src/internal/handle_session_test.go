@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestSession(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "a.txt"), filepath.Join(dir1, "b.txt"), filepath.Join(dir1, "c.txt"),
+		filepath.Join(dir2, "x.txt"), filepath.Join(dir2, "y.go"))
+
+	setupModel := func(t *testing.T) *model {
+		t.Helper()
+		m := defaultTestModel(dir1)
+		m.sessionsDir = filepath.Join(t.TempDir(), "sessions")
+		TeaUpdate(m, nil)
+		// Cursor on b.txt
+		m.getFocusedFilePanel().ListDown()
+		_, err := m.createNewFilePanelRelativeToCurrent(dir2)
+		require.NoError(t, err)
+		panel := m.getFocusedFilePanel()
+		panel.SetSortOptions(sortmodel.Options{Kind: sortmodel.SortByType, Reversed: true})
+		panel.ToggleTreeMode()
+		panel.SearchBar.SetValue("x")
+		m.fileModel.FocusedPanelIndex = 0
+		m.fileModel.FilePanels[0].IsFocused = true
+		m.fileModel.FilePanels[1].IsFocused = false
+		return m
+	}
+
+	checkRestored := func(t *testing.T, m *model) {
+		t.Helper()
+		TeaUpdate(m, nil)
+		require.Equal(t, 2, m.fileModel.PanelCount())
+		assert.Equal(t, 0, m.fileModel.FocusedPanelIndex)
+		assert.True(t, m.fileModel.FilePanels[0].IsFocused)
+		assert.False(t, m.fileModel.FilePanels[1].IsFocused)
+
+		first := &m.fileModel.FilePanels[0]
+		assert.Equal(t, dir1, first.Location)
+		assert.Equal(t, "b.txt", first.GetFocusedItem().Name, "cursor on the same item")
+
+		second := &m.fileModel.FilePanels[1]
+		assert.Equal(t, dir2, second.Location)
+		assert.Equal(t, sortmodel.Options{Kind: sortmodel.SortByType, Reversed: true}, second.SortOptions())
+		assert.Equal(t, filepanel.TreeMode, second.PanelMode)
+		assert.Equal(t, "x", second.SearchBar.Value())
+		assert.Equal(t, 1, second.ElemCount(), "search filter applied")
+	}
+
+	t.Run("Named session", func(t *testing.T) {
+		m := setupModel(t)
+		msg, _, err := m.logAndExecuteAction(common.SaveSessionAction{Name: "work"})
+		require.NoError(t, err)
+		assert.Equal(t, "Session saved as work", msg)
+
+		_, err = m.fileModel.CloseFilePanel()
+		require.NoError(t, err)
+		require.NoError(t, m.updateCurrentFilePanelDir(curTestDir))
+
+		msg, _, err = m.logAndExecuteAction(common.LoadSessionAction{Name: "work"})
+		require.NoError(t, err)
+		assert.Equal(t, "Session work loaded", msg)
+		checkRestored(t, m)
+	})
+
+	t.Run("Unknown or invalid name", func(t *testing.T) {
+		m := setupModel(t)
+		require.NoError(t, m.saveNamedSession("work"))
+		require.NoError(t, m.saveNamedSession("home"))
+
+		_, err := m.loadNamedSession("other")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "saved sessions: home, work")
+
+		require.Error(t, m.saveNamedSession("../work"))
+		assert.Equal(t, 2, m.fileModel.PanelCount(), "panels unchanged")
+	})
+
+	t.Run("Last session restored on start", func(t *testing.T) {
+		m := setupModel(t)
+		m.sessionFile = filepath.Join(t.TempDir(), "session.json")
+		m.saveLastSession()
+
+		restored := defaultModelConfig(false, false, false, []string{curTestDir}, nil)
+		restored.sessionFile = m.sessionFile
+		restored.restoreLastSession()
+		restored = setModelParamsForTest(restored, true)
+		checkRestored(t, restored)
+	})
+
+	t.Run("Tabs", func(t *testing.T) {
+		m := setupModel(t)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.NewTab[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.RenameTab[0]))
+		for range len("dir1") {
+			TeaUpdate(m, utils.TeaRuneKeyMsg("backspace"))
+		}
+		TeaUpdate(m, utils.TeaRuneKeyMsg("w"))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ConfirmTyping[0]))
+		require.NoError(t, m.saveNamedSession("work"))
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CloseTab[0]))
+		require.Equal(t, 1, m.fileModel.TabCount())
+
+		_, err := m.loadNamedSession("work")
+		require.NoError(t, err)
+		require.Equal(t, 2, m.fileModel.TabCount())
+		assert.Equal(t, 1, m.fileModel.ActiveTab())
+		assert.Equal(t, "w", m.fileModel.TabName(1))
+		assert.Equal(t, 1, m.fileModel.PanelCount())
+		assertLayoutValidity(t, m)
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.NextTab[0]))
+		checkRestored(t, m)
+		assertLayoutValidity(t, m)
+	})
+
+	t.Run("Missing directories left out", func(t *testing.T) {
+		removedDir := filepath.Join(curTestDir, "removed")
+		utils.SetupDirectories(t, removedDir)
+		m := setupModel(t)
+		require.NoError(t, m.updateCurrentFilePanelDir(removedDir))
+		require.NoError(t, m.saveNamedSession("work"))
+		require.NoError(t, os.Remove(removedDir))
+
+		_, err := m.loadNamedSession("work")
+		require.NoError(t, err)
+		TeaUpdate(m, nil)
+		require.Equal(t, 1, m.fileModel.PanelCount())
+		assert.Equal(t, dir2, m.getFocusedFilePanel().Location)
+	})
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+)
+
+// newSortMemory returns the sorts chosen for each directory, or nil if the
+// sort is not remembered per directory
+func newSortMemory() *sortmodel.Memory {
+	if !common.Config.SortPerDirectory {
+		return nil
+	}
+	return sortmodel.NewMemory(variable.SortFile)
+}
+
+// applyDirSorts sorts the panels that navigated to another directory with the
+// sort chosen for it
+func (m *model) applyDirSorts() {
+	if m.sortMemory == nil {
+		return
+	}
+	for _, panel := range m.fileModel.Panels() {
+		panel.ApplyDirSort(m.sortMemory)
+	}
+}
+
+// rememberSort saves the sort of the focused panel for its directory
+func (m *model) rememberSort() {
+	if m.sortMemory == nil {
+		return
+	}
+	panel := m.getFocusedFilePanel()
+	m.sortMemory.Set(panel.Location, panel.SortOptions())
+}
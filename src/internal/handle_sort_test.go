@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestSortPerDirectory(t *testing.T) {
+	curTestDir := t.TempDir()
+	childDir := filepath.Join(curTestDir, "child")
+	utils.SetupDirectories(t, childDir)
+	utils.SetupFiles(t, filepath.Join(childDir, "a.txt"), filepath.Join(childDir, "b.txt"))
+
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := origConfig
+	cfg.SortPerDirectory = true
+	cfg.SortOrderReversed = false
+	common.SetConfig(cfg)
+
+	sortFile := filepath.Join(t.TempDir(), "sort.json")
+	m := defaultTestModel(childDir)
+	m.sortMemory = sortmodel.NewMemory(sortFile)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ToggleReverseSort[0]))
+	require.True(t, m.getFocusedFilePanel().SortReversed)
+	assert.Equal(t, "b.txt", m.getFocusedFilePanel().GetElementAtIdx(0).Name)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	require.Equal(t, curTestDir, m.getFocusedFilePanel().Location)
+	assert.False(t, m.getFocusedFilePanel().SortReversed, "the default sort in another directory")
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.Confirm[0]))
+	require.Equal(t, childDir, m.getFocusedFilePanel().Location)
+	assert.True(t, m.getFocusedFilePanel().SortReversed, "the sort chosen is restored")
+	assert.Equal(t, "b.txt", m.getFocusedFilePanel().GetElementAtIdx(0).Name)
+
+	opts, ok := sortmodel.NewMemory(sortFile).Get(childDir)
+	require.True(t, ok, "the sort is saved")
+	assert.True(t, opts.Reversed)
+}
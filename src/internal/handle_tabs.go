@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filemodel"
+)
+
+// Open a new tab in the directory of the focused panel
+func (m *model) newTab() tea.Cmd {
+	cmd, err := m.fileModel.NewTab(m.getFocusedFilePanel().Location)
+	if err != nil {
+		slog.Error("Error while opening a new tab", "error", err)
+		return nil
+	}
+	m.focusPanel = nonePanelFocus
+	return tea.Batch(cmd, m.updateTabStripLayout())
+}
+
+func (m *model) closeTab() tea.Cmd {
+	cmd, err := m.fileModel.CloseTab()
+	if err != nil {
+		if !errors.Is(err, filemodel.ErrMinimumTabCount) {
+			slog.Error("Unexpected error while closing the tab", "error", err)
+		}
+		return nil
+	}
+	m.focusPanel = nonePanelFocus
+	return tea.Batch(cmd, m.updateTabStripLayout())
+}
+
+func (m *model) moveActiveTabBy(delta int) tea.Cmd {
+	if m.fileModel.TabCount() <= 1 {
+		return nil
+	}
+	m.focusPanel = nonePanelFocus
+	return m.fileModel.MoveActiveTabBy(delta)
+}
+
+func (m *model) renameTab() {
+	m.fileModel.StartTabRename()
+	if m.fileModel.IsRenamingTab() {
+		m.firstTextInput = true
+	}
+}
+
+// The tab strip takes a line above the panels when there is more than one
+// tab, the components have to be resized when it appears or disappears
+func (m *model) updateTabStripLayout() tea.Cmd {
+	if m.fullHeight == 0 {
+		return nil
+	}
+	m.setHeightValues()
+	return m.updateComponentDimensions()
+}
+
+func (m *model) tabStripHeight() int {
+	if m.fileModel.TabCount() <= 1 {
+		return 0
+	}
+	return filemodel.TabStripHeight
+}
+
+func (m *model) tabRenamingKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.CancelTyping, msg):
+		m.fileModel.CancelTabRename()
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
+		m.fileModel.ConfirmTabRename()
+	}
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestTabs(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	file1 := filepath.Join(dir1, "file1.txt")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, file1)
+
+	t.Run("Create, switch and close", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CloseTab[0]))
+		assert.Equal(t, 1, m.fileModel.TabCount(), "the only tab cannot be closed")
+		assert.NotContains(t, ansi.Strip(m.View().Content), " 1 dir1 ", "no tab strip with a single tab")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.NewTab[0]))
+		require.Equal(t, 2, m.fileModel.TabCount())
+		assert.Equal(t, 1, m.fileModel.ActiveTab())
+		assert.Equal(t, 1, m.fileModel.PanelCount())
+		assert.Equal(t, dir1, m.getFocusedFilePanel().Location, "new tab opened in the same directory")
+		assertLayoutValidity(t, m)
+
+		require.NoError(t, m.updateCurrentFilePanelDir(dir2))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CreateNewFilePanel[0]))
+		assert.Equal(t, 2, m.fileModel.PanelCount())
+		// Named after the directory of the focused panel
+		newPanelName := filepath.Base(m.getFocusedFilePanel().Location)
+		assert.Equal(t, newPanelName, m.fileModel.TabName(1))
+		view := ansi.Strip(m.View().Content)
+		assert.Contains(t, view, " 1 dir1 ")
+		assert.Contains(t, view, " 2 "+newPanelName+" ")
+		assertLayoutValidity(t, m)
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.PreviousTab[0]))
+		assert.Equal(t, 0, m.fileModel.ActiveTab())
+		assert.Equal(t, 1, m.fileModel.PanelCount(), "panels of the first tab kept")
+		assert.Equal(t, dir1, m.getFocusedFilePanel().Location)
+		assertLayoutValidity(t, m)
+
+		// Wraps around
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.PreviousTab[0]))
+		assert.Equal(t, 1, m.fileModel.ActiveTab())
+		assert.Equal(t, 2, m.fileModel.PanelCount())
+		assert.Equal(t, dir2, m.fileModel.FilePanels[0].Location)
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CloseTab[0]))
+		assert.Equal(t, 1, m.fileModel.TabCount())
+		assert.Equal(t, dir1, m.getFocusedFilePanel().Location)
+		assert.NotContains(t, ansi.Strip(m.View().Content), " 1 dir1 ")
+		assertLayoutValidity(t, m)
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.RenameTab[0]))
+		assert.False(t, m.fileModel.IsRenamingTab(), "nothing to rename with a single tab")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.NewTab[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.RenameTab[0]))
+		require.True(t, m.fileModel.IsRenamingTab())
+		m.fileModel.ConfirmTabRename()
+		assert.Equal(t, "dir1", m.fileModel.TabName(1), "unchanged name kept")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.RenameTab[0]))
+		// Clear the name filled in the input
+		for range len("dir1") {
+			TeaUpdate(m, utils.TeaRuneKeyMsg("backspace"))
+		}
+		for _, r := range "work" {
+			TeaUpdate(m, utils.TeaRuneKeyMsg(string(r)))
+		}
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ConfirmTyping[0]))
+		assert.False(t, m.fileModel.IsRenamingTab())
+		assert.Equal(t, "work", m.fileModel.TabName(1))
+		assert.Contains(t, ansi.Strip(m.View().Content), " 2 work ")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.RenameTab[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg("x"))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CancelTyping[0]))
+		assert.Equal(t, "work", m.fileModel.TabName(1), "name unchanged when cancelled")
+	})
+
+	t.Run("Recursive search in a tab left", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		panel := m.getFocusedFilePanel()
+		panel.ToggleRecursiveSearch()
+		panel.SearchBar.SetValue("file")
+		TeaUpdate(m, nil)
+		require.True(t, panel.RecursiveSearchRunning())
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.NewTab[0]))
+		assert.False(t, panel.RecursiveSearchRunning(), "its results cannot reach a panel of another tab")
+
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.PreviousTab[0]))
+		assert.True(t, m.getFocusedFilePanel().RecursiveSearchRunning(), "searched again once back")
+	})
+
+	t.Run("Copy and paste across tabs", func(t *testing.T) {
+		p := NewTestTeaProgWithEventLoop(t, defaultTestModel(dir1))
+		require.Equal(t, "file1.txt", p.getModel().getFocusedFilePanel().GetFocusedItem().Name)
+		p.SendKeyDirectly(common.Hotkeys.CopyItems[0])
+
+		p.SendKeyDirectly(common.Hotkeys.NewTab[0])
+		require.NoError(t, p.getModel().updateCurrentFilePanelDir(dir2))
+		assert.Equal(t, file1, p.getModel().clipboard.GetFirstItem(), "clipboard shared by the tabs")
+		p.SendKey(common.Hotkeys.PasteItems[0])
+
+		assert.Eventually(t, func() bool {
+			_, err := os.Lstat(filepath.Join(dir2, "file1.txt"))
+			return err == nil
+		}, DefaultTestTimeout, DefaultTestTick)
+	})
+}
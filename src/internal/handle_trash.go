@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/internal/ui/trashbin"
+)
+
+func (m *model) openTrashBin() (tea.Cmd, error) {
+	if !m.hasTrash {
+		return nil, errors.New("trash is not available")
+	}
+	m.trashBin.Open()
+	return m.getTrashListCmd(), nil
+}
+
+func (m *model) getTrashListCmd() tea.Cmd {
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting trash list request", "id", reqID)
+	return func() tea.Msg {
+		items, err := trash.List()
+		if err != nil {
+			slog.Error("Error while listing the trash", "error", err)
+		}
+		return NewTrashListMsg(items, err, reqID)
+	}
+}
+
+func (m *model) trashBinKey(msg string) tea.Cmd {
+	switch {
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.trashBin.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.trashBin.ListDown()
+	case slices.Contains(trashbin.KeyToggleSelect(), msg):
+		m.trashBin.ToggleSelect()
+	case slices.Contains(trashbin.KeySelectAll(), msg):
+		m.trashBin.SelectAll()
+	case slices.Contains(trashbin.KeyRestore(), msg):
+		return m.getRestoreTrashItemsCmd(m.trashBin.GetTargetItems())
+	case slices.Contains(trashbin.KeyPurge(), msg):
+		if len(m.trashBin.GetTargetItems()) > 0 {
+			m.notifyModel = notify.New(true, common.PurgeTrashWarnTitle,
+				common.PermanentDeleteWarnContent, notify.PurgeTrashAction)
+		}
+	case slices.Contains(trashbin.KeyEmptyTrash(), msg):
+		if len(m.trashBin.GetItems()) > 0 {
+			m.notifyModel = notify.New(true, common.EmptyTrashWarnTitle,
+				common.PermanentDeleteWarnContent, notify.EmptyTrashAction)
+		}
+	case slices.Contains(trashbin.KeyClose(), msg):
+		m.trashBin.Close()
+	default:
+		slog.Debug("Invalid keypress in trash bin", "msg", msg)
+	}
+	return nil
+}
+
+// Restore the items, after asking how to resolve the conflicts with
+// the items now at their original paths, if any
+func (m *model) getRestoreTrashItemsCmd(items []trash.Item) tea.Cmd {
+	if len(items) == 0 {
+		return nil
+	}
+	var conflicts []trash.Item
+	for _, item := range items {
+		if _, err := os.Lstat(item.OriginalPath); err == nil {
+			conflicts = append(conflicts, item)
+		}
+	}
+	if len(conflicts) == 0 {
+		return m.submitRestore(items, nil)
+	}
+	m.openConflictPrompt(&conflictPrompt[trash.Item]{
+		conflicts: conflicts,
+		key:       func(item trash.Item) string { return item.TrashedPath },
+		describe: func(item trash.Item) string {
+			return fmt.Sprintf("%q already exists in %s", filepath.Base(item.OriginalPath),
+				filepath.Dir(item.OriginalPath))
+		},
+		onResolved: func(resolutions map[string]conflictResolution) tea.Cmd {
+			return m.submitRestore(items, resolutions)
+		},
+	})
+	return nil
+}
+
+func (m *model) submitRestore(items []trash.Item, resolutions map[string]conflictResolution) tea.Cmd {
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting trash restore request", "id", reqID, "items cnt", len(items))
+	return func() tea.Msg {
+		return m.restoreTrashOperation(&m.processBarModel, items, resolutions, reqID)
+	}
+}
+
+func (m *model) getPurgeTrashItemsCmd(items []trash.Item) tea.Cmd {
+	if len(items) == 0 {
+		return nil
+	}
+	reqID := m.nextIoReqCnt()
+	slog.Debug("Submitting trash purge request", "id", reqID, "items cnt", len(items))
+	return func() tea.Msg {
+		return m.purgeTrashOperation(&m.processBarModel, items, reqID)
+	}
+}
+
+func (m *model) restoreTrashOperation(processBarModel *processbar.Model, items []trash.Item,
+	resolutions map[string]conflictResolution, reqID int) tea.Msg {
+	p, err := processBarModel.SendAddProcessMsg(filepath.Base(items[0].OriginalPath),
+		processbar.OpRestore, len(items), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		return NewTrashOperationMsg(processbar.Failed, reqID)
+	}
+	originalPaths := make(map[string]string, len(items))
+	for _, item := range items {
+		originalPaths[item.TrashedPath] = item.OriginalPath
+	}
+	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg { return NewTrashOperationMsg(state, reqID) }
+	processor := makeTrashProcessor(p, processBarModel, func(trashedPath string, summary conflictSummary) error {
+		originalPath := originalPaths[trashedPath]
+		// The overwritten item goes to the trash too, like with a paste
+		useTrash := m.hasTrash && trash.Available(originalPath)
+		dst, err := prepareConflictingDestination(trashedPath, originalPath, resolutions[trashedPath], useTrash)
+		if dst.conflict {
+			summary[dst.resolution]++
+		}
+		if err != nil || (dst.conflict && dst.resolution == conflictSkip) {
+			return err
+		}
+		return trash.Restore(trashedPath, dst.path)
+	})
+	return m.runFileProcessor(processor, finalizer, trashedPaths(items), reqID)
+}
+
+func (m *model) purgeTrashOperation(processBarModel *processbar.Model, items []trash.Item, reqID int) tea.Msg {
+	p, err := processBarModel.SendAddProcessMsg(filepath.Base(items[0].OriginalPath),
+		processbar.OpDelete, len(items), true)
+	if err != nil {
+		slog.Error("Cannot spawn a new process", "error", err)
+		return NewTrashOperationMsg(processbar.Failed, reqID)
+	}
+	finalizer := func(state processbar.ProcessState, reqID int) tea.Msg { return NewTrashOperationMsg(state, reqID) }
+	processor := makeTrashProcessor(p, processBarModel, func(trashedPath string, _ conflictSummary) error {
+		return trash.Purge(trashedPath)
+	})
+	return m.runFileProcessor(processor, finalizer, trashedPaths(items), reqID)
+}
+
+// makeTrashProcessor runs itemFunc on each trashed path. itemFunc records
+// how it resolved the conflicts, if any, in the summary
+func makeTrashProcessor(process processbar.Process, processBarModel *processbar.Model,
+	itemFunc func(trashedPath string, summary conflictSummary) error) processbar.FileListProcessor {
+	summary := conflictSummary{}
+	return makeItemProcessor(process, processBarModel, func(process *processbar.Process, item string) error {
+		err := itemFunc(item, summary)
+		process.Summary = summary.String()
+		return err
+	})
+}
+
+func trashedPaths(items []trash.Item) []string {
+	paths := make([]string, len(items))
+	for i, item := range items {
+		paths[i] = item.TrashedPath
+	}
+	return paths
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"log/slog"
+	"path/filepath"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/watcher"
+)
+
+// startWatcher starts watching the directories shown, and returns the Cmd
+// listening for their changes. Without a watcher, the panels are polled
+func (m *model) startWatcher() tea.Cmd {
+	w, err := watcher.New()
+	if err != nil {
+		slog.Warn("Cannot watch the filesystem, directories will be polled", "error", err)
+		return nil
+	}
+	m.watcher = w
+	m.syncWatcher()
+	return listenWatcher(w.Changes(), m.nextIoReqCnt())
+}
+
+// listenWatcher waits for the next changes reported by the watcher
+func listenWatcher(source <-chan watcher.Changes, reqID int) tea.Cmd {
+	return func() tea.Msg {
+		changes, ok := <-source
+		if !ok {
+			// The watcher is closed
+			return nil
+		}
+		return NewFilesystemChangedMsg(changes, source, reqID)
+	}
+}
+
+// syncWatcher watches the directories shown by the panels and by the preview.
+// The panels with a directory that cannot be watched are polled
+func (m *model) syncWatcher() {
+	if m.watcher == nil {
+		return
+	}
+	var dirs []string
+	for _, panel := range m.fileModel.Panels() {
+		if !panel.InArchive() {
+			dirs = append(dirs, panel.ShownDirs()...)
+		}
+	}
+	if location := m.previewedLocation(); location != "" {
+		dirs = append(dirs, filepath.Dir(location))
+		if m.getFocusedFilePanel().GetFocusedItem().Directory {
+			// The preview lists its content
+			dirs = append(dirs, location)
+		}
+	}
+	m.watcher.Sync(dirs)
+
+	for _, panel := range m.fileModel.Panels() {
+		panel.Watched = !panel.InArchive() && !slices.ContainsFunc(panel.ShownDirs(), func(dir string) bool {
+			return !m.watcher.IsWatching(dir)
+		})
+	}
+}
+
+// previewedLocation returns the location of the item shown in the preview, or
+// an empty string if it is not on the disk
+func (m *model) previewedLocation() string {
+	panel := m.getFocusedFilePanel()
+	if !m.fileModel.FilePreview.IsOpen() || panel.InArchive() || panel.EmptyOrInvalid() {
+		return ""
+	}
+	return m.fileModel.FilePreview.GetLocation()
+}
+
+// applyFilesystemChanges re-reads the panels, and re-renders the preview, that
+// show the changed paths
+func (m *model) applyFilesystemChanges(changes watcher.Changes) tea.Cmd {
+	if changes.Overflow {
+		m.invalidateGitStatus(nil)
+		m.invalidateDirSizes(nil)
+		m.invalidateComparison(nil)
+	} else {
+		m.invalidateGitStatus(changes.Paths)
+		m.invalidateDirSizes(changes.Paths)
+		m.invalidateComparison(changes.Paths)
+	}
+	affects := func(location string) bool {
+		return changes.Overflow || slices.ContainsFunc(changes.Paths, func(path string) bool {
+			return path == location || filepath.Dir(path) == location
+		})
+	}
+	for _, panel := range m.fileModel.Panels() {
+		if panel.Watched && slices.ContainsFunc(panel.ShownDirs(), affects) {
+			slog.Debug("Directory changed, updating panel", "location", panel.Location)
+			panel.UpdateElementsIfNeeded(true, m.fileModel.DisplayDotFiles)
+		}
+	}
+	if location := m.previewedLocation(); location != "" && affects(location) {
+		return m.fileModel.GetFilePreviewCmd(true)
+	}
+	return nil
+}
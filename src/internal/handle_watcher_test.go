@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestWatcherRefreshesPanels(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "file1"))
+
+	m := defaultTestModel(dir1, dir2)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	require.Eventually(t, func() bool {
+		return p.getModel().fileModel.FilePanels[1].Watched
+	}, DefaultTestTimeout, DefaultTestTick)
+	require.False(t, p.getModel().fileModel.FilePanels[1].IsFocused)
+
+	// Shown well before the unfocused panel would be polled again
+	utils.SetupFiles(t, filepath.Join(dir2, "file2"))
+	assert.Eventually(t, func() bool {
+		return p.getModel().fileModel.FilePanels[1].ElemCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick)
+
+	require.NoError(t, os.Remove(filepath.Join(dir1, "file1")))
+	assert.Eventually(t, func() bool {
+		return p.getModel().fileModel.FilePanels[0].ElemCount() == 0
+	}, DefaultTestTimeout, DefaultTestTick)
+}
+
+func TestWatcherRefreshesPreview(t *testing.T) {
+	curTestDir := t.TempDir()
+	file1 := filepath.Join(curTestDir, "file1.txt")
+	utils.SetupFilesWithData(t, []byte("old content"), file1)
+
+	originalPreviewWidth := common.Config.FilePreviewWidth
+	common.Config.FilePreviewWidth = 0
+	t.Cleanup(func() {
+		common.Config.FilePreviewWidth = originalPreviewWidth
+	})
+	m := defaultTestModelWithFilePreview(curTestDir)
+	p := NewTestTeaProgWithEventLoopWithWinSize(t, m, 4*DefaultTestModelWidth, 4*DefaultTestModelHeight)
+	eventuallyEnsurePreviewContent(t, p.getModel(), "old content")
+	require.Eventually(t, func() bool {
+		return p.getModel().watcher.IsWatching(curTestDir)
+	}, DefaultTestTimeout, DefaultTestTick)
+
+	require.NoError(t, os.WriteFile(file1, []byte("new content"), 0o644))
+	eventuallyEnsurePreviewContent(t, p.getModel(), "new content")
+}
+
+func TestWithoutWatcher(t *testing.T) {
+	m := defaultTestModel(t.TempDir())
+	m.syncWatcher()
+	assert.False(t, m.getFocusedFilePanel().Watched, "no watcher before Init")
+}
@@ -0,0 +1,165 @@
+// Package journal keeps a history of the file operations done by the user,
+// so that they can be undone and redone. The history is persisted as JSON
+// so it survives restarts.
+package journal
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Maximum count of entries kept in each of the undo and redo stacks
+const maxEntries = 100
+
+type Kind string
+
+const (
+	KindCopy     Kind = "copy"
+	KindMove     Kind = "move"
+	KindRename   Kind = "rename"
+	KindCreate   Kind = "create"
+	KindTrash    Kind = "trash"
+	KindSymlink  Kind = "symlink"
+	KindHardlink Kind = "hardlink"
+)
+
+// Step is a single item affected by an operation. Src is where the item was
+// before the operation and Dst is where it is after it. For KindCreate, only
+// Dst is set. For KindTrash, Dst is the path of the item inside the trash.
+// For KindSymlink, Src is the target written in the link, which can be relative.
+// For KindCopy and KindMove, Trashed is the path inside the trash of the item
+// that was at Dst, and was overwritten
+type Step struct {
+	Src     string `json:"src,omitempty"`
+	Dst     string `json:"dst"`
+	IsDir   bool   `json:"is_dir,omitempty"`
+	Trashed string `json:"trashed,omitempty"`
+}
+
+type Entry struct {
+	Kind  Kind      `json:"kind"`
+	Steps []Step    `json:"steps"`
+	Time  time.Time `json:"time"`
+}
+
+type history struct {
+	Undo []Entry `json:"undo"`
+	Redo []Entry `json:"redo"`
+}
+
+// Journal is safe for concurrent use, as file operations record their
+// entries from tea.Cmd goroutines
+type Journal struct {
+	mu       sync.Mutex
+	filePath string
+	history  history
+}
+
+// New loads the journal stored at filePath. An empty filePath keeps the
+// journal in memory only
+func New(filePath string) *Journal {
+	j := &Journal{filePath: filePath}
+	if filePath == "" {
+		return j
+	}
+	if err := utils.LoadJSONFile(filePath, &j.history); err != nil {
+		slog.Error("Error loading journal", "error", err)
+	}
+	return j
+}
+
+// Record adds a new entry for a just completed operation. This invalidates
+// the redo history. Operations that affected no items are not recorded
+func (j *Journal) Record(kind Kind, steps []Step) {
+	if j == nil || len(steps) == 0 {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history.Undo = pushEntry(j.history.Undo, Entry{Kind: kind, Steps: steps, Time: time.Now()})
+	j.history.Redo = nil
+	j.save()
+}
+
+// PopUndo removes and returns the most recent entry that can be undone
+func (j *Journal) PopUndo() (Entry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var entry Entry
+	var ok bool
+	j.history.Undo, entry, ok = popEntry(j.history.Undo)
+	if ok {
+		j.save()
+	}
+	return entry, ok
+}
+
+// PopRedo removes and returns the most recently undone entry
+func (j *Journal) PopRedo() (Entry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var entry Entry
+	var ok bool
+	j.history.Redo, entry, ok = popEntry(j.history.Redo)
+	if ok {
+		j.save()
+	}
+	return entry, ok
+}
+
+// PushUndo puts back an entry that was redone, without touching the redo history
+func (j *Journal) PushUndo(entry Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history.Undo = pushEntry(j.history.Undo, entry)
+	j.save()
+}
+
+// PushRedo stores an entry that was undone
+func (j *Journal) PushRedo(entry Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history.Redo = pushEntry(j.history.Redo, entry)
+	j.save()
+}
+
+func (j *Journal) UndoCount() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.history.Undo)
+}
+
+func (j *Journal) RedoCount() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.history.Redo)
+}
+
+// Must be called with the mutex held
+func (j *Journal) save() {
+	if j.filePath == "" {
+		return
+	}
+	if err := utils.SaveJSONFile(j.filePath, j.history); err != nil {
+		slog.Error("Error saving journal", "error", err)
+	}
+}
+
+func pushEntry(entries []Entry, entry Entry) []Entry {
+	entries = append(entries, entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+	return entries
+}
+
+func popEntry(entries []Entry) ([]Entry, Entry, bool) {
+	if len(entries) == 0 {
+		return entries, Entry{}, false
+	}
+	last := len(entries) - 1
+	return entries[:last], entries[last], true
+}
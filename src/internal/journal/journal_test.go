@@ -0,0 +1,65 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalUndoRedo(t *testing.T) {
+	j := New("")
+	j.Record(KindCreate, []Step{{Dst: "/a"}})
+	j.Record(KindRename, []Step{{Src: "/a", Dst: "/b"}})
+	j.Record(KindCopy, nil)
+	assert.Equal(t, 2, j.UndoCount())
+
+	entry, ok := j.PopUndo()
+	require.True(t, ok)
+	assert.Equal(t, KindRename, entry.Kind)
+	j.PushRedo(entry)
+
+	entry, ok = j.PopRedo()
+	require.True(t, ok)
+	assert.Equal(t, KindRename, entry.Kind)
+	j.PushUndo(entry)
+	assert.Equal(t, 2, j.UndoCount())
+
+	entry, ok = j.PopUndo()
+	require.True(t, ok)
+	j.PushRedo(entry)
+	assert.Equal(t, 1, j.RedoCount())
+
+	// A new operation invalidates the redo history
+	j.Record(KindTrash, []Step{{Src: "/a", Dst: "/trash/a"}})
+	assert.Equal(t, 0, j.RedoCount())
+	_, ok = j.PopRedo()
+	assert.False(t, ok)
+}
+
+func TestJournalMaxEntries(t *testing.T) {
+	j := New("")
+	for i := range maxEntries + 5 {
+		j.Record(KindCreate, []Step{{Dst: filepath.Join("/", string(rune('a'+i%26)))}})
+	}
+	assert.Equal(t, maxEntries, j.UndoCount())
+}
+
+func TestJournalPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "journal.json")
+	j := New(filePath)
+	j.Record(KindMove, []Step{{Src: "/x/a", Dst: "/y/a"}, {Src: "/x/b", Dst: "/y/b", IsDir: true}})
+	j.Record(KindCreate, []Step{{Dst: "/y/c"}})
+	entry, ok := j.PopUndo()
+	require.True(t, ok)
+	j.PushRedo(entry)
+
+	loaded := New(filePath)
+	assert.Equal(t, 1, loaded.UndoCount())
+	assert.Equal(t, 1, loaded.RedoCount())
+	entry, ok = loaded.PopUndo()
+	require.True(t, ok)
+	assert.Equal(t, KindMove, entry.Kind)
+	assert.Equal(t, []Step{{Src: "/x/a", Dst: "/y/a"}, {Src: "/x/b", Dst: "/y/b", IsDir: true}}, entry.Steps)
+}
@@ -34,7 +34,11 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 		case metadataFocus:
 			m.fileMetaData.ListUp()
 		case nonePanelFocus:
-			m.getFocusedFilePanel().ListUp()
+			if m.fileModel.IsParentColumnFocused() {
+				m.fileModel.ParentColumnListUp()
+			} else {
+				m.getFocusedFilePanel().ListUp()
+			}
 		}
 
 		// If move down Key is pressed, check the current state and executes
@@ -47,7 +51,11 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 		case metadataFocus:
 			m.fileMetaData.ListDown()
 		case nonePanelFocus:
-			m.getFocusedFilePanel().ListDown()
+			if m.fileModel.IsParentColumnFocused() {
+				m.fileModel.ParentColumnListDown()
+			} else {
+				m.getFocusedFilePanel().ListDown()
+			}
 		}
 
 	case slices.Contains(common.Hotkeys.PageUp, msg):
@@ -59,6 +67,10 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.ChangePanelMode, msg):
 		m.getFocusedFilePanel().ChangeFilePanelMode()
 
+	case slices.Contains(common.Hotkeys.ToggleTreeMode, msg):
+		m.getFocusedFilePanel().ToggleTreeMode()
+		m.getFocusedFilePanel().UpdateElementsIfNeeded(true, m.fileModel.DisplayDotFiles)
+
 	case slices.Contains(common.Hotkeys.NextFilePanel, msg):
 		if m.focusPanel == nonePanelFocus {
 			m.fileModel.NextFilePanel()
@@ -81,6 +93,30 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 			slog.Error("unexpected error while creating new panel", "error", err)
 		}
 		return cmd
+	case slices.Contains(common.Hotkeys.NewTab, msg):
+		return m.newTab()
+	case slices.Contains(common.Hotkeys.CloseTab, msg):
+		return m.closeTab()
+	case slices.Contains(common.Hotkeys.NextTab, msg):
+		return m.moveActiveTabBy(1)
+	case slices.Contains(common.Hotkeys.PreviousTab, msg):
+		return m.moveActiveTabBy(-1)
+	case slices.Contains(common.Hotkeys.RenameTab, msg):
+		m.renameTab()
+	case slices.Contains(common.Hotkeys.ComparePanels, msg):
+		m.toggleComparison(false)
+	case slices.Contains(common.Hotkeys.ComparePanelsByContent, msg):
+		m.toggleComparison(true)
+	case slices.Contains(common.Hotkeys.SelectDifferences, msg):
+		m.selectDifferences()
+	case slices.Contains(common.Hotkeys.SetMark, msg):
+		m.pendingMark = pendingSetMark
+	case slices.Contains(common.Hotkeys.JumpToMark, msg):
+		m.pendingMark = pendingJumpToMark
+	case slices.Contains(common.Hotkeys.HistoryBack, msg):
+		m.getFocusedFilePanel().HistoryBack()
+	case slices.Contains(common.Hotkeys.HistoryForward, msg):
+		m.getFocusedFilePanel().HistoryForward()
 	case slices.Contains(common.Hotkeys.SplitFilePanel, msg):
 		cmd, err := m.splitPanel()
 		if err != nil && !errors.Is(err, filemodel.ErrMaximumPanelCount) {
@@ -99,8 +135,29 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.FocusOnMetaData, msg):
 		m.focusOnMetadata()
 
+	case slices.Contains(common.Hotkeys.FocusOnParentColumn, msg):
+		m.focusOnParentColumn()
+
 	case slices.Contains(common.Hotkeys.PasteItems, msg):
 		return m.getPasteItemCmd()
+	case slices.Contains(common.Hotkeys.PasteSymlink, msg):
+		return m.getPasteLinkCmd(linkSymlink)
+	case slices.Contains(common.Hotkeys.PasteRelativeSymlink, msg):
+		return m.getPasteLinkCmd(linkRelativeSymlink)
+	case slices.Contains(common.Hotkeys.PasteHardlink, msg):
+		return m.getPasteLinkCmd(linkHardlink)
+
+	case slices.Contains(common.Hotkeys.Undo, msg):
+		return m.getUndoCmd()
+
+	case slices.Contains(common.Hotkeys.Redo, msg):
+		return m.getRedoCmd()
+
+	case m.focusPanel == processBarFocus && slices.Contains(common.Hotkeys.CancelProcess, msg):
+		m.processBarModel.CancelFocusedProcess()
+
+	case m.focusPanel == processBarFocus && slices.Contains(common.Hotkeys.PauseProcess, msg):
+		m.processBarModel.TogglePauseFocusedProcess()
 
 	case slices.Contains(common.Hotkeys.FilePanelItemCreate, msg):
 		m.panelCreateNewFile()
@@ -114,10 +171,10 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 		return m.toggleFooterController()
 
 	case slices.Contains(common.Hotkeys.ExtractFile, msg):
-		return m.getExtractFileCmd()
+		m.openExtractModal()
 
 	case slices.Contains(common.Hotkeys.CompressFile, msg):
-		return m.getCompressSelectedFilesCmd()
+		m.openCompressModal()
 
 	case slices.Contains(common.Hotkeys.OpenCommandLine, msg):
 		m.promptModal.Open(true)
@@ -125,6 +182,8 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 		m.promptModal.Open(false)
 	case slices.Contains(common.Hotkeys.OpenZoxide, msg):
 		return m.zoxideModal.Open()
+	case slices.Contains(common.Hotkeys.OpenContentSearch, msg):
+		m.openContentSearch()
 
 	case slices.Contains(common.Hotkeys.OpenHelpMenu, msg):
 		m.helpMenu.Open()
@@ -134,6 +193,11 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 
 	case slices.Contains(common.Hotkeys.ToggleReverseSort, msg):
 		m.getFocusedFilePanel().ToggleReverseSort()
+		m.rememberSort()
+
+	case slices.Contains(common.Hotkeys.ToggleDirectoriesFirst, msg):
+		m.getFocusedFilePanel().ToggleDirectoriesFirst()
+		m.rememberSort()
 
 	case slices.Contains(common.Hotkeys.OpenFileWithEditor, msg):
 		return m.openFileWithEditor()
@@ -141,6 +205,14 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.OpenCurrentDirectoryWithEditor, msg):
 		return m.openDirectoryWithEditor()
 
+	case slices.Contains(common.Hotkeys.BulkRename, msg):
+		return m.getBulkRenameCmd()
+
+	case slices.Contains(common.Hotkeys.PatternRename, msg):
+		m.openPatternRenameModal()
+	case slices.Contains(common.Hotkeys.ChangePermissions, msg):
+		m.openPermissionModal()
+
 	default:
 		return m.normalAndBrowserModeKey(msg)
 	}
@@ -158,11 +230,41 @@ func (m *model) normalAndBrowserModeKey(msg string) tea.Cmd {
 	if m.getFocusedFilePanel().PanelMode == filepanel.SelectMode {
 		return m.filePanelSelectModeKey(msg)
 	}
+	if m.getFocusedFilePanel().PanelMode == filepanel.TreeMode {
+		return m.filePanelTreeModeKey(msg)
+	}
 
 	return m.filePanelNormalModeKey(msg)
 }
 
+// The tree mode expands and collapses the directories instead of entering
+// and leaving them. The other keys work as in the normal mode
+func (m *model) filePanelTreeModeKey(msg string) tea.Cmd {
+	panel := m.getFocusedFilePanel()
+
+	switch {
+	case slices.Contains(common.Hotkeys.Confirm, msg):
+		if panel.InRecursiveResults() || !panel.ToggleExpandFocused() {
+			m.enterPanel()
+			return nil
+		}
+	case slices.Contains(common.Hotkeys.ParentDirectory, msg):
+		if panel.InRecursiveResults() || !panel.CollapseFocused() {
+			m.parentDirectory()
+			return nil
+		}
+	default:
+		return m.filePanelNormalModeKey(msg)
+	}
+	panel.UpdateElementsIfNeeded(true, m.fileModel.DisplayDotFiles)
+	return nil
+}
+
 func (m *model) unfocusedFilePanelKey(msg string) {
+	if m.fileModel.IsParentColumnFocused() {
+		m.parentColumnKey(msg)
+		return
+	}
 	if m.focusPanel != sidebarFocus {
 		return
 	}
@@ -178,6 +280,17 @@ func (m *model) unfocusedFilePanelKey(msg string) {
 	}
 }
 
+// The parent column follows the focused panel, going to the parent directory
+// moves both
+func (m *model) parentColumnKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.Confirm, msg):
+		m.fileModel.SetParentColumnFocused(false)
+	case slices.Contains(common.Hotkeys.ParentDirectory, msg):
+		m.parentDirectory()
+	}
+}
+
 func (m *model) filePanelSelectModeKey(msg string) tea.Cmd {
 	panel := m.getFocusedFilePanel()
 
@@ -242,6 +355,14 @@ func (m *model) typingModalOpenKey(msg string) tea.Cmd {
 }
 
 func (m *model) notifyModelOpenKey(msg string) tea.Cmd {
+	if action, ok := m.notifyModel.GetChoiceAction(msg); ok {
+		m.notifyModel.Close()
+		return m.handleNotifyModelConfirm(action)
+	}
+	if m.notifyModel.HasChoices() && slices.Contains(notify.KeyApplyToAll(), msg) {
+		m.notifyModel.ToggleApplyToAll()
+		return nil
+	}
 	isCancel := slices.Contains(common.Hotkeys.CancelTyping, msg) || slices.Contains(common.Hotkeys.Quit, msg)
 	isConfirm := slices.Contains(common.Hotkeys.ConfirmTyping, msg)
 
@@ -263,7 +384,11 @@ func (m *model) handleNotifyModelCancel(action notify.ConfirmActionType) tea.Cmd
 		m.cancelRename()
 	case notify.QuitAction:
 		m.modelQuitState = notQuitting
-	case notify.DeleteAction, notify.NoAction, notify.PermanentDeleteAction:
+	case notify.ConflictOverwriteAction, notify.ConflictOverwriteIfNewerAction,
+		notify.ConflictSkipAction, notify.ConflictKeepBothAction:
+		m.cancelPendingConflicts()
+	case notify.DeleteAction, notify.NoAction, notify.PermanentDeleteAction,
+		notify.PurgeTrashAction, notify.EmptyTrashAction:
 		// Do nothing
 	default:
 		slog.Error("Unknown type of action", "action", action)
@@ -281,6 +406,13 @@ func (m *model) handleNotifyModelConfirm(action notify.ConfirmActionType) tea.Cm
 		m.confirmRename()
 	case notify.QuitAction:
 		m.modelQuitState = quitConfirmationReceived
+	case notify.ConflictOverwriteAction, notify.ConflictOverwriteIfNewerAction,
+		notify.ConflictSkipAction, notify.ConflictKeepBothAction:
+		return m.resolveConflict(action)
+	case notify.PurgeTrashAction:
+		return m.getPurgeTrashItemsCmd(m.trashBin.GetTargetItems())
+	case notify.EmptyTrashAction:
+		return m.getPurgeTrashItemsCmd(m.trashBin.GetItems())
 	case notify.NoAction:
 		// Ignore
 	default:
@@ -328,6 +460,42 @@ func (m *model) sortOptionsKey(msg string) {
 	}
 }
 
+// Handles key inputs inside the compress format picker
+func (m *model) compressOptionsKey(msg string) tea.Cmd {
+	switch {
+	case slices.Contains(common.Hotkeys.CompressFile, msg),
+		slices.Contains(common.Hotkeys.Quit, msg):
+		m.compressModal.Close()
+	case slices.Contains(common.Hotkeys.Confirm, msg):
+		format := m.compressModal.GetSelectedFormat()
+		m.compressModal.Close()
+		return m.getCompressSelectedFilesCmd(format)
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.compressModal.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.compressModal.ListDown()
+	}
+	return nil
+}
+
+// Handles key inputs inside the extraction target picker
+func (m *model) extractOptionsKey(msg string) tea.Cmd {
+	switch {
+	case slices.Contains(common.Hotkeys.ExtractFile, msg),
+		slices.Contains(common.Hotkeys.Quit, msg):
+		m.extractModal.Close()
+	case slices.Contains(common.Hotkeys.Confirm, msg):
+		target := m.extractModal.GetSelectedTarget()
+		m.extractModal.Close()
+		return m.getExtractFileCmd(target)
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.extractModal.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.extractModal.ListDown()
+	}
+	return nil
+}
+
 func (m *model) renamingKey(msg string) tea.Cmd {
 	switch {
 	case slices.Contains(common.Hotkeys.CancelTyping, msg):
@@ -358,5 +526,7 @@ func (m *model) focusOnSearchbarKey(msg string) {
 		m.cancelSearch()
 	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
 		m.confirmSearch()
+	case slices.Contains(common.Hotkeys.ToggleRecursiveSearch, msg):
+		m.getFocusedFilePanel().ToggleRecursiveSearch()
 	}
 }
@@ -0,0 +1,65 @@
+// Package marks keeps the directories marked by the user under a single
+// letter, like the marks of vim, to jump back to them later. The marks are
+// persisted as JSON so they survive restarts.
+package marks
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+var ErrInvalidName = errors.New("marks are named with a single letter")
+
+type Mark struct {
+	Location string `json:"location"`
+	// Name of the item under the cursor when the mark was set
+	Target string `json:"target,omitempty"`
+}
+
+type Marks struct {
+	filePath string
+	marks    map[string]Mark
+}
+
+// New loads the marks stored at filePath. An empty filePath keeps them in
+// memory only
+func New(filePath string) *Marks {
+	m := &Marks{filePath: filePath, marks: make(map[string]Mark)}
+	if filePath == "" {
+		return m
+	}
+	if err := utils.LoadJSONFile(filePath, &m.marks); err != nil {
+		slog.Error("Error loading marks", "error", err)
+	}
+	return m
+}
+
+// ValidName reports whether name can name a mark, a single ASCII letter.
+// Lowercase and uppercase letters are different marks
+func ValidName(name string) bool {
+	return len(name) == 1 && ('a' <= name[0] && name[0] <= 'z' || 'A' <= name[0] && name[0] <= 'Z')
+}
+
+// Get returns the mark named name, if it is set
+func (m *Marks) Get(name string) (Mark, bool) {
+	mark, ok := m.marks[name]
+	return mark, ok
+}
+
+// Set names mark with name, replacing the mark already named so
+func (m *Marks) Set(name string, mark Mark) error {
+	if !ValidName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	m.marks[name] = mark
+	if m.filePath == "" {
+		return nil
+	}
+	if err := utils.SaveJSONFile(m.filePath, m.marks); err != nil {
+		return fmt.Errorf("error saving marks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package marks
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"a", "z", "A", "Z"} {
+		assert.True(t, ValidName(name), name)
+	}
+	for _, name := range []string{"", "ab", "1", "'", "é", "esc"} {
+		assert.False(t, ValidName(name), name)
+	}
+}
+
+func TestMarks(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "marks.json")
+	m := New(filePath)
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+
+	require.NoError(t, m.Set("a", Mark{Location: "/x", Target: "file"}))
+	require.NoError(t, m.Set("A", Mark{Location: "/y"}))
+	require.NoError(t, m.Set("a", Mark{Location: "/z"}))
+	require.ErrorIs(t, m.Set("1", Mark{Location: "/w"}), ErrInvalidName)
+
+	loaded := New(filePath)
+	mark, ok := loaded.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, Mark{Location: "/z"}, mark, "mark replaced")
+	mark, ok = loaded.Get("A")
+	require.True(t, ok)
+	assert.Equal(t, Mark{Location: "/y"}, mark)
+	_, ok = loaded.Get("1")
+	assert.False(t, ok)
+}
@@ -19,6 +19,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/barasher/go-exiftool"
 
+	"github.com/yorukot/superfile/src/internal/ui/contentsearch"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/metadata"
 	"github.com/yorukot/superfile/src/internal/ui/notify"
@@ -41,8 +42,13 @@ var (
 // Either way type 'model' is not exported, so there is not way main package can
 // be aware of it, and use it directly
 func InitialModel(firstPanelPaths []string, firstUseCheck bool) tea.Model {
+	noPathGiven := len(firstPanelPaths) == 1 && firstPanelPaths[0] == ""
 	toggleDotFile, toggleFooter, zClient := initialConfig(firstPanelPaths)
-	return defaultModelConfig(toggleDotFile, toggleFooter, firstUseCheck, firstPanelPaths, zClient)
+	m := defaultModelConfig(toggleDotFile, toggleFooter, firstUseCheck, firstPanelPaths, zClient)
+	if variable.RestoreSession || (common.Config.RestoreSession && noPathGiven) {
+		m.restoreLastSession()
+	}
+	return m
 }
 
 // Init function to be called by Bubble tea framework, sets windows title,
@@ -54,6 +60,7 @@ func (m *model) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink, // Assuming textinput.Blink is a valid command
 		processCmdToTeaCmd(m.processBarModel.GetListenCmd()),
+		m.startWatcher(),
 	)
 }
 
@@ -62,8 +69,9 @@ func (m *model) Init() tea.Cmd {
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	slog.Debug("model.Update() called", "msgType", reflect.TypeOf(msg))
 
-	var sidebarCmd, inputCmd, updateCmd, panelCmd,
-		metadataCmd, filePreviewCmd, helpMenuCmd, resizeCmd tea.Cmd
+	var sidebarCmd, inputCmd, updateCmd, panelCmd, searchCmd,
+		metadataCmd, filePreviewCmd, helpMenuCmd, resizeCmd, gitStatusCmd, dirSizeCmd, comparisonCmd,
+		frecencyCmd tea.Cmd
 
 	// These are above the key message handing to prevent issues with firstKeyInput
 	// if someone presses `/` to focus to searchBar, searchBar will otherwise
@@ -86,6 +94,9 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case zoxideui.UpdateMsg:
 		slog.Debug("Got ModelUpdate message", "id", msg.GetReqID())
 		updateCmd = msg.Apply(&m.zoxideModal)
+	case contentsearch.UpdateMsg:
+		slog.Debug("Got ModelUpdate message", "id", msg.GetReqID())
+		updateCmd = msg.Apply(&m.contentSearchModal)
 
 	// Its a pain to interconvert commands like processBar
 	case preview.UpdateMsg:
@@ -101,14 +112,19 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// This is needed for blink, etc to work
 	panelCmd = m.updateComponentState(msg)
+	searchCmd = m.getRecursiveSearchCmd()
 
 	m.updateModelStateAfterMsg()
 	filePreviewCmd = m.fileModel.GetFilePreviewCmd(false)
 
 	metadataCmd = m.getMetadataCmd()
+	gitStatusCmd = m.getGitStatusCmd()
+	dirSizeCmd = m.getDirSizeCmd()
+	comparisonCmd = m.getComparisonCmd()
+	frecencyCmd = m.getFrecencySaveCmd()
 
-	return m, tea.Batch(sidebarCmd, helpMenuCmd, inputCmd, updateCmd,
-		panelCmd, metadataCmd, filePreviewCmd, resizeCmd)
+	return m, tea.Batch(sidebarCmd, helpMenuCmd, inputCmd, updateCmd, panelCmd, searchCmd, metadataCmd,
+		filePreviewCmd, resizeCmd, gitStatusCmd, dirSizeCmd, comparisonCmd, frecencyCmd)
 }
 
 func (m *model) handleMouseMsg(msg tea.MouseMsg) {
@@ -122,6 +138,8 @@ func (m *model) handleMouseMsg(msg tea.MouseMsg) {
 
 func (m *model) updateModelStateAfterMsg() {
 	m.sidebarModel.UpdateDirectories()
+	m.syncWatcher()
+	m.applyDirSorts()
 	m.fileModel.UpdateFilePanelsIfNeeded(false)
 	// TODO: Move to utility
 	if m.focusPanel != metadataFocus {
@@ -169,6 +187,13 @@ func (m *model) getMetadataCmd() tea.Cmd {
 		m.fileMetaData.SetInfoMsg(icon.InOperation + icon.Space + "Loading metadata...")
 	}
 
+	if panel := m.getFocusedFilePanel(); panel.InArchive() {
+		// Already known from the archive index, no need of an async fetch
+		m.fileMetaData.SetMetadata(metadata.GetArchiveEntryMetadata(selectedItem.Location,
+			panel.Archive().ArchivePath(), selectedItem.Info), metadataFocused)
+		return nil
+	}
+
 	reqCnt := m.nextIoReqCnt()
 	// If there are too many metadata fetches, we need to have a cache with path as a key
 	// and timeout based eviction
@@ -206,13 +231,17 @@ func (m *model) setHeightValues() {
 	// TODO : Calculate the value , instead of manually hard coding it.
 
 	// Main panel height = Total terminal height- 2(file panel border) - footer height
-	m.mainPanelHeight = m.fullHeight - common.BorderPadding - utils.FullFooterHeight(m.footerHeight, m.toggleFooter)
+	m.mainPanelHeight = m.fullHeight - common.BorderPadding - utils.FullFooterHeight(m.footerHeight, m.toggleFooter) -
+		m.tabStripHeight()
 }
 
 func (m *model) updateComponentDimensions() tea.Cmd {
 	m.setHelpMenuSize()
 	m.setPromptModelSize()
 	m.setZoxideModelSize()
+	m.setTrashBinSize()
+	m.setBulkRenameSize()
+	m.setContentSearchSize()
 	m.setFooterComponentSize()
 
 	// File preview panel requires explicit height update, unlike sidebar/file panels
@@ -259,6 +288,22 @@ func (m *model) setZoxideModelSize() {
 	m.zoxideModal.SetWidth(m.fullWidth / 2) //nolint:mnd // modal uses half width for layout
 }
 
+func (m *model) setTrashBinSize() {
+	m.trashBin.SetMaxHeight(m.fullHeight / 2) //nolint:mnd // modal uses half height for layout
+	m.trashBin.SetWidth(m.fullWidth / 2)      //nolint:mnd // modal uses half width for layout
+}
+
+func (m *model) setBulkRenameSize() {
+	m.bulkRenameModal.SetMaxHeight(m.fullHeight / 2) //nolint:mnd // modal uses half height for layout
+	m.bulkRenameModal.SetWidth(m.fullWidth / 2)      //nolint:mnd // modal uses half width for layout
+}
+
+func (m *model) setContentSearchSize() {
+	m.contentSearchModal.SetMaxHeight(m.fullHeight / 2) //nolint:mnd // modal uses half height for layout
+	// Wider than the other modals, for the snippets of the matches
+	m.contentSearchModal.SetWidth(m.fullWidth * 3 / 4) //nolint:mnd // modal uses three quarters of the width
+}
+
 func (m *model) setFooterComponentSize() {
 	var width, clipBoardwidth, height int
 	height = m.footerHeight + common.BorderPadding
@@ -305,11 +350,22 @@ func (m *model) handleKeyInput(msg tea.KeyPressMsg) tea.Cmd {
 	case m.zoxideModal.IsOpen():
 		// Ignore keypress. It will be handled in Update call via
 		// updateFilePanelState
+	case m.contentSearchModal.IsOpen():
+		// Ignore keypress. It will be handled in Update call via
+		// updateFilePanelState
 
 	// Handles all warn models except the warn model for confirming to quit
 	case m.notifyModel.IsOpen():
 		cmd = m.notifyModelOpenKey(msg.String())
-
+	case m.trashBin.IsOpen():
+		cmd = m.trashBinKey(msg.String())
+	case m.bulkRenameModal.IsOpen():
+		cmd = m.bulkRenameKey(msg.String())
+	case m.permissionModal.IsOpen():
+		cmd = m.permissionKey(msg.String())
+
+	case m.fileModel.IsRenamingTab():
+		m.tabRenamingKey(msg.String())
 	// If renaming a object
 	case m.fileModel.Renaming:
 		cmd = m.renamingKey(msg.String())
@@ -323,9 +379,15 @@ func (m *model) handleKeyInput(msg tea.KeyPressMsg) tea.Cmd {
 		m.sidebarModel.HandleSearchBarKey(msg.String())
 	case m.sortModal.IsOpen():
 		m.sortOptionsKey(msg.String())
+	case m.compressModal.IsOpen():
+		cmd = m.compressOptionsKey(msg.String())
+	case m.extractModal.IsOpen():
+		cmd = m.extractOptionsKey(msg.String())
 	// If help menu is open
 	case m.helpMenu.IsOpen():
 		m.helpMenu.HandleKey(msg.String())
+	case m.pendingMark != noPendingMark:
+		m.markKey(msg.String())
 
 	case slices.Contains(common.Hotkeys.Quit, msg.String()):
 		m.modelQuitState = quitInitiated
@@ -366,6 +428,8 @@ func (m *model) updateComponentState(msg tea.Msg) tea.Cmd {
 	switch {
 	case m.firstTextInput:
 		m.firstTextInput = false
+	case m.fileModel.IsRenamingTab():
+		cmd = m.fileModel.UpdateTabRename(msg)
 	case m.fileModel.Renaming:
 		focusPanel.Rename, cmd = focusPanel.Rename.Update(msg)
 	case focusPanel.SearchBar.Focused():
@@ -380,6 +444,13 @@ func (m *model) updateComponentState(msg tea.Msg) tea.Cmd {
 	case m.zoxideModal.IsOpen():
 		action, cmd = m.zoxideModal.HandleUpdate(msg)
 		cmd = tea.Batch(cmd, m.applyZoxideModalAction(action))
+	case m.bulkRenameModal.IsOpen():
+		cmd = m.bulkRenameModal.HandleUpdate(msg)
+	case m.permissionModal.IsOpen():
+		cmd = m.permissionModal.HandleUpdate(msg)
+	case m.contentSearchModal.IsOpen():
+		action, cmd = m.contentSearchModal.HandleUpdate(msg)
+		cmd = tea.Batch(cmd, m.applyContentSearchModalAction(action))
 	}
 	return cmd
 }
@@ -417,6 +488,22 @@ func (m *model) logAndExecuteAction(action common.ModelAction) (string, tea.Cmd,
 	case common.OpenPanelAction:
 		cmd, err := m.createNewFilePanelRelativeToCurrent(action.Location)
 		return "New panel opened", cmd, err
+	case common.ShowFileAction:
+		return "", nil, m.showFileInFocusedPanel(action.Path)
+	case common.EditFileAction:
+		return "", m.openFileWithEditorAtLine(action.Path, action.Line), nil
+	case common.SaveSessionAction:
+		return "Session saved as " + action.Name, nil, m.saveNamedSession(action.Name)
+	case common.LoadSessionAction:
+		cmd, err := m.loadNamedSession(action.Name)
+		return "Session " + action.Name + " loaded", cmd, err
+	case common.OpenTrashBinAction:
+		cmd, err := m.openTrashBin()
+		if err == nil {
+			// The trash bin would be hidden behind the prompt
+			m.promptModal.Close()
+		}
+		return "", cmd, err
 	default:
 		return "", nil, errors.New("unhandled action type")
 	}
@@ -428,6 +515,15 @@ func (m *model) applyZoxideModalAction(action common.ModelAction) tea.Cmd {
 	return cmd
 }
 
+// Apply the Action for the content search modal
+func (m *model) applyContentSearchModalAction(action common.ModelAction) tea.Cmd {
+	_, cmd, err := m.logAndExecuteAction(action)
+	if err != nil {
+		slog.Error("Error while applying content search action", "action", action, "error", err)
+	}
+	return cmd
+}
+
 // TODO : Move them around to appropriate places
 func (m *model) applyShellCommandAction(shellCommand string) {
 	focusPanelDir := m.getFocusedFilePanel().Location
@@ -435,6 +531,8 @@ func (m *model) applyShellCommandAction(shellCommand string) {
 	retCode, output, err := utils.ExecuteCommandInShell(common.DefaultCommandTimeout, focusPanelDir, shellCommand)
 
 	m.promptModal.HandleShellCommandResults(retCode, output)
+	// The command may have been a git one
+	m.invalidateGitStatus(nil)
 
 	if err != nil {
 		slog.Error("Command execution failed", "retCode", retCode,
@@ -456,15 +554,20 @@ func (m *model) createNewFilePanelRelativeToCurrent(path string) (tea.Cmd, error
 func (m *model) updateCurrentFilePanelDir(path string) error {
 	panel := m.getFocusedFilePanel()
 	err := panel.UpdateCurrentFilePanelDir(path)
-	if err == nil {
+	// Virtual paths inside archives can't be visited outside of superfile
+	if err == nil && !panel.InArchive() {
 		// Track the directory change with zoxide
 		m.trackDirectoryWithZoxide(panel.Location)
 	}
 	return err
 }
 
-// trackDirectoryWithZoxide adds the directory to zoxide database if zoxide is available and enabled
+// trackDirectoryWithZoxide adds the directory to the frecency database, and to
+// zoxide database if zoxide is available and enabled
 func (m *model) trackDirectoryWithZoxide(path string) {
+	if m.frecencyDB != nil {
+		m.frecencyDB.Add(path)
+	}
 	if !common.Config.ZoxideSupport || m.zClient == nil {
 		return
 	}
@@ -475,6 +578,24 @@ func (m *model) trackDirectoryWithZoxide(path string) {
 	}
 }
 
+// getFrecencySaveCmd saves the frecency database in the background, once
+// directories were visited since the last save
+func (m *model) getFrecencySaveCmd() tea.Cmd {
+	if m.frecencyDB == nil || !m.frecencyDB.Unsaved() {
+		return nil
+	}
+	return func() tea.Msg {
+		m.saveFrecencyDB()
+		return nil
+	}
+}
+
+func (m *model) saveFrecencyDB() {
+	if err := m.frecencyDB.Save(); err != nil {
+		slog.Error("Error saving the frecency database", "error", err)
+	}
+}
+
 // Check if there's any processes running in background
 
 // Triggers a warn for confirm quiting
@@ -552,6 +673,13 @@ func (m *model) updateRenderForOverlay(finalRender string) string {
 		return stringfunction.PlaceOverlay(overlayX, overlayY, zoxideModal, finalRender)
 	}
 
+	if m.contentSearchModal.IsOpen() {
+		contentSearch := m.contentSearchModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.contentSearchModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.contentSearchModal.GetMaxHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, contentSearch, finalRender)
+	}
+
 	if m.sortModal.IsOpen() {
 		sortOptions := m.sortModal.Render()
 		overlayX := m.fullWidth/common.CenterDivisor - m.sortModal.Width/common.CenterDivisor
@@ -559,6 +687,20 @@ func (m *model) updateRenderForOverlay(finalRender string) string {
 		return stringfunction.PlaceOverlay(overlayX, overlayY, sortOptions, finalRender)
 	}
 
+	if m.compressModal.IsOpen() {
+		compressOptions := m.compressModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.compressModal.Width/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.compressModal.Height/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, compressOptions, finalRender)
+	}
+
+	if m.extractModal.IsOpen() {
+		extractOptions := m.extractModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.extractModal.Width/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.extractModal.Height/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, extractOptions, finalRender)
+	}
+
 	if m.firstUse {
 		introduceModal := m.introduceModalRender()
 		overlayX := m.fullWidth/common.CenterDivisor - m.helpMenu.GetWidth()/common.CenterDivisor
@@ -580,6 +722,27 @@ func (m *model) updateRenderForOverlay(finalRender string) string {
 		return stringfunction.PlaceOverlay(overlayX, overlayY, notifyModal, finalRender)
 	}
 
+	if m.trashBin.IsOpen() {
+		trashBin := m.trashBin.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.trashBin.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.trashBin.GetMaxHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, trashBin, finalRender)
+	}
+
+	if m.permissionModal.IsOpen() {
+		permissionModal := m.permissionModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.permissionModal.Width/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.permissionModal.Height/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, permissionModal, finalRender)
+	}
+
+	if m.bulkRenameModal.IsOpen() {
+		bulkRename := m.bulkRenameModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.bulkRenameModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.bulkRenameModal.GetMaxHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, bulkRename, finalRender)
+	}
+
 	return finalRender
 }
 
@@ -587,6 +750,9 @@ func (m *model) mainComponentsRender() string {
 	sidebar := m.sidebarRender()
 	fileModel := m.fileModel.Render()
 	mainPanel := lipgloss.JoinHorizontal(0, sidebar, fileModel)
+	if tabStrip := m.fileModel.RenderTabStrip(m.fullWidth); tabStrip != "" {
+		mainPanel = lipgloss.JoinVertical(0, tabStrip, mainPanel)
+	}
 
 	if !m.toggleFooter {
 		return mainPanel
@@ -607,6 +773,11 @@ func (m *model) quitSuperfile(cdOnQuit bool) {
 		_ = et.Close()
 	}
 	m.fileModel.FilePreview.CleanUp()
+	m.watcher.Close()
+	m.saveLastSession()
+	if m.frecencyDB != nil {
+		m.saveFrecencyDB()
+	}
 
 	// cd on quit
 	currentDir := m.getFocusedFilePanel().Location
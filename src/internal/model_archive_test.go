@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestArchiveBrowsing(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	zipPath := filepath.Join(dir1, "test.zip")
+	utils.SetupDirectories(t, dir1, dir2)
+
+	out, err := os.Create(zipPath)
+	require.NoError(t, err)
+	zw := zip.NewWriter(out)
+	for name, content := range map[string]string{"a.txt": "a", "dir/inner.txt": "inner"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, out.Close())
+
+	p := NewTestTeaProgWithEventLoop(t, defaultTestModel(dir1))
+	require.Equal(t, "test.zip", p.getModel().getFocusedFilePanel().GetFocusedItem().Name)
+	p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+	require.True(t, p.getModel().getFocusedFilePanel().InArchive())
+	assert.Equal(t, zipPath, p.getModel().getFocusedFilePanel().Location)
+
+	assert.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().GetFocusedItem().Name == "dir"
+	}, DefaultTestTimeout, DefaultTestTick, "Entries of the archive should be listed")
+
+	// Nothing can be changed inside the archive
+	p.SendKeyDirectly(common.Hotkeys.DeleteItems[0])
+	assert.False(t, p.getModel().notifyModel.IsOpen())
+	p.SendKeyDirectly(common.Hotkeys.CutItems[0])
+	assert.Equal(t, 0, p.getModel().clipboard.Len())
+
+	p.SendKeyDirectly(common.Hotkeys.CopyItems[0])
+	assert.Equal(t, filepath.Join(zipPath, "dir"), p.getModel().clipboard.GetFirstItem())
+
+	require.NoError(t, p.getModel().updateCurrentFilePanelDir(dir2))
+	assert.False(t, p.getModel().getFocusedFilePanel().InArchive())
+	p.SendKey(common.Hotkeys.PasteItems[0])
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Lstat(filepath.Join(dir2, "dir", "inner.txt"))
+		return err == nil
+	}, DefaultTestTimeout, DefaultTestTick)
+	assertFileContent(t, filepath.Join(dir2, "dir", "inner.txt"), "inner")
+	assert.FileExists(t, zipPath, "the archive is left as is")
+}
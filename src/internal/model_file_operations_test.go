@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 	variable "github.com/yorukot/superfile/src/config"
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
 )
 
 // TODO : Add test for model initialized with multiple directories
@@ -56,6 +58,7 @@ func TestCopy(t *testing.T) {
 		assert.Equal(t, file1, p.getModel().clipboard.GetFirstItem())
 
 		p.SendKey(common.Hotkeys.PasteItems[0])
+		answerPasteConflict(t, p, common.Hotkeys.ConfirmTyping[0])
 		assert.Eventually(t, func() bool {
 			_, err := os.Lstat(filepath.Join(dir2, "file1(1).txt"))
 			return err == nil
@@ -325,3 +328,221 @@ func TestFileDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestUndoRedo(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	file1 := filepath.Join(dir1, "file1.txt")
+	movedFile1 := filepath.Join(dir2, "file1.txt")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, file1)
+
+	m := defaultTestModel(dir1)
+	p := NewTestTeaProgWithEventLoop(t, m)
+
+	t.Run("Undo and redo a cut paste", func(t *testing.T) {
+		p.SendKeyDirectly(common.Hotkeys.CutItems[0])
+		p.getModel().updateCurrentFilePanelDir(dir2)
+		p.SendKey(common.Hotkeys.PasteItems[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.FileExists(t, movedFile1)
+		assert.NoFileExists(t, file1)
+
+		p.SendKey(common.Hotkeys.Undo[0])
+		require.Eventually(t, func() bool {
+			return m.journal.RedoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.FileExists(t, file1)
+		assert.NoFileExists(t, movedFile1)
+
+		p.SendKey(common.Hotkeys.Redo[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.FileExists(t, movedFile1)
+		assert.NoFileExists(t, file1)
+	})
+
+	t.Run("Undo refuses to overwrite", func(t *testing.T) {
+		utils.SetupFiles(t, file1)
+		p.SendKey(common.Hotkeys.Undo[0])
+		require.Eventually(t, func() bool {
+			for _, process := range m.processBarModel.GetProcessesSlice() {
+				if process.Operation == processbar.OpUndo && process.State == processbar.Failed {
+					return true
+				}
+			}
+			return false
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.Equal(t, 1, m.journal.UndoCount())
+		assert.FileExists(t, movedFile1)
+		require.NoError(t, os.Remove(file1))
+	})
+
+	t.Run("Undo a file creation", func(t *testing.T) {
+		p.SendKey(common.Hotkeys.FilePanelItemCreate[0])
+		p.SendKey("new.txt")
+		p.SendKey(common.Hotkeys.ConfirmTyping[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 2
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.FileExists(t, filepath.Join(dir2, "new.txt"))
+
+		p.SendKey(common.Hotkeys.Undo[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.NoFileExists(t, filepath.Join(dir2, "new.txt"))
+		assert.FileExists(t, movedFile1)
+	})
+}
+
+func TestPasteConflict(t *testing.T) {
+	testdata := []struct {
+		name string
+		// Modification time of the existing a.txt and b.txt, relative to the pasted ones
+		dstAge    [2]time.Duration
+		answers   []string
+		expectedA string
+		expectedB string
+		keptBoth  []string
+		summary   string
+	}{
+		{
+			name:      "Overwrite one and skip the other",
+			answers:   []string{common.Hotkeys.ConflictOverwrite[0], common.Hotkeys.ConflictSkip[0]},
+			expectedA: "new",
+			expectedB: "old",
+			summary:   "1 overwritten, 1 skipped",
+		},
+		{
+			name:      "Keep both for all remaining",
+			answers:   []string{notify.KeyApplyToAll()[0], common.Hotkeys.ConflictKeepBoth[0]},
+			expectedA: "old",
+			expectedB: "old",
+			keptBoth:  []string{"a(1).txt", "b(1).txt"},
+			summary:   "2 kept both",
+		},
+		{
+			name:      "Overwrite if newer for all remaining",
+			dstAge:    [2]time.Duration{time.Hour, -time.Hour},
+			answers:   []string{notify.KeyApplyToAll()[0], common.Hotkeys.ConflictOverwriteIfNewer[0]},
+			expectedA: "old",
+			expectedB: "new",
+			summary:   "1 overwritten, 1 skipped",
+		},
+	}
+
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			curTestDir := t.TempDir()
+			srcDir := filepath.Join(curTestDir, "src")
+			dstDir := filepath.Join(curTestDir, "dst")
+			srcFiles := []string{filepath.Join(srcDir, "a.txt"), filepath.Join(srcDir, "b.txt")}
+			dstFiles := []string{filepath.Join(dstDir, "a.txt"), filepath.Join(dstDir, "b.txt")}
+			utils.SetupDirectories(t, srcDir, dstDir)
+			utils.SetupFilesWithData(t, []byte("new"), srcFiles...)
+			utils.SetupFilesWithData(t, []byte("old"), dstFiles...)
+			for i, dstFile := range dstFiles {
+				mtime := time.Now().Add(tt.dstAge[i])
+				require.NoError(t, os.Chtimes(dstFile, mtime, mtime))
+			}
+
+			m := defaultTestModel(dstDir)
+			m.clipboard.Reset(false)
+			m.clipboard.SetItems(srcFiles)
+			p := NewTestTeaProgWithEventLoop(t, m)
+
+			p.SendKey(common.Hotkeys.PasteItems[0])
+			for _, answer := range tt.answers {
+				answerPasteConflict(t, p, answer)
+			}
+
+			var process processbar.Process
+			require.Eventually(t, func() bool {
+				for _, process = range m.processBarModel.GetProcessesSlice() {
+					if process.State == processbar.Successful {
+						return true
+					}
+				}
+				return false
+			}, DefaultTestTimeout, DefaultTestTick)
+			assert.Equal(t, tt.summary, process.Summary)
+			assertFileContent(t, dstFiles[0], tt.expectedA)
+			assertFileContent(t, dstFiles[1], tt.expectedB)
+			for _, name := range tt.keptBoth {
+				assertFileContent(t, filepath.Join(dstDir, name), "new")
+			}
+		})
+	}
+
+	t.Run("Cancel the paste", func(t *testing.T) {
+		curTestDir := t.TempDir()
+		srcFile := filepath.Join(curTestDir, "src", "a.txt")
+		dstFile := filepath.Join(curTestDir, "dst", "a.txt")
+		utils.SetupDirectories(t, filepath.Dir(srcFile), filepath.Dir(dstFile))
+		utils.SetupFilesWithData(t, []byte("new"), srcFile)
+		utils.SetupFilesWithData(t, []byte("old"), dstFile)
+
+		m := defaultTestModel(filepath.Dir(dstFile))
+		m.clipboard.Reset(false)
+		m.clipboard.SetItems([]string{srcFile})
+		p := NewTestTeaProgWithEventLoop(t, m)
+
+		p.SendKey(common.Hotkeys.PasteItems[0])
+		answerPasteConflict(t, p, common.Hotkeys.Quit[0])
+		require.Eventually(t, func() bool {
+			return !m.notifyModel.IsOpen() && m.conflictPrompt == nil
+		}, DefaultTestTimeout, DefaultTestTick)
+		assert.Empty(t, m.processBarModel.GetProcessesSlice())
+		assertFileContent(t, dstFile, "old")
+	})
+
+	t.Run("Undo an overwrite", func(t *testing.T) {
+		if runtime.GOOS != utils.OsLinux {
+			t.Skip("Only the freedesktop trash can be redirected to a test directory")
+		}
+		curTestDir := t.TempDir()
+		t.Setenv("XDG_DATA_HOME", filepath.Join(curTestDir, "data"))
+		srcFile := filepath.Join(curTestDir, "src", "a.txt")
+		dstFile := filepath.Join(curTestDir, "dst", "a.txt")
+		utils.SetupDirectories(t, filepath.Dir(srcFile), filepath.Dir(dstFile))
+		utils.SetupFilesWithData(t, []byte("new"), srcFile)
+		utils.SetupFilesWithData(t, []byte("old"), dstFile)
+
+		m := defaultTestModel(filepath.Dir(dstFile))
+		m.hasTrash = true
+		m.clipboard.Reset(false)
+		m.clipboard.SetItems([]string{srcFile})
+		p := NewTestTeaProgWithEventLoop(t, m)
+
+		p.SendKey(common.Hotkeys.PasteItems[0])
+		answerPasteConflict(t, p, common.Hotkeys.ConflictOverwrite[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assertFileContent(t, dstFile, "new")
+
+		p.SendKey(common.Hotkeys.Undo[0])
+		require.Eventually(t, func() bool {
+			return m.journal.RedoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assertFileContent(t, dstFile, "old")
+
+		p.SendKey(common.Hotkeys.Redo[0])
+		require.Eventually(t, func() bool {
+			return m.journal.UndoCount() == 1
+		}, DefaultTestTimeout, DefaultTestTick)
+		assertFileContent(t, dstFile, "new")
+	})
+}
+
+func assertFileContent(t *testing.T, path string, expected string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+}
@@ -89,6 +89,13 @@ func TestLayout(t *testing.T) {
 		testWithConfig(t, cfg, baseTestDir)
 	})
 
+	t.Run("miller-columns", func(t *testing.T) {
+		cfg := common.Config
+		cfg.SidebarWidth = sWDef
+		cfg.MillerColumns = true
+		testWithConfig(t, cfg, baseTestDir)
+	})
+
 	t.Run("sidebar-no-sections", func(t *testing.T) {
 		cfg := common.Config
 		cfg.SidebarWidth = sWDef
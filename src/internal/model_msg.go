@@ -2,13 +2,20 @@ package internal
 
 import (
 	"log/slog"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/yorukot/superfile/src/internal/dircompare"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/metadata"
 	"github.com/yorukot/superfile/src/internal/ui/notify"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 	"github.com/yorukot/superfile/src/internal/ui/spferror"
+	"github.com/yorukot/superfile/src/internal/watcher"
 )
 
 type ModelUpdateMessage interface {
@@ -65,6 +72,44 @@ func (msg CreateOperationMsg) ApplyToModel(m *model) tea.Cmd {
 	return nil
 }
 
+type LinkOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewLinkOperationMsg(state processbar.ProcessState, reqID int) LinkOperationMsg {
+	return LinkOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg LinkOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	return nil
+}
+
+type PermissionOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewPermissionOperationMsg(state processbar.ProcessState, reqID int) PermissionOperationMsg {
+	return PermissionOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg PermissionOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	return nil
+}
+
 type DeleteOperationMsg struct {
 	BaseMessage
 
@@ -138,6 +183,106 @@ func (msg ExtractOperationMsg) ApplyToModel(_ *model) tea.Cmd {
 	return nil
 }
 
+// BulkRenameEditedMsg is sent once the editor with the names to rename exits
+type BulkRenameEditedMsg struct {
+	BaseMessage
+
+	dir       string
+	oldNames  []string
+	namesFile string
+	err       error
+}
+
+func NewBulkRenameEditedMsg(dir string, oldNames []string, namesFile string, err error,
+	reqID int) BulkRenameEditedMsg {
+	return BulkRenameEditedMsg{
+		dir:       dir,
+		oldNames:  oldNames,
+		namesFile: namesFile,
+		err:       err,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg BulkRenameEditedMsg) ApplyToModel(m *model) tea.Cmd {
+	m.handleBulkRenameEdited(msg.dir, msg.oldNames, msg.namesFile, msg.err)
+	return nil
+}
+
+type BulkRenameOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewBulkRenameOperationMsg(state processbar.ProcessState, reqID int) BulkRenameOperationMsg {
+	return BulkRenameOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg BulkRenameOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	// The selected paths no longer exist
+	m.getFocusedFilePanel().ResetSelected()
+	return nil
+}
+
+// RecursiveSearchMsg carries a batch of results of a recursive search of a panel
+type RecursiveSearchMsg struct {
+	BaseMessage
+
+	batch   filepanel.RecursiveSearchBatch
+	results <-chan filepanel.RecursiveSearchBatch
+}
+
+func NewRecursiveSearchMsg(batch filepanel.RecursiveSearchBatch, results <-chan filepanel.RecursiveSearchBatch,
+	reqID int) RecursiveSearchMsg {
+	return RecursiveSearchMsg{
+		batch:   batch,
+		results: results,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg RecursiveSearchMsg) ApplyToModel(m *model) tea.Cmd {
+	for i := range m.fileModel.FilePanels {
+		if m.fileModel.FilePanels[i].ApplyRecursiveSearchBatch(msg.batch) {
+			if msg.batch.Done {
+				return nil
+			}
+			return listenRecursiveSearch(msg.results, msg.reqID)
+		}
+	}
+	// The search was stopped, its walk stops on its own
+	return nil
+}
+
+type JournalOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewJournalOperationMsg(state processbar.ProcessState, reqID int) JournalOperationMsg {
+	return JournalOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg JournalOperationMsg) ApplyToModel(_ *model) tea.Cmd {
+	return nil
+}
+
 type MetadataMsg struct {
 	BaseMessage
 
@@ -196,6 +341,73 @@ func (msg NotifyModalUpdateMsg) ApplyToModel(m *model) tea.Cmd {
 	return nil
 }
 
+type ConflictMsg struct {
+	BaseMessage
+
+	prompt pendingConflicts
+}
+
+func NewConflictMsg(prompt pendingConflicts, reqID int) ConflictMsg {
+	return ConflictMsg{
+		prompt: prompt,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg ConflictMsg) ApplyToModel(m *model) tea.Cmd {
+	m.openConflictPrompt(msg.prompt)
+	return nil
+}
+
+type TrashListMsg struct {
+	BaseMessage
+
+	items []trash.Item
+	err   error
+}
+
+func NewTrashListMsg(items []trash.Item, err error, reqID int) TrashListMsg {
+	return TrashListMsg{
+		items: items,
+		err:   err,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg TrashListMsg) ApplyToModel(m *model) tea.Cmd {
+	// The trash bin might have been closed while listing
+	if m.trashBin.IsOpen() {
+		m.trashBin.SetItems(msg.items, msg.err)
+	}
+	return nil
+}
+
+type TrashOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewTrashOperationMsg(state processbar.ProcessState, reqID int) TrashOperationMsg {
+	return TrashOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg TrashOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	if m.trashBin.IsOpen() {
+		return m.getTrashListCmd()
+	}
+	return nil
+}
+
 type SpfErrorModalUpdateMsg struct {
 	BaseMessage
 
@@ -215,3 +427,143 @@ func (msg SpfErrorModalUpdateMsg) ApplyToModel(m *model) tea.Cmd {
 	m.spfError = msg.m
 	return nil
 }
+
+// GitStatusMsg carries the status read of the work tree of a directory
+type GitStatusMsg struct {
+	BaseMessage
+
+	dir       string
+	repo      *gitstatus.Repo
+	startedAt time.Time
+}
+
+func NewGitStatusMsg(dir string, repo *gitstatus.Repo, startedAt time.Time, reqID int) GitStatusMsg {
+	return GitStatusMsg{
+		dir:       dir,
+		repo:      repo,
+		startedAt: startedAt,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg GitStatusMsg) ApplyToModel(m *model) tea.Cmd {
+	m.gitStatus.Store(msg.dir, msg.repo, msg.startedAt)
+	return nil
+}
+
+// DirSizeMsg carries the size computed of a directory
+type DirSizeMsg struct {
+	BaseMessage
+
+	job  *dirsize.Job
+	size int64
+	err  error
+}
+
+func NewDirSizeMsg(job *dirsize.Job, size int64, err error, reqID int) DirSizeMsg {
+	return DirSizeMsg{
+		job:  job,
+		size: size,
+		err:  err,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg DirSizeMsg) ApplyToModel(m *model) tea.Cmd {
+	m.dirSizes.Finish(msg.job, msg.size, msg.err)
+	if msg.err == nil {
+		for _, panel := range m.fileModel.Panels() {
+			panel.DirSizeComputed(msg.job.Path)
+		}
+	}
+	return nil
+}
+
+// ComparisonMsg carries the result of the comparison of two directories
+type ComparisonMsg struct {
+	BaseMessage
+
+	comparison *dircompare.Comparison
+	result     *dircompare.Result
+	err        error
+}
+
+func NewComparisonMsg(comparison *dircompare.Comparison, result *dircompare.Result, err error,
+	reqID int) ComparisonMsg {
+	return ComparisonMsg{
+		comparison: comparison,
+		result:     result,
+		err:        err,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg ComparisonMsg) ApplyToModel(m *model) tea.Cmd {
+	// Stopped, or restarted since
+	if msg.comparison != m.comparison {
+		return nil
+	}
+	if msg.err != nil {
+		slog.Error("Error while comparing the directories", "left", msg.comparison.Left,
+			"right", msg.comparison.Right, "error", msg.err)
+		m.comparison = nil
+		return nil
+	}
+	m.comparison.SetResult(msg.result)
+	return nil
+}
+
+// ComparisonRestartMsg tells that an outdated comparison waited enough to be
+// walked again
+type ComparisonRestartMsg struct {
+	BaseMessage
+
+	comparison *dircompare.Comparison
+}
+
+func NewComparisonRestartMsg(comparison *dircompare.Comparison, reqID int) ComparisonRestartMsg {
+	return ComparisonRestartMsg{
+		comparison: comparison,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg ComparisonRestartMsg) ApplyToModel(m *model) tea.Cmd {
+	// Stopped, or started again since
+	if msg.comparison != m.comparison {
+		return nil
+	}
+	m.restartComparison()
+	return nil
+}
+
+// FilesystemChangedMsg carries the changes reported by the filesystem watcher
+type FilesystemChangedMsg struct {
+	BaseMessage
+
+	changes watcher.Changes
+	source  <-chan watcher.Changes
+}
+
+func NewFilesystemChangedMsg(changes watcher.Changes, source <-chan watcher.Changes,
+	reqID int) FilesystemChangedMsg {
+	return FilesystemChangedMsg{
+		changes: changes,
+		source:  source,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg FilesystemChangedMsg) ApplyToModel(m *model) tea.Cmd {
+	return tea.Batch(m.applyFilesystemChanges(msg.changes), listenWatcher(msg.source, msg.reqID))
+}
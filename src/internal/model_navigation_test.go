@@ -291,3 +291,56 @@ func TestCursorRemembersParentPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestMillerColumns(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "file1.txt"), filepath.Join(dir2, "file2.txt"),
+		filepath.Join(curTestDir, "file3.txt"))
+
+	origConfig := common.Config
+	t.Cleanup(func() { common.SetConfig(origConfig) })
+	cfg := common.Config
+	cfg.MillerColumns = true
+	common.SetConfig(cfg)
+
+	m := defaultTestModel(dir1)
+	panel := m.getFocusedFilePanel()
+	parent := m.fileModel.GetParentColumn()
+	require.NotNil(t, parent)
+	assert.Equal(t, curTestDir, parent.Location)
+	assert.Equal(t, dir1, parent.GetFocusedItem().Location, "the cursor is on the panel's directory")
+	assert.Len(t, m.fileModel.Panels(), 2)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.FocusOnParentColumn[0]))
+	require.True(t, m.fileModel.IsParentColumnFocused())
+	assert.False(t, panel.IsFocused)
+
+	// Moving in the parent column switches the panel
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ListDown[0]))
+	assert.Equal(t, dir2, panel.Location)
+	assert.Equal(t, "file2.txt", panel.GetFocusedItem().Name)
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ListDown[0]))
+	assert.Equal(t, dir2, panel.Location, "files are skipped")
+	assert.Equal(t, 2, parent.GetCursor())
+
+	// Going to the parent moves both columns
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	assert.Equal(t, curTestDir, panel.Location)
+	assert.Equal(t, filepath.Dir(curTestDir), parent.Location)
+	assert.Equal(t, curTestDir, parent.GetFocusedItem().Location)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.Confirm[0]))
+	assert.False(t, m.fileModel.IsParentColumnFocused())
+	assert.True(t, panel.IsFocused)
+
+	// The parent column follows the focused panel
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.SplitFilePanel[0]))
+	require.Equal(t, 2, m.fileModel.PanelCount())
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.Confirm[0]))
+	assert.Equal(t, dir1, m.getFocusedFilePanel().Location)
+	assert.Equal(t, curTestDir, parent.Location)
+	assert.Equal(t, dir1, parent.GetFocusedItem().Location)
+}
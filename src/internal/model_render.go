@@ -47,6 +47,9 @@ func (m *model) terminalSizeWarnAfterFirstRender() string {
 	minimumWidthInt := common.Config.SidebarWidth + common.FilePanelWidthUnit*len(
 		m.fileModel.FilePanels,
 	) + common.FilePanelWidthUnit - 1
+	if m.fileModel.MillerColumns {
+		minimumWidthInt += common.FilePanelWidthUnit
+	}
 	minimumWidthString := strconv.Itoa(minimumWidthInt)
 	fullWidthString := strconv.Itoa(m.fullWidth)
 	fullHeightString := strconv.Itoa(m.fullHeight)
@@ -394,7 +394,7 @@ func TestUnlockErrorModalMutex(t *testing.T) {
 		pModel := prompt.DefaultModel(10, 10)
 		pModel.Open(false)
 		m.promptModal = pModel
-		zModel := zoxideui.GenerateModel(nil, 50, 80)
+		zModel := zoxideui.GenerateModel(nil, nil, 50, 80)
 		zModel.Open()
 		m.zoxideModal = zModel
 		m.notifyModel = notify.New(true, "test", "test", notify.RenameAction)
@@ -416,7 +416,7 @@ func TestUnlockErrorModalMutex(t *testing.T) {
 		pModel := prompt.DefaultModel(10, 10)
 		pModel.Open(false)
 		m.promptModal = pModel
-		zModel := zoxideui.GenerateModel(nil, 50, 80)
+		zModel := zoxideui.GenerateModel(nil, nil, 50, 80)
 		zModel.Open()
 		m.zoxideModal = zModel
 		m.notifyModel = notify.New(true, "test", "test", notify.RenameAction)
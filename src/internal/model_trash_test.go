@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/trash"
+	"github.com/yorukot/superfile/src/internal/ui/prompt"
+	"github.com/yorukot/superfile/src/internal/ui/trashbin"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Open the trash bin via the spf prompt, and wait for the items to be listed
+func openTrashBin(t *testing.T, p *TeaProg, expectedItems ...string) {
+	t.Helper()
+	p.SendKey(common.Hotkeys.OpenSPFPrompt[0])
+	p.SendKey(prompt.TrashCommand)
+	p.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.Eventually(t, func() bool {
+		m := p.getModel()
+		return !m.promptModal.IsOpen() && m.trashBin.IsOpen() &&
+			trashBinContains(m, expectedItems...)
+	}, DefaultTestTimeout, DefaultTestTick, "Trash bin should list the trashed items")
+}
+
+func trashBinContains(m *model, originalPaths ...string) bool {
+	for _, path := range originalPaths {
+		if !slices.ContainsFunc(m.trashBin.GetItems(), func(item trash.Item) bool {
+			return item.OriginalPath == path
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select the items with the given original paths. The trash might contain
+// other items, as the per-volume trash directories are listed too
+func selectTrashItems(p *TeaProg, originalPaths ...string) {
+	for _, item := range p.getModel().trashBin.GetItems() {
+		if slices.Contains(originalPaths, item.OriginalPath) {
+			p.Send(tea.KeyPressMsg{Code: tea.KeySpace})
+		}
+		p.SendKey(common.Hotkeys.ListDown[0])
+	}
+}
+
+func TestTrashBin(t *testing.T) {
+	if runtime.GOOS != utils.OsLinux {
+		t.Skip("Only the freedesktop trash can be redirected to a test directory")
+	}
+	curTestDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(curTestDir, "data"))
+	dir1 := filepath.Join(curTestDir, "dir1")
+	file1 := filepath.Join(dir1, "file1.txt")
+	file2 := filepath.Join(dir1, "file2.txt")
+	utils.SetupDirectories(t, dir1)
+
+	t.Run("Restore with a conflict", func(t *testing.T) {
+		utils.SetupFilesWithData(t, []byte("trashed"), file1, file2)
+		for _, file := range []string{file1, file2} {
+			_, err := trash.Move(file)
+			require.NoError(t, err)
+		}
+		// file1 is created again after being trashed
+		utils.SetupFilesWithData(t, []byte("new"), file1)
+
+		m := defaultTestModel(dir1)
+		m.hasTrash = true
+		p := NewTestTeaProgWithEventLoop(t, m)
+		openTrashBin(t, p, file1, file2)
+		selectTrashItems(p, file1, file2)
+		p.SendKey(trashbin.KeyRestore()[0])
+		answerPasteConflict(t, p, common.Hotkeys.ConflictOverwrite[0])
+
+		assert.Eventually(t, func() bool {
+			return !trashBinContains(p.getModel(), file2)
+		}, DefaultTestTimeout, DefaultTestTick, "Restored items should not be listed anymore")
+		assertFileContent(t, file1, "trashed")
+		assertFileContent(t, file2, "trashed")
+		// The overwritten file1 took its place in the trash
+		items, err := trash.List()
+		require.NoError(t, err)
+		idx := slices.IndexFunc(items, func(item trash.Item) bool { return item.OriginalPath == file1 })
+		require.NotEqual(t, -1, idx, "Overwritten item should be moved to the trash")
+		assertFileContent(t, items[idx].TrashedPath, "new")
+		assert.True(t, p.getModel().trashBin.IsOpen())
+	})
+
+	t.Run("Purge and empty", func(t *testing.T) {
+		utils.SetupFilesWithData(t, []byte("trashed"), file1, file2)
+		var trashedPaths []string
+		for _, file := range []string{file1, file2} {
+			result, err := trash.Move(file)
+			require.NoError(t, err)
+			trashedPaths = append(trashedPaths, result.TrashedPath)
+		}
+
+		m := defaultTestModel(dir1)
+		m.hasTrash = true
+		p := NewTestTeaProgWithEventLoop(t, m)
+		openTrashBin(t, p, file1, file2)
+		selectTrashItems(p, file1)
+		p.SendKey(trashbin.KeyPurge()[0])
+		require.Eventually(t, p.getModel().notifyModel.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		p.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+		assert.Eventually(t, func() bool {
+			return !trashBinContains(p.getModel(), file1) && trashBinContains(p.getModel(), file2)
+		}, DefaultTestTimeout, DefaultTestTick, "Only the selected item should be purged")
+		assert.NoFileExists(t, trashedPaths[0])
+		assert.NoFileExists(t, file1)
+
+		// Emptying would purge the items of the real per-volume trashes as well
+		for _, item := range p.getModel().trashBin.GetItems() {
+			if !strings.HasPrefix(item.TrashedPath, curTestDir+string(filepath.Separator)) {
+				t.Skip("Trash outside of the test directory is not empty")
+			}
+		}
+		p.SendKey(trashbin.KeyEmptyTrash()[0])
+		require.Eventually(t, p.getModel().notifyModel.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		p.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+		assert.Eventually(t, func() bool {
+			return !trashBinContains(p.getModel(), file2)
+		}, DefaultTestTimeout, DefaultTestTick, "Trash should be emptied")
+		assert.NoFileExists(t, trashedPaths[1])
+
+		p.SendKey(trashbin.KeyClose()[0])
+		assert.Eventually(t, func() bool { return !p.getModel().trashBin.IsOpen() },
+			DefaultTestTimeout, DefaultTestTick)
+	})
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestTreeMode(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	nested := filepath.Join(dir1, "nested.txt")
+	utils.SetupDirectories(t, dir1)
+	utils.SetupFiles(t, nested, filepath.Join(curTestDir, "file.txt"))
+
+	m := defaultTestModel(curTestDir)
+	panel := m.getFocusedFilePanel()
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ToggleTreeMode[0]))
+	require.Equal(t, filepanel.TreeMode, panel.PanelMode)
+
+	// Expands instead of entering the directory
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.Confirm[0]))
+	assert.Equal(t, curTestDir, panel.Location)
+	require.Equal(t, 3, panel.ElemCount())
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ListDown[0]))
+	assert.Equal(t, nested, panel.GetFocusedItem().Location)
+
+	// Copies the nested item
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.CopyItems[0]))
+	assert.Equal(t, []string{nested}, m.clipboard.GetItems())
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	assert.Equal(t, curTestDir, panel.Location, "collapses instead of leaving the directory")
+	assert.Equal(t, 2, panel.ElemCount())
+	assert.Equal(t, dir1, panel.GetFocusedItem().Location)
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ParentDirectory[0]))
+	assert.Equal(t, filepath.Dir(curTestDir), panel.Location, "nothing to collapse")
+}
+
+func TestTreeModeDeleteNested(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	nested1 := filepath.Join(dir1, "nested1.txt")
+	nested2 := filepath.Join(dir1, "nested2.txt")
+	utils.SetupDirectories(t, dir1)
+	utils.SetupFiles(t, nested1, nested2)
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	p.SendKey(common.Hotkeys.ToggleTreeMode[0])
+	p.SendKey(common.Hotkeys.Confirm[0])
+	p.SendKey(common.Hotkeys.ChangePanelMode[0])
+	require.Eventually(t, func() bool {
+		return m.getFocusedFilePanel().ElemCount() == 3
+	}, DefaultTestTimeout, DefaultTestTick)
+	m.getFocusedFilePanel().SetSelectedAll([]string{nested2})
+
+	p.SendKey(common.Hotkeys.PermanentlyDeleteItems[0])
+	assert.Eventually(t, m.notifyModel.IsOpen, DefaultTestTimeout, DefaultTestTick)
+	p.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(nested2)
+		return os.IsNotExist(err)
+	}, DefaultTestTimeout, DefaultTestTick, "the nested item is deleted")
+	assert.FileExists(t, nested1)
+}
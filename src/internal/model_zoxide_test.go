@@ -181,3 +181,42 @@ func TestZoxide(t *testing.T) {
 			"Zoxide modal should close on escape key")
 	})
 }
+
+func TestFrecencyNavigation(t *testing.T) {
+	originalZoxideSupport := common.Config.ZoxideSupport
+	t.Cleanup(func() {
+		common.Config.ZoxideSupport = originalZoxideSupport
+	})
+	common.Config.ZoxideSupport = false
+
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	dir3 := filepath.Join(curTestDir, "dir3")
+	utils.SetupDirectories(t, dir1, dir2, dir3)
+
+	// Without zoxide, the directories visited are queried from the frecency
+	// database of superfile
+	p := NewTestTeaProgWithEventLoop(t, defaultTestModel(dir1))
+	updateCurrentFilePanelDirOfTestModel(t, p, dir2)
+	updateCurrentFilePanelDirOfTestModel(t, p, dir3)
+	updateCurrentFilePanelDirOfTestModel(t, p, dir2)
+
+	openZoxide(t, p)
+	assert.Eventually(t, func() bool {
+		results := p.getModel().zoxideModal.GetResults()
+		return len(results) == 2 && results[0].Path == dir2 && results[1].Path == dir3
+	}, DefaultTestTimeout, DefaultTestTick, "visited directories ranked by frecency")
+
+	p.SendKey("dir3")
+	assert.Eventually(t, func() bool {
+		results := p.getModel().zoxideModal.GetResults()
+		return len(results) == 1 && results[0].Path == dir3
+	}, DefaultTestTimeout, DefaultTestTick, "dir3 should be found by the search")
+
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	assert.Eventually(t, func() bool {
+		return !p.getModel().zoxideModal.IsOpen() &&
+			p.getModel().getFocusedFilePanel().Location == dir3
+	}, DefaultTestTimeout, DefaultTestTick, "should navigate to %s", dir3)
+}
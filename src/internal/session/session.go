@@ -0,0 +1,111 @@
+// Package session saves the layout of the tabs and of their file panels, to
+// open them again as they were. The last session is saved when superfile
+// quits, and sessions can be saved under a name to be loaded later. They are
+// persisted as JSON.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+const fileExt = ".json"
+
+var (
+	ErrInvalidName = errors.New("invalid session name")
+	errNoPanel     = errors.New("session has no panel")
+)
+
+// Panel is the state of a file panel
+type Panel struct {
+	Location string            `json:"location"`
+	Sort     sortmodel.Options `json:"sort"`
+	// Shown as a tree, see filepanel.TreeMode
+	Tree bool `json:"tree,omitempty"`
+	// In the select mode. The selection itself is not kept
+	Select bool `json:"select,omitempty"`
+	// Name of the item under the cursor
+	Target string `json:"target,omitempty"`
+	// Filter of the search bar
+	Search string `json:"search,omitempty"`
+}
+
+// Tab is the state of a tab and of its panels
+type Tab struct {
+	Name         string  `json:"name,omitempty"`
+	Panels       []Panel `json:"panels"`
+	FocusedPanel int     `json:"focused_panel"`
+}
+
+type Session struct {
+	Tabs        []Tab `json:"tabs"`
+	ActiveTab   int   `json:"active_tab"`
+	PreviewOpen bool  `json:"preview_open"`
+	FooterOpen  bool  `json:"footer_open"`
+}
+
+// Load reads the session saved at filePath
+func Load(filePath string) (Session, error) {
+	var s Session
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("error parsing session file %s: %w", filePath, err)
+	}
+	if len(s.Tabs) == 0 {
+		return s, fmt.Errorf("%w: %s", errNoPanel, filePath)
+	}
+	for _, tab := range s.Tabs {
+		if len(tab.Panels) == 0 {
+			return s, fmt.Errorf("%w: %s", errNoPanel, filePath)
+		}
+	}
+	return s, nil
+}
+
+// Save writes s at filePath, creating its directory if needed
+func Save(filePath string, s Session) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), utils.ConfigDirPerm); err != nil {
+		return fmt.Errorf("error creating session directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding session: %w", err)
+	}
+	return os.WriteFile(filePath, data, utils.ConfigFilePerm)
+}
+
+// NamedFile returns the path of the session saved as name in dir. The name
+// is used as the file name, so it cannot contain a path separator
+func NamedFile(dir string, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return filepath.Join(dir, name+fileExt), nil
+}
+
+// Names returns the sorted names of the sessions saved in dir
+func Names(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), fileExt); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
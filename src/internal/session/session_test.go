@@ -0,0 +1,65 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nested", "session.json")
+	s := Session{
+		Tabs: []Tab{
+			{
+				Panels: []Panel{
+					{Location: "/a", Sort: sortmodel.Options{Kind: sortmodel.SortBySize, Reversed: true}, Target: "file"},
+					{Location: "/b", Tree: true, Select: true, Search: "query"},
+				},
+				FocusedPanel: 1,
+			},
+			{Name: "work", Panels: []Panel{{Location: "/c"}}},
+		},
+		ActiveTab:   1,
+		PreviewOpen: true,
+	}
+	require.NoError(t, Save(filePath, s))
+	loaded, err := Load(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+
+	for _, data := range []string{`{"tabs": []}`, `{"tabs": [{"panels": []}]}`} {
+		require.NoError(t, os.WriteFile(filePath, []byte(data), 0o600))
+		_, err = Load(filePath)
+		require.ErrorIs(t, err, errNoPanel, data)
+	}
+}
+
+func TestNamedFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath, err := NamedFile(dir, "my work")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "my work.json"), filePath)
+
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`} {
+		_, err := NamedFile(dir, name)
+		require.ErrorIs(t, err, ErrInvalidName, "name %q", name)
+	}
+}
+
+func TestNames(t *testing.T) {
+	dir := t.TempDir()
+	assert.Empty(t, Names(dir))
+	for _, name := range []string{"work", "home"} {
+		filePath, err := NamedFile(dir, name)
+		require.NoError(t, err)
+		require.NoError(t, Save(filePath, Session{Tabs: []Tab{{Panels: []Panel{{Location: "/"}}}}}))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o600))
+	assert.Equal(t, []string{"home", "work"}, Names(dir))
+	assert.Nil(t, Names(filepath.Join(dir, "missing")))
+}
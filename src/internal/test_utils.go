@@ -13,7 +13,11 @@ import (
 
 	"github.com/yorukot/superfile/src/pkg/utils"
 
+	"github.com/yorukot/superfile/src/internal/frecency"
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/marks"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	zoxideui "github.com/yorukot/superfile/src/internal/ui/zoxide"
 
 	"github.com/yorukot/superfile/src/internal/common"
 )
@@ -49,6 +53,13 @@ func defaultTestModelWithFilePreview(dirs ...string) *model {
 
 func setModelParamsForTest(m *model, disablePreview bool) *model {
 	m.disableMetadata = true
+	// Keep the journal in memory, so tests don't touch the user's state directory
+	m.journal = journal.New("")
+	m.sessionFile = ""
+	m.marks = marks.New("")
+	// Keep the frecency database in memory, for the zoxide modal too
+	m.frecencyDB = frecency.New("")
+	m.zoxideModal = zoxideui.DefaultModel(zoxideui.ZoxideMinHeight, zoxideui.ZoxideMinWidth, m.zClient, m.frecencyDB)
 	if disablePreview {
 		m.fileModel.FilePreview.Close()
 	}
@@ -177,6 +188,15 @@ func verifyDestinationFiles(t *testing.T, targetDir string, expectedDestFiles []
 	}
 }
 
+// Helper function to answer the conflict prompt opened by a paste
+func answerPasteConflict(t *testing.T, p *TeaProg, key string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return p.getModel().notifyModel.IsOpen() && p.getModel().notifyModel.HasChoices()
+	}, DefaultTestTimeout, DefaultTestTick, "Paste conflict prompt should open")
+	p.SendKey(key)
+}
+
 // Helper function to verify prevented paste results
 func verifyPreventedPasteResults(t *testing.T, m *model, originalPath string) {
 	t.Helper()
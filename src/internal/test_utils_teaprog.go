@@ -94,4 +94,6 @@ func (p *TeaProg) SendKeyDirectly(key string) tea.Cmd {
 
 func (p *TeaProg) Close() {
 	p.prog.Kill()
+	p.prog.Wait()
+	p.m.watcher.Close()
 }
@@ -1,6 +1,9 @@
 package trash
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type Backend string
 
@@ -18,3 +21,12 @@ type Result struct {
 	Backend          Backend
 	StrictlyRecycled bool
 }
+
+// Item is an entry of the trash, as returned by List
+type Item struct {
+	OriginalPath string
+	TrashedPath  string
+	// Zero if unknown
+	DeletionDate time.Time
+	IsDir        bool
+}
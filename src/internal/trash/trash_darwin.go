@@ -18,8 +18,13 @@ import "C"
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"unsafe"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
 )
 
 func Init() error {
@@ -57,3 +62,36 @@ func Move(path string) (Result, error) {
 		StrictlyRecycled: true,
 	}, nil
 }
+
+// Restore moves an item trashed by Move back to originalPath. It refuses to
+// overwrite an existing originalPath
+func Restore(trashedPath, originalPath string) error {
+	if _, err := os.Lstat(originalPath); err == nil {
+		return fmt.Errorf("cannot restore %s: %w", originalPath, os.ErrExist)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(originalPath), utils.UserDirPerm); err != nil {
+		return err
+	}
+	return os.Rename(trashedPath, originalPath)
+}
+
+// List is not supported, as Finder keeps the original location of the
+// trashed items in its own private metadata
+func List() ([]Item, error) {
+	return nil, ErrUnsupported
+}
+
+// Purge permanently deletes an item of the user's trash
+func Purge(trashedPath string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(filepath.Join(home, ".Trash"), trashedPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is not inside the trash", trashedPath)
+	}
+	return os.RemoveAll(trashedPath)
+}
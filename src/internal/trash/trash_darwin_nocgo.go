@@ -15,3 +15,15 @@ func Available(_ string) bool {
 func Move(path string) (Result, error) {
 	return Result{OriginalPath: path, Backend: BackendMacOS}, fmt.Errorf("%w: macOS trash requires cgo for Foundation FileManager", ErrUnsupported)
 }
+
+func Restore(_, _ string) error {
+	return fmt.Errorf("%w: macOS trash requires cgo for Foundation FileManager", ErrUnsupported)
+}
+
+func List() ([]Item, error) {
+	return nil, fmt.Errorf("%w: macOS trash requires cgo for Foundation FileManager", ErrUnsupported)
+}
+
+func Purge(_ string) error {
+	return fmt.Errorf("%w: macOS trash requires cgo for Foundation FileManager", ErrUnsupported)
+}
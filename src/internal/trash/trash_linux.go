@@ -3,17 +3,23 @@
 package trash
 
 import (
+	"bufio"
+	"cmp"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
 )
 
 const trashInfoDateLayout = "2006-01-02T15:04:05"
@@ -84,6 +90,201 @@ func Move(path string) (Result, error) {
 	}, nil
 }
 
+// Restore moves an item trashed by Move back to originalPath, and removes
+// its .trashinfo file. It refuses to overwrite an existing originalPath
+func Restore(trashedPath, originalPath string) error {
+	trashedPath = filepath.Clean(trashedPath)
+	if !isInsideKnownTrash(trashedPath) {
+		return fmt.Errorf("%s is not inside a trash directory", trashedPath)
+	}
+	if _, err := os.Lstat(originalPath); err == nil {
+		return fmt.Errorf("cannot restore %s: %w", originalPath, os.ErrExist)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(originalPath), utils.UserDirPerm); err != nil {
+		return err
+	}
+	if err := movePath(trashedPath, originalPath); err != nil {
+		return err
+	}
+	if err := os.Remove(trashInfoPathFor(trashedPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("restored %s, but failed to remove trash info: %w", originalPath, err)
+	}
+	return nil
+}
+
+// List returns the items of the home trash, and of the per-volume trash
+// directories of the mounted filesystems, most recently deleted first.
+// Items whose .trashinfo file is missing or invalid are left out
+func List() ([]Item, error) {
+	var items []Item
+	var errs []error
+	for _, td := range knownTrashDirs() {
+		tdItems, err := listTrashDir(td)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, tdItems...)
+	}
+	slices.SortStableFunc(items, func(a, b Item) int {
+		return b.DeletionDate.Compare(a.DeletionDate)
+	})
+	// Unreadable volume trashes should not hide the others
+	if len(items) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return items, nil
+}
+
+// Purge permanently deletes an item of the trash, along with its .trashinfo file
+func Purge(trashedPath string) error {
+	trashedPath = filepath.Clean(trashedPath)
+	if !isInsideKnownTrash(trashedPath) {
+		return fmt.Errorf("%s is not inside a trash directory", trashedPath)
+	}
+	if err := os.RemoveAll(trashedPath); err != nil {
+		return err
+	}
+	if err := os.Remove(trashInfoPathFor(trashedPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("purged %s, but failed to remove trash info: %w", trashedPath, err)
+	}
+	return nil
+}
+
+func trashInfoPathFor(trashedPath string) string {
+	filesDir := filepath.Dir(trashedPath)
+	return filepath.Join(filepath.Dir(filesDir), "info", filepath.Base(trashedPath)+trashInfoSuffix)
+}
+
+func knownTrashDirs() []linuxTrashDir {
+	home := homeTrashDir()
+	dirs := []linuxTrashDir{home}
+	seen := map[string]bool{home.root: true}
+	uid := strconv.Itoa(os.Getuid())
+	for _, topDir := range mountPoints() {
+		candidates := []linuxTrashDir{{
+			root:     filepath.Join(topDir, ".Trash-"+uid),
+			pathBase: topDir,
+		}}
+		if validSharedTrash(filepath.Join(topDir, ".Trash")) {
+			candidates = append(candidates, linuxTrashDir{
+				root:     filepath.Join(topDir, ".Trash", uid),
+				pathBase: topDir,
+			})
+		}
+		for _, td := range candidates {
+			if seen[td.root] {
+				continue
+			}
+			seen[td.root] = true
+			td.files = filepath.Join(td.root, "files")
+			td.info = filepath.Join(td.root, "info")
+			if info, err := os.Lstat(td.info); err == nil && info.IsDir() {
+				dirs = append(dirs, td)
+			}
+		}
+	}
+	return dirs
+}
+
+func mountPoints() []string {
+	file, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	var mounts []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 { //nolint:mnd // device and mount point
+			continue
+		}
+		mounts = append(mounts, unescapeMountPoint(fields[1]))
+	}
+	return mounts
+}
+
+// /proc/self/mounts escapes spaces, tabs, newlines and backslashes as octal
+func unescapeMountPoint(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if value, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(value))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+func listTrashDir(td linuxTrashDir) ([]Item, error) {
+	entries, err := os.ReadDir(td.info)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	items := make([]Item, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), trashInfoSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		trashedPath := filepath.Join(td.files, name)
+		info, err := os.Lstat(trashedPath)
+		if err != nil {
+			continue
+		}
+		item, err := parseTrashInfo(td, filepath.Join(td.info, entry.Name()))
+		if err != nil {
+			continue
+		}
+		item.TrashedPath = trashedPath
+		item.IsDir = info.IsDir()
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func parseTrashInfo(td linuxTrashDir, infoPath string) (Item, error) {
+	data, err := os.ReadFile(infoPath)
+	if err != nil {
+		return Item{}, err
+	}
+	var item Item
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "Path":
+			path, err := url.PathUnescape(value)
+			if err != nil {
+				return Item{}, fmt.Errorf("invalid path in %s: %w", infoPath, err)
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(cmp.Or(td.pathBase, string(filepath.Separator)), path)
+			}
+			item.OriginalPath = filepath.Clean(path)
+		case "DeletionDate":
+			// Left as zero if invalid, the item can still be restored
+			item.DeletionDate, _ = time.ParseInLocation(trashInfoDateLayout, value, time.Local)
+		}
+	}
+	if item.OriginalPath == "" {
+		return Item{}, fmt.Errorf("no path in %s", infoPath)
+	}
+	return item, nil
+}
+
 func selectTrashDir(path string, create bool) (linuxTrashDir, error) {
 	srcAbs, err := filepath.Abs(path)
 	if err != nil {
@@ -142,3 +142,83 @@ func TestMoveRejectsTrashSymlink(t *testing.T) {
 	require.Error(t, err)
 	assert.FileExists(t, src)
 }
+
+func TestRestoreMovesItemBackAndRemovesInfo(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	src := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(src, []byte("content"), 0o644))
+
+	result, err := Move(src)
+	require.NoError(t, err)
+	infoPath := filepath.Join(dataHome, "Trash", "info", filepath.Base(result.TrashedPath)+trashInfoSuffix)
+	require.FileExists(t, infoPath)
+
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0o644))
+	require.ErrorIs(t, Restore(result.TrashedPath, src), os.ErrExist)
+	assert.FileExists(t, result.TrashedPath)
+
+	require.NoError(t, os.Remove(src))
+	require.NoError(t, Restore(result.TrashedPath, src))
+	assert.FileExists(t, src)
+	assert.NoFileExists(t, result.TrashedPath)
+	assert.NoFileExists(t, infoPath)
+
+	require.Error(t, Restore(src, filepath.Join(t.TempDir(), "file.txt")))
+}
+
+func TestListAndPurge(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "file with space.txt")
+	dir := filepath.Join(srcDir, "dir")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0o644))
+	require.NoError(t, os.Mkdir(dir, 0o755))
+
+	fileResult, err := Move(file)
+	require.NoError(t, err)
+	dirResult, err := Move(dir)
+	require.NoError(t, err)
+	// Info file without a trashed item must be ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dataHome, "Trash", "info", "orphan"+trashInfoSuffix),
+		[]byte("[Trash Info]\nPath=/orphan\nDeletionDate=2020-01-01T00:00:00\n"), 0o600))
+
+	listHomeTrash := func() []Item {
+		items, err := List()
+		require.NoError(t, err)
+		var homeItems []Item
+		for _, item := range items {
+			if strings.HasPrefix(item.TrashedPath, dataHome) {
+				homeItems = append(homeItems, item)
+			}
+		}
+		return homeItems
+	}
+
+	items := listHomeTrash()
+	require.Len(t, items, 2)
+	byPath := map[string]Item{items[0].OriginalPath: items[0], items[1].OriginalPath: items[1]}
+	require.Contains(t, byPath, file)
+	require.Contains(t, byPath, dir)
+	assert.Equal(t, fileResult.TrashedPath, byPath[file].TrashedPath)
+	assert.False(t, byPath[file].IsDir)
+	assert.True(t, byPath[dir].IsDir)
+	assert.WithinDuration(t, time.Now(), byPath[file].DeletionDate, time.Minute)
+
+	require.NoError(t, Purge(dirResult.TrashedPath))
+	assert.NoDirExists(t, dirResult.TrashedPath)
+	items = listHomeTrash()
+	require.Len(t, items, 1)
+	assert.Equal(t, file, items[0].OriginalPath)
+
+	require.Error(t, Purge(file))
+}
+
+func TestUnescapeMountPoint(t *testing.T) {
+	assert.Equal(t, "/mnt/my disk", unescapeMountPoint(`/mnt/my\040disk`))
+	assert.Equal(t, `/mnt/back\slash`, unescapeMountPoint(`/mnt/back\134slash`))
+	assert.Equal(t, "/", unescapeMountPoint("/"))
+}
@@ -13,3 +13,15 @@ func Available(_ string) bool {
 func Move(path string) (Result, error) {
 	return Result{OriginalPath: path}, ErrUnsupported
 }
+
+func Restore(_, _ string) error {
+	return ErrUnsupported
+}
+
+func List() ([]Item, error) {
+	return nil, ErrUnsupported
+}
+
+func Purge(_ string) error {
+	return ErrUnsupported
+}
@@ -154,6 +154,21 @@ func Move(path string) (Result, error) {
 	return result, nil
 }
 
+// Restore is not supported, as the Recycle Bin does not expose the
+// location of the recycled item
+func Restore(_, _ string) error {
+	return ErrUnsupported
+}
+
+// List and Purge are not supported, for the same reason as Restore
+func List() ([]Item, error) {
+	return nil, ErrUnsupported
+}
+
+func Purge(_ string) error {
+	return ErrUnsupported
+}
+
 func recycleWithIFileOperation(path string) error {
 	hr, _, _ := procCoInitializeEx.Call(0, coinitApartmentThreaded)
 	if failed(hr) {
@@ -5,10 +5,19 @@ import (
 
 	zoxidelib "github.com/lazysegtree/go-zoxide"
 
+	"github.com/yorukot/superfile/src/internal/frecency"
+	"github.com/yorukot/superfile/src/internal/journal"
+	"github.com/yorukot/superfile/src/internal/marks"
 	"github.com/yorukot/superfile/src/internal/ui/helpmenu"
 	"github.com/yorukot/superfile/src/internal/ui/spferror"
+	"github.com/yorukot/superfile/src/internal/ui/trashbin"
 
+	"github.com/yorukot/superfile/src/internal/ui/bulkrename"
 	"github.com/yorukot/superfile/src/internal/ui/clipboard"
+	"github.com/yorukot/superfile/src/internal/ui/compressmodel"
+	"github.com/yorukot/superfile/src/internal/ui/contentsearch"
+	"github.com/yorukot/superfile/src/internal/ui/extractmodel"
+	"github.com/yorukot/superfile/src/internal/ui/permissionmodel"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 
 	"github.com/yorukot/superfile/src/internal/ui/filemodel"
@@ -22,6 +31,11 @@ import (
 
 	"github.com/yorukot/superfile/src/internal/ui/prompt"
 	zoxideui "github.com/yorukot/superfile/src/internal/ui/zoxide"
+	"github.com/yorukot/superfile/src/internal/watcher"
+
+	"github.com/yorukot/superfile/src/internal/dircompare"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
 )
 
 // Type representing the type of focused panel
@@ -29,6 +43,9 @@ type focusPanelType int
 
 type modelQuitStateType int
 
+// Kind of mark whose letter is awaited, after its key was pressed
+type pendingMarkType int
+
 // Constants for panel with no focus
 const (
 	nonePanelFocus focusPanelType = iota
@@ -45,6 +62,12 @@ const (
 	quitDone
 )
 
+const (
+	noPendingMark pendingMarkType = iota
+	pendingSetMark
+	pendingJumpToMark
+)
+
 // Main model
 // TODO : We could consider using *model as tea.Model, instead of model.
 // for reducing re-allocations. The struct is 20K bytes. But this could lead to
@@ -61,16 +84,67 @@ type model struct {
 
 	// Modals
 	notifyModel     notify.Model
+	conflictPrompt  pendingConflicts
 	typingModal     typingModal
 	helpMenu        helpmenu.Model
 	promptModal     prompt.Model
 	zoxideModal     zoxideui.Model
 	sortModal       sortmodel.Model
-	spfError        spferror.Model
-	mutexErrorModal sync.Mutex
+	compressModal   compressmodel.Model
+	extractModal    extractmodel.Model
+	trashBin        trashbin.Model
+	bulkRenameModal bulkrename.Model
+	permissionModal permissionmodel.Model
+	// Search in the content of the files
+	contentSearchModal contentsearch.Model
+	spfError           spferror.Model
+	mutexErrorModal    sync.Mutex
+
+	// Reports the changes in the directories shown. Nil if the filesystem
+	// cannot be watched
+	watcher *watcher.Watcher
+
+	// Status of the git work trees of the panels. Nil if the git_status
+	// plugin is disabled
+	gitStatus *gitstatus.Cache
+
+	// Sizes of the directories of the panels. Nil if the computation of the
+	// directory sizes is disabled
+	dirSizes *dirsize.Cache
+
+	// Comparison of the directories of two panels. Nil if they are not
+	// compared, see handle_compare.go
+	comparison *dircompare.Comparison
+	// The comparison has to be started on the next update
+	comparisonPending bool
+	// The trees changed since the walk running or done started. They are
+	// compared again once it is done, after comparisonRestartDelay
+	comparisonOutdated bool
+	// The restart of the outdated comparison is scheduled
+	comparisonRestartScheduled bool
+
+	// Sort chosen for each directory. Nil if the sort is not remembered per
+	// directory
+	sortMemory *sortmodel.Memory
 
 	// Zoxide client for directory tracking
 	zClient *zoxidelib.Client
+	// Directories visited, ranked like zoxide does. Queried instead of zoxide
+	// when it is not installed or disabled
+	frecencyDB *frecency.DB
+
+	// History of file operations, for undo and redo
+	journal *journal.Journal
+
+	// Session saved when superfile quits, and directory of the named sessions.
+	// No session is saved on quit if sessionFile is empty
+	sessionFile string
+	sessionsDir string
+
+	// Directories marked with a letter, and the kind of mark whose letter is
+	// awaited after its key was pressed
+	marks       *marks.Marks
+	pendingMark pendingMarkType
 
 	fileMetaData metadata.Model
 
@@ -0,0 +1,22 @@
+package bulkrename
+
+const (
+	bulkRenameHeadlineText = "Rename"
+
+	BulkRenameMinWidth  = 30
+	BulkRenameMinHeight = 12
+
+	// renderOverhead is the number of lines that are not renames
+	// (borders + section divider + key hints)
+	renderOverhead = 4
+	// patternRenderOverhead adds the inputs of the pattern, the case transform,
+	// a section divider and a second line of key hints
+	patternRenderOverhead = renderOverhead + 5
+
+	renameArrow = " -> "
+
+	// Width of the labels in front of the inputs of the pattern
+	inputLabelWidth = 10
+	// Longest padding of the {n:WIDTH} counter
+	maxCounterWidth = 99
+)
@@ -0,0 +1,259 @@
+package bulkrename
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func New(maxHeight int, width int) Model {
+	m := Model{
+		headline: icon.Rename + icon.Space + bulkRenameHeadlineText,
+	}
+	m.SetMaxHeight(maxHeight)
+	m.SetWidth(width)
+	return m
+}
+
+func (m *Model) newPatternInput(placeholder string) textinput.Model {
+	ti := common.GenerateRenameTextInput(m.inputWidth(), 0, "")
+	ti.Placeholder = placeholder
+	ti.Blur()
+	return ti
+}
+
+func KeyConfirm() []string {
+	return common.Hotkeys.ConfirmTyping
+}
+
+func KeyClose() []string {
+	return slices.Concat(common.Hotkeys.Quit, common.Hotkeys.CancelTyping)
+}
+
+func KeyNextInput() []string {
+	return common.Hotkeys.PatternRenameNextField
+}
+
+func KeyCycleCase() []string {
+	return common.Hotkeys.PatternRenameCycleCase
+}
+
+// Open the preview of the given renames of the items of dir
+func (m *Model) Open(dir string, items []Item) {
+	m.open = true
+	m.dir = dir
+	m.items = items
+	m.cursor = 0
+	m.renderIndex = 0
+}
+
+// OpenPattern opens the modal to rename the items of dir named names with a
+// pattern. existing are the names of all the items of dir
+func (m *Model) OpenPattern(dir string, names []string, existing []string) {
+	items := make([]Item, len(names))
+	for i, name := range names {
+		items[i] = Item{OldName: name, NewName: name}
+	}
+	m.Open(dir, items)
+	m.patternMode = true
+	m.existing = make(map[string]struct{}, len(existing))
+	for _, name := range existing {
+		m.existing[name] = struct{}{}
+	}
+	m.findInput = m.newPatternInput("Regex, empty for the whole name")
+	m.replaceInput = m.newPatternInput("$1, {n}, {n:3}, {name}, {ext}")
+	m.focusReplace = false
+	m.findInput.Focus()
+	m.caseTransform = CaseKeep
+	m.updateNewNames()
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.dir = ""
+	m.items = nil
+	m.cursor = 0
+	m.renderIndex = 0
+	m.patternMode = false
+	m.patternErr = nil
+	m.existing = nil
+	m.findInput.Blur()
+	m.replaceInput.Blur()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// IsPatternMode tells whether the new names are typed as a pattern, in which
+// case the printable keys are for the inputs
+func (m *Model) IsPatternMode() bool {
+	return m.patternMode
+}
+
+func (m *Model) GetDir() string {
+	return m.dir
+}
+
+func (m *Model) GetItems() []Item {
+	return m.items
+}
+
+// HasConflicts tells whether some of the renames cannot be done
+func (m *Model) HasConflicts() bool {
+	return m.patternErr != nil || slices.ContainsFunc(m.items, func(item Item) bool {
+		return item.Conflict != ""
+	})
+}
+
+// HandleUpdate passes the message, like a key or a cursor blink, to the
+// focused input of the pattern, and updates the new names
+func (m *Model) HandleUpdate(msg tea.Msg) tea.Cmd {
+	if !m.open || !m.patternMode {
+		return nil
+	}
+	if key, ok := msg.(tea.KeyPressMsg); ok && isModalKey(key.String()) {
+		return nil
+	}
+	var cmd tea.Cmd
+	if m.focusReplace {
+		m.replaceInput, cmd = m.replaceInput.Update(msg)
+	} else {
+		m.findInput, cmd = m.findInput.Update(msg)
+	}
+	m.updateNewNames()
+	return cmd
+}
+
+// The keys handled by the main model, that are not typed in the inputs
+func isModalKey(key string) bool {
+	isNavigation := slices.Contains(common.Hotkeys.ListUp, key) || slices.Contains(common.Hotkeys.ListDown, key)
+	// Printable keys, like 'j', are typed even if they are navigation hotkeys
+	return slices.Contains(KeyNextInput(), key) || slices.Contains(KeyCycleCase(), key) ||
+		isNavigation && utf8.RuneCountInString(key) > 1
+}
+
+// NextInput moves the focus between the find and replace inputs
+func (m *Model) NextInput() tea.Cmd {
+	if !m.patternMode {
+		return nil
+	}
+	m.focusReplace = !m.focusReplace
+	if m.focusReplace {
+		m.findInput.Blur()
+		return m.replaceInput.Focus()
+	}
+	m.replaceInput.Blur()
+	return m.findInput.Focus()
+}
+
+func (m *Model) CycleCase() {
+	if !m.patternMode {
+		return
+	}
+	m.caseTransform = m.caseTransform.Next()
+	m.updateNewNames()
+}
+
+func (m *Model) GetPattern() Pattern {
+	return Pattern{Find: m.findInput.Value(), Replace: m.replaceInput.Value(), Case: m.caseTransform}
+}
+
+func (m *Model) updateNewNames() {
+	if !m.patternMode {
+		return
+	}
+	oldNames := make([]string, len(m.items))
+	for i, item := range m.items {
+		oldNames[i] = item.OldName
+	}
+	var newNames []string
+	newNames, m.patternErr = m.GetPattern().Apply(oldNames)
+	if m.patternErr != nil {
+		return
+	}
+	for i := range m.items {
+		m.items[i].NewName = newNames[i]
+	}
+	m.updateConflicts()
+}
+
+// Find the renames that would give an invalid name, or the name of another item
+func (m *Model) updateConflicts() {
+	renamed := make(map[string]struct{}, len(m.items))
+	finalNames := make(map[string]int, len(m.items))
+	for _, item := range m.items {
+		if item.NewName != item.OldName {
+			renamed[item.OldName] = struct{}{}
+		}
+		finalNames[item.NewName]++
+	}
+	for i := range m.items {
+		item := &m.items[i]
+		item.Conflict = ""
+		if item.NewName == item.OldName {
+			continue
+		}
+		_, exists := m.existing[item.NewName]
+		_, freed := renamed[item.NewName]
+		switch {
+		case item.NewName == "":
+			item.Conflict = "empty name"
+		case item.NewName == "." || item.NewName == "..":
+			item.Conflict = "invalid name"
+		case strings.ContainsRune(item.NewName, '/') || strings.ContainsRune(item.NewName, os.PathSeparator):
+			item.Conflict = "path separator"
+		case finalNames[item.NewName] > 1:
+			item.Conflict = "duplicate"
+		case exists && !freed:
+			item.Conflict = "already exists"
+		}
+	}
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetMaxHeight() int {
+	return m.maxHeight
+}
+
+func (m *Model) SetWidth(width int) {
+	if width < BulkRenameMinWidth {
+		slog.Warn("Bulk rename preview initialized with too less width", "width", width)
+		width = BulkRenameMinWidth
+	}
+	m.width = width
+	m.findInput.SetWidth(m.inputWidth())
+	m.replaceInput.SetWidth(m.inputWidth())
+}
+
+// Width of the inputs: modal width - borders(2) - padding(1) - label - prompt(2) - cursor(1)
+func (m *Model) inputWidth() int {
+	return m.width - 2 - 1 - inputLabelWidth - 2 - 1 //nolint:mnd // see above
+}
+
+func (m *Model) SetMaxHeight(maxHeight int) {
+	if maxHeight < BulkRenameMinHeight {
+		slog.Warn("Bulk rename preview initialized with too less maxHeight", "maxHeight", maxHeight)
+		maxHeight = BulkRenameMinHeight
+	}
+	m.maxHeight = maxHeight
+	m.updateRenderIndex()
+}
+
+func (m *Model) visibleCount() int {
+	if m.patternMode {
+		return m.maxHeight - patternRenderOverhead
+	}
+	return m.maxHeight - renderOverhead
+}
@@ -0,0 +1,105 @@
+package bulkrename
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testItems(cnt int) []Item {
+	items := make([]Item, cnt)
+	for i := range items {
+		items[i] = Item{OldName: "file" + strconv.Itoa(i), NewName: "new" + strconv.Itoa(i)}
+	}
+	return items
+}
+
+func TestNavigation(t *testing.T) {
+	m := New(BulkRenameMinHeight, 60)
+	m.ListDown()
+	assert.Equal(t, 0, m.cursor, "no renames keeps the cursor")
+
+	m.Open("/dir", testItems(10))
+	visible := m.visibleCount()
+	for range visible {
+		m.ListDown()
+	}
+	assert.Equal(t, visible, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+	m.ListUp()
+	m.ListUp()
+	assert.Equal(t, visible-2, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+
+	m.cursor = 9
+	m.ListDown()
+	assert.Equal(t, 0, m.cursor, "wraps to the top")
+	assert.Equal(t, 0, m.renderIndex)
+	m.ListUp()
+	assert.Equal(t, 9, m.cursor, "wraps to the bottom")
+	assert.Equal(t, 10-visible, m.renderIndex)
+
+	m.Close()
+	assert.False(t, m.IsOpen())
+	assert.Empty(t, m.GetItems())
+	assert.Equal(t, 0, m.cursor)
+}
+
+func TestRender(t *testing.T) {
+	m := New(BulkRenameMinHeight, 60)
+	m.Open("/dir", testItems(2))
+	res := m.Render()
+	assert.Contains(t, res, "file0 -> new0")
+	assert.Contains(t, res, "file1 -> new1")
+	assert.Contains(t, res, "2 items")
+	assert.Contains(t, res, "1/2")
+	assert.Contains(t, res, "Rename")
+}
+
+func TestPatternConflicts(t *testing.T) {
+	m := New(BulkRenameMinHeight, 80)
+	m.OpenPattern("/dir", []string{"a.txt", "b.txt", "c.txt"}, []string{"a.txt", "b.txt", "c.txt", "taken.txt"})
+	assert.True(t, m.IsPatternMode())
+	assert.False(t, m.HasConflicts(), "names are kept until a pattern is typed")
+
+	m.replaceInput.SetValue("{n}.txt")
+	m.updateNewNames()
+	assert.Equal(t, []Item{
+		{OldName: "a.txt", NewName: "1.txt"},
+		{OldName: "b.txt", NewName: "2.txt"},
+		{OldName: "c.txt", NewName: "3.txt"},
+	}, m.GetItems())
+	assert.False(t, m.HasConflicts())
+
+	m.replaceInput.SetValue("taken.txt")
+	m.updateNewNames()
+	assert.Equal(t, "duplicate", m.GetItems()[0].Conflict)
+	assert.True(t, m.HasConflicts())
+
+	m.findInput.SetValue(`^a\.txt$`)
+	m.updateNewNames()
+	assert.Equal(t, Item{OldName: "a.txt", NewName: "taken.txt", Conflict: "already exists"}, m.GetItems()[0])
+	assert.Equal(t, Item{OldName: "b.txt", NewName: "b.txt"}, m.GetItems()[1])
+	assert.Contains(t, m.Render(), "(already exists)")
+
+	m.findInput.SetValue("^b")
+	m.replaceInput.SetValue("a")
+	m.updateNewNames()
+	assert.Equal(t, "duplicate", m.GetItems()[1].Conflict, "a.txt is kept")
+	m.findInput.SetValue("(")
+	m.updateNewNames()
+	assert.True(t, m.HasConflicts())
+	assert.Contains(t, m.Render(), "Invalid pattern")
+
+	// The name of a renamed item is free
+	m.OpenPattern("/dir", []string{"2.txt", "3.txt"}, []string{"2.txt", "3.txt"})
+	m.replaceInput.SetValue("{n}.txt")
+	m.updateNewNames()
+	assert.Equal(t, "2.txt", m.GetItems()[1].NewName)
+	assert.False(t, m.HasConflicts())
+
+	m.Close()
+	assert.False(t, m.IsPatternMode())
+	assert.False(t, m.HasConflicts())
+}
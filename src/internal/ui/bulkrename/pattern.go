@@ -0,0 +1,107 @@
+package bulkrename
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{(?:n(?::(\d{1,2}))?|name|ext)\}`)
+
+// Apply returns the new name of each of names, in order. The counter {n} is
+// the position of the name, from 1
+func (p Pattern) Apply(names []string) ([]string, error) {
+	var find *regexp.Regexp
+	if p.Find != "" {
+		var err error
+		find, err = regexp.Compile(p.Find)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newNames := make([]string, len(names))
+	for i, name := range names {
+		newName := name
+		switch {
+		case find != nil:
+			// The placeholders must not be read as capture groups
+			newName = find.ReplaceAllString(name, expandPlaceholders(p.Replace, name, i+1, true))
+		case p.Replace != "":
+			newName = expandPlaceholders(p.Replace, name, i+1, false)
+		}
+		newNames[i] = p.Case.Apply(newName)
+	}
+	return newNames, nil
+}
+
+func expandPlaceholders(template string, name string, n int, escapeDollar bool) string {
+	ext := filepath.Ext(name)
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		var value string
+		switch placeholder {
+		case "{name}":
+			value = strings.TrimSuffix(name, ext)
+		case "{ext}":
+			value = ext
+		default:
+			width := 0
+			if match := placeholderRegexp.FindStringSubmatch(placeholder); match[1] != "" {
+				width, _ = strconv.Atoi(match[1])
+			}
+			value = fmt.Sprintf("%0*d", min(width, maxCounterWidth), n)
+		}
+		if escapeDollar {
+			value = strings.ReplaceAll(value, "$", "$$")
+		}
+		return value
+	})
+}
+
+// Apply changes the case of name. Title case capitalizes the words separated
+// by spaces, '_' or '-', and lowers the rest
+func (c CaseTransform) Apply(name string) string {
+	switch c {
+	case CaseLower:
+		return strings.ToLower(name)
+	case CaseUpper:
+		return strings.ToUpper(name)
+	case CaseTitle:
+		runes := []rune(name)
+		wordStart := true
+		for i, r := range runes {
+			if wordStart {
+				runes[i] = unicode.ToUpper(r)
+			} else {
+				runes[i] = unicode.ToLower(r)
+			}
+			wordStart = unicode.IsSpace(r) || r == '_' || r == '-'
+		}
+		return string(runes)
+	case CaseKeep:
+		return name
+	}
+	return name
+}
+
+func (c CaseTransform) String() string {
+	switch c {
+	case CaseLower:
+		return "lower"
+	case CaseUpper:
+		return "UPPER"
+	case CaseTitle:
+		return "Title"
+	case CaseKeep:
+		return "keep"
+	}
+	return "keep"
+}
+
+// Next returns the transform that follows c, back to CaseKeep after the last one
+func (c CaseTransform) Next() CaseTransform {
+	return (c + 1) % (CaseTitle + 1)
+}
@@ -0,0 +1,76 @@
+package bulkrename
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternApply(t *testing.T) {
+	names := []string{"IMG_0001.jpg", "my holiday.JPG", "notes"}
+	testdata := []struct {
+		name     string
+		pattern  Pattern
+		expected []string
+	}{
+		{
+			name:     "Empty pattern keeps the names",
+			pattern:  Pattern{},
+			expected: names,
+		},
+		{
+			name:     "Regex with capture groups",
+			pattern:  Pattern{Find: `^IMG_(\d+)`, Replace: "photo-$1"},
+			expected: []string{"photo-0001.jpg", "my holiday.JPG", "notes"},
+		},
+		{
+			name:     "Whole name with counter and extension",
+			pattern:  Pattern{Replace: "file {n:3}{ext}"},
+			expected: []string{"file 001.jpg", "file 002.JPG", "file 003"},
+		},
+		{
+			name:     "Placeholders in a regex replacement are not capture groups",
+			pattern:  Pattern{Find: `\..*$`, Replace: "_{n}$${ext}"},
+			expected: []string{"IMG_0001_1$.jpg", "my holiday_2$.JPG", "notes"},
+		},
+		{
+			name:     "Name without the extension",
+			pattern:  Pattern{Replace: "{name}-{n}{ext}"},
+			expected: []string{"IMG_0001-1.jpg", "my holiday-2.JPG", "notes-3"},
+		},
+		{
+			name:     "Lower case",
+			pattern:  Pattern{Case: CaseLower},
+			expected: []string{"img_0001.jpg", "my holiday.jpg", "notes"},
+		},
+		{
+			name:     "Upper case",
+			pattern:  Pattern{Replace: "{name}", Case: CaseUpper},
+			expected: []string{"IMG_0001", "MY HOLIDAY", "NOTES"},
+		},
+		{
+			name:     "Title case",
+			pattern:  Pattern{Case: CaseTitle},
+			expected: []string{"Img_0001.jpg", "My Holiday.jpg", "Notes"},
+		},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			newNames, err := tt.pattern.Apply(names)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, newNames)
+		})
+	}
+
+	_, err := Pattern{Find: "(unclosed"}.Apply(names)
+	require.Error(t, err)
+}
+
+func TestCaseTransformNext(t *testing.T) {
+	c := CaseKeep
+	for _, expected := range []CaseTransform{CaseLower, CaseUpper, CaseTitle, CaseKeep} {
+		c = c.Next()
+		assert.Equal(t, expected, c)
+	}
+}
@@ -0,0 +1,79 @@
+package bulkrename
+
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/internal/ui/rendering"
+)
+
+func (m *Model) Render() string {
+	r := ui.BulkRenameRenderer(m.maxHeight, m.width)
+	r.SetBorderTitle(fmt.Sprintf("%s %d items", m.headline, len(m.items)))
+	r.SetBorderInfoItems(fmt.Sprintf("%d/%d", m.cursor+1, len(m.items)))
+
+	if m.patternMode {
+		r.AddLines(
+			fmt.Sprintf(" %-*s", inputLabelWidth, "Find")+m.findInput.View(),
+			fmt.Sprintf(" %-*s", inputLabelWidth, "Replace")+m.replaceInput.View(),
+			fmt.Sprintf(" %-*s", inputLabelWidth, "Case")+"  "+m.caseTransform.String(),
+		)
+		r.AddSection()
+	}
+
+	lineCnt := 1
+	if m.patternErr != nil {
+		r.AddLines(common.ModalErrorStyle.Render(" Invalid pattern: " + m.patternErr.Error()))
+	} else {
+		lineCnt = m.renderItems(r)
+	}
+	// Keep the key hints at the bottom of the modal
+	for range m.visibleCount() - lineCnt {
+		r.AddLines("")
+	}
+
+	r.AddSection()
+	if m.patternMode {
+		r.AddLines(
+			keyHint(KeyConfirm(), "Rename")+keyHint(KeyNextInput(), "Next field"),
+			keyHint(KeyCycleCase(), "Case")+keyHint(common.Hotkeys.CancelTyping, "Cancel"),
+		)
+	} else {
+		r.AddLines(keyHint(KeyConfirm(), "Rename") + keyHint(KeyClose(), "Cancel"))
+	}
+	return r.Render()
+}
+
+// renderItems adds the visible renames and returns how many were added.
+// The renames that cannot be done are highlighted, with the reason
+func (m *Model) renderItems(r *rendering.Renderer) int {
+	endIndex := min(m.renderIndex+m.visibleCount(), len(m.items))
+	// Available width of each name: modal width - borders(2) - padding(1) - arrow
+	nameWidth := (m.width - 2 - 1 - len(renameArrow)) / 2 //nolint:mnd // old and new name
+	for i := m.renderIndex; i < endIndex; i++ {
+		item := m.items[i]
+		newName := item.NewName
+		if item.Conflict != "" {
+			newName += " (" + item.Conflict + ")"
+		}
+		line := " " + common.TruncateText(item.OldName, nameWidth, "...") + renameArrow +
+			common.TruncateText(newName, nameWidth, "...")
+		switch {
+		case i == m.cursor:
+			line = common.ModalCursorStyle.Render(line)
+		case item.Conflict != "":
+			line = common.ModalErrorStyle.Render(line)
+		}
+		r.AddLines(line)
+	}
+	return endIndex - m.renderIndex
+}
+
+func keyHint(keys []string, text string) string {
+	key := ""
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	return " (" + key + ") " + text
+}
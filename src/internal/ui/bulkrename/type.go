@@ -0,0 +1,60 @@
+package bulkrename
+
+import "charm.land/bubbles/v2/textinput"
+
+// Item is a rename of the preview, from OldName to NewName
+type Item struct {
+	OldName string
+	NewName string
+	// Why the item cannot be renamed to NewName, empty if it can
+	Conflict string
+}
+
+// CaseTransform changes the case of the new names
+type CaseTransform int
+
+const (
+	CaseKeep CaseTransform = iota
+	CaseLower
+	CaseUpper
+	CaseTitle
+)
+
+// Pattern computes the new names from the old ones
+type Pattern struct {
+	// Regular expression replaced in the old name. Empty to replace the whole name
+	Find string
+	// Replacement, with the capture groups of Find like $1, the counter {n}
+	// padded with zeros like {n:3}, {name} without the extension and {ext}
+	Replace string
+	Case    CaseTransform
+}
+
+// Model of the preview shown before a bulk rename. The new names are either
+// given, when edited in the editor, or computed from a pattern as it is
+// typed. The renames are validated again and executed by the main model
+type Model struct {
+	headline string
+
+	// State
+	open        bool
+	dir         string
+	items       []Item
+	cursor      int
+	renderIndex int
+
+	// Pattern mode
+	patternMode   bool
+	findInput     textinput.Model
+	replaceInput  textinput.Model
+	focusReplace  bool
+	caseTransform CaseTransform
+	// Error of the pattern, like an invalid regular expression
+	patternErr error
+	// Names of the items of dir, to find the new names that are taken
+	existing map[string]struct{}
+
+	// Dimensions
+	width     int
+	maxHeight int
+}
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strconv"
 
+	"github.com/yorukot/superfile/src/internal/archivefs"
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui"
 )
@@ -46,6 +47,11 @@ func (m *Model) Render() string {
 				// better use filepanel's Element strcut as-is
 				fileInfo, err := os.Lstat(m.items.items[i])
 				if err != nil {
+					if archivefs.IsVirtual(m.items.items[i]) {
+						// Entries of archives are shown as files, reading the archive is too slow here
+						r.AddLines(common.ClipboardPrettierName(m.items.items[i], viewWidth, false, false, false))
+						continue
+					}
 					slog.Error("Clipboard render function get item state ", "error", err)
 					continue
 				}
@@ -79,7 +85,8 @@ func (m *Model) SetItems(items []string) {
 func (m *Model) pruneInaccessibleItems() {
 	m.items.items = slices.DeleteFunc(m.items.items, func(item string) bool {
 		_, err := os.Lstat(item)
-		return err != nil
+		// Entries of archives are kept as long as the archive exists
+		return err != nil && !archivefs.IsVirtual(item)
 	})
 }
 
@@ -0,0 +1,6 @@
+package compressmodel
+
+const (
+	compressOptionsDefaultWidth  = 24
+	compressOptionsDefaultHeight = 5
+)
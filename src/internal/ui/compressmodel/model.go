@@ -0,0 +1,10 @@
+package compressmodel
+
+func New() Model {
+	return Model{
+		Height: compressOptionsDefaultHeight,
+		Width:  compressOptionsDefaultWidth,
+		Cursor: 0,
+		open:   false,
+	}
+}
@@ -0,0 +1,9 @@
+package compressmodel
+
+func (m *Model) ListUp() {
+	m.Cursor = (m.Cursor - 1 + len(FormatOptionsStr)) % len(FormatOptionsStr)
+}
+
+func (m *Model) ListDown() {
+	m.Cursor = (m.Cursor + 1 + len(FormatOptionsStr)) % len(FormatOptionsStr)
+}
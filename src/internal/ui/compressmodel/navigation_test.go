@@ -0,0 +1,30 @@
+package compressmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpDownModalCompress(t *testing.T) {
+	model := New()
+	model.Open(FormatTarGz)
+	assert.Equal(t, FormatTarGz, model.GetSelectedFormat())
+	model.ListDown()
+	assert.Equal(t, FormatTarXz, model.GetSelectedFormat())
+	model.ListDown()
+	model.ListDown()
+	assert.Equal(t, FormatZip, model.GetSelectedFormat(), "wraps to the top")
+	model.ListUp()
+	assert.Equal(t, FormatTarZst, model.GetSelectedFormat(), "wraps to the bottom")
+	model.Close()
+	assert.False(t, model.IsOpen())
+	assert.Equal(t, FormatZip, model.GetSelectedFormat())
+}
+
+func TestParseFormat(t *testing.T) {
+	assert.Equal(t, FormatTarZst, ParseFormat("tar.zst"))
+	assert.Equal(t, FormatZip, ParseFormat("zip"))
+	assert.Equal(t, FormatZip, ParseFormat("rar"))
+	assert.Equal(t, ".tar.xz", FormatTarXz.Extension())
+}
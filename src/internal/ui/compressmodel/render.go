@@ -0,0 +1,28 @@
+package compressmodel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func (m *Model) Render() string {
+	var content strings.Builder
+	content.WriteString(common.ModalTitleStyle.Render(" Compress as") + "\n\n")
+	for i, option := range FormatOptionsStr {
+		cursor := " "
+		if i == m.Cursor {
+			cursor = common.FilePanelCursorStyle.Render(icon.Cursor)
+		}
+		content.WriteString(cursor + common.ModalStyle.Render(" ."+option) + "\n")
+	}
+	bottomBorder := common.GenerateFooterBorder(
+		fmt.Sprintf("%s/%s", strconv.Itoa(m.Cursor+1),
+			strconv.Itoa(len(FormatOptionsStr))), m.Width-common.BorderPadding)
+
+	return common.SortOptionsModalBorderStyle(m.Height, m.Width,
+		bottomBorder).Render(content.String())
+}
@@ -0,0 +1,27 @@
+package compressmodel
+
+type Format int
+
+// NOTE: Update the validation of DefaultCompressFormat config if you make changes here
+const (
+	FormatZip Format = iota
+	FormatTar
+	FormatTarGz
+	FormatTarXz
+	FormatTarZst
+)
+
+// Names of the formats, as used in the config
+var FormatOptionsStr = []string{ //nolint: gochecknoglobals // Effectively const
+	"zip", "tar", "tar.gz", "tar.xz", "tar.zst",
+}
+
+// Compression format picker
+type Model struct {
+	Width  int
+	Height int
+	open   bool
+
+	// Cursor has meaning only during open state, its lost on close
+	Cursor int
+}
@@ -0,0 +1,40 @@
+package compressmodel
+
+import "slices"
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+func (m *Model) Open(defaultFormat Format) {
+	m.Cursor = int(defaultFormat)
+	m.open = true
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.Cursor = 0
+}
+
+func (m *Model) GetSelectedFormat() Format {
+	return Format(m.Cursor)
+}
+
+// ParseFormat returns the format with the given name, like "tar.gz".
+// Unknown names fall back to zip
+func ParseFormat(name string) Format {
+	idx := slices.Index(FormatOptionsStr, name)
+	if idx < 0 {
+		return FormatZip
+	}
+	return Format(idx)
+}
+
+func (f Format) String() string {
+	return FormatOptionsStr[f]
+}
+
+// Extension of the archives of this format, with the leading dot
+func (f Format) Extension() string {
+	return "." + f.String()
+}
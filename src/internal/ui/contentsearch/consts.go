@@ -0,0 +1,32 @@
+package contentsearch
+
+import "time"
+
+const (
+	headlineText = "Search in files"
+
+	ContentSearchMinWidth  = 30
+	ContentSearchMinHeight = 8
+
+	// renderOverhead is the number of lines needed for UI chrome
+	// (borders + input line + sections + status line + key hints)
+	renderOverhead = 7
+
+	// searchDelay is how long typing must pause before a search starts, so
+	// that a search is not started for each typed character
+	searchDelay = 250 * time.Millisecond
+
+	// Size of the beginning of a file that is checked for NUL bytes. Like
+	// git, files with one are binary
+	binaryCheckSize = 8000
+	// Lines longer than this are not searched, and neither is the rest of
+	// their file. They are mostly minified or generated content
+	maxLineSize = 1024 * 1024
+	// Snippets longer than this are cut, the modal can't show more anyway
+	maxSnippetLength = 256
+	// The search stops once it found that many matches
+	maxMatches = 1000
+	// Matches are sent to the modal once that many are found, or once the
+	// search is done
+	matchBatchSize = 50
+)
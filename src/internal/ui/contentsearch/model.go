@@ -0,0 +1,190 @@
+package contentsearch
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func New(maxHeight int, width int) Model {
+	m := Model{
+		headline:  icon.Search + icon.Space + headlineText,
+		textInput: common.GeneratePromptTextInput(),
+	}
+	m.SetMaxHeight(maxHeight)
+	m.SetWidth(width)
+	return m
+}
+
+// KeyOpenInEditor opens the file of the selected match in the editor, at its line
+func KeyOpenInEditor() []string {
+	return common.Hotkeys.ContentSearchOpenInEditor
+}
+
+// HandleUpdate handles the keys and the updates of the text input
+// while the modal is open
+func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
+	var action common.ModelAction = common.NoAction{}
+	var cmd tea.Cmd
+	if !m.IsOpen() {
+		slog.Error("HandleUpdate called on closed content search")
+		return action, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case slices.Contains(common.Hotkeys.ConfirmTyping, msg.String()):
+			if match, ok := m.GetSelected(); ok {
+				action = common.ShowFileAction{Path: match.Path}
+			}
+			m.Close()
+		case slices.Contains(KeyOpenInEditor(), msg.String()):
+			if match, ok := m.GetSelected(); ok {
+				action = common.EditFileAction{Path: match.Path, Line: match.Line}
+				m.Close()
+			}
+		case slices.Contains(common.Hotkeys.CancelTyping, msg.String()):
+			m.Close()
+		// Letters like `j` and `k` are typed, as the modal is in text input mode
+		case slices.Contains(common.Hotkeys.ListUp, msg.String()) && !isKeyAlphaNum(msg):
+			m.navigateUp()
+		case slices.Contains(common.Hotkeys.ListDown, msg.String()) && !isKeyAlphaNum(msg):
+			m.navigateDown()
+		case slices.Contains(common.Hotkeys.OpenContentSearch, msg.String()) && m.justOpened:
+			// Ignore the key that just opened this modal to prevent it from appearing in text input
+			m.justOpened = false
+		default:
+			cmd = m.handleNormalKeyInput(msg)
+		}
+	default:
+		// Non keypress updates like Cursor Blink
+		m.textInput, cmd = m.textInput.Update(msg)
+	}
+	return action, cmd
+}
+
+func (m *Model) handleNormalKeyInput(msg tea.KeyPressMsg) tea.Cmd {
+	m.justOpened = false
+	query := m.textInput.Value()
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() == query {
+		return cmd
+	}
+	m.stopSearch()
+	if m.textInput.Value() == "" {
+		return cmd
+	}
+	// Already shown as searching while waiting for the pause
+	m.searching = true
+	return tea.Batch(cmd, m.getStartCmd(m.textInput.Value()))
+}
+
+// getStartCmd starts the search of the query once typing paused
+func (m *Model) getStartCmd(query string) tea.Cmd {
+	reqID := m.nextReqID()
+	return tea.Tick(searchDelay, func(time.Time) tea.Msg {
+		return startMsg{query: query, reqID: reqID}
+	})
+}
+
+func (m *Model) nextReqID() int {
+	reqID := m.reqCnt
+	m.reqCnt++
+	return reqID
+}
+
+// startSearch runs the search of the query in the background, and returns
+// the Cmd waiting for its first results
+func (m *Model) startSearch(query string) tea.Cmd {
+	m.stopSearch()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.searchID++
+	m.cancel = cancel
+	m.searching = true
+	m.query = query
+
+	results := make(chan matchBatch)
+	slog.Debug("Starting content search", "root", m.root, "query", query, "id", m.searchID)
+	go search(ctx, m.root, query, m.displayDotFile, results)
+	return m.listen(results)
+}
+
+// listen waits for the next batch of matches of the running search
+func (m *Model) listen(results <-chan matchBatch) tea.Cmd {
+	searchID := m.searchID
+	reqID := m.nextReqID()
+	return func() tea.Msg {
+		batch, ok := <-results
+		if !ok {
+			// Cancelled, nothing more is coming
+			return nil
+		}
+		return resultsMsg{searchID: searchID, batch: batch, results: results, reqID: reqID}
+	}
+}
+
+// stopSearch cancels the running search, if any, and drops the results
+func (m *Model) stopSearch() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	// Batches still on their way are ignored
+	m.searchID++
+	m.query = ""
+	m.searching = false
+	m.truncated = false
+	m.results = nil
+	m.cursor = 0
+	m.renderIndex = 0
+}
+
+func (msg startMsg) GetReqID() int {
+	return msg.reqID
+}
+
+// Apply starts the search, unless the query changed in the meantime
+func (msg startMsg) Apply(m *Model) tea.Cmd {
+	if !m.IsOpen() || msg.query != m.textInput.Value() || msg.query == m.query {
+		slog.Debug("Ignoring stale content search", "query", msg.query, "id", msg.reqID)
+		return nil
+	}
+	return m.startSearch(msg.query)
+}
+
+func (msg resultsMsg) GetReqID() int {
+	return msg.reqID
+}
+
+// Apply adds the matches to the results, and waits for the next ones
+func (msg resultsMsg) Apply(m *Model) tea.Cmd {
+	if msg.searchID != m.searchID {
+		return nil
+	}
+	m.results = append(m.results, msg.batch.matches...)
+	if msg.batch.done {
+		m.searching = false
+		m.truncated = msg.batch.truncated
+		m.cancel()
+		m.cancel = nil
+		return nil
+	}
+	return m.listen(msg.results)
+}
+
+func isKeyAlphaNum(msg tea.KeyPressMsg) bool {
+	r := []rune(msg.String())
+	if len(r) != 1 {
+		return false
+	}
+	return unicode.IsLetter(r[0]) || unicode.IsNumber(r[0])
+}
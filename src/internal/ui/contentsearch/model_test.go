@@ -0,0 +1,152 @@
+package contentsearch
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMain(m *testing.M) {
+	common.Hotkeys.ConfirmTyping = []string{"enter"}
+	common.Hotkeys.CancelTyping = []string{"esc"}
+	common.Hotkeys.ListUp = []string{"up", "k"}
+	common.Hotkeys.ListDown = []string{"down", "j"}
+	common.Hotkeys.OpenContentSearch = []string{"G"}
+	common.Hotkeys.ContentSearchOpenInEditor = []string{"ctrl+o"}
+	m.Run()
+}
+
+func keyPress(key string) tea.KeyPressMsg {
+	switch key {
+	case "enter":
+		return tea.KeyPressMsg{Code: tea.KeyEnter}
+	case "esc":
+		return tea.KeyPressMsg{Code: tea.KeyEscape}
+	case "down":
+		return tea.KeyPressMsg{Code: tea.KeyDown}
+	case "ctrl+o":
+		return tea.KeyPressMsg{Code: 'o', Mod: tea.ModCtrl}
+	}
+	r := []rune(key)[0]
+	return tea.KeyPressMsg{Code: r, Text: key}
+}
+
+func setupTestModelWithResults(resultCount int) Model {
+	m := New(ContentSearchMinHeight+5, 80) //nolint:mnd // test dimensions
+	m.Open("/root", false)
+	m.justOpened = false
+	for i := range resultCount {
+		m.results = append(m.results, Match{Path: filepath.Join("/root", "file"), Line: i + 1, Text: "text"})
+	}
+	return m
+}
+
+func TestOpenIgnoresOpeningKey(t *testing.T) {
+	m := New(ContentSearchMinHeight, ContentSearchMinWidth)
+	m.Open("/root", false)
+	m.HandleUpdate(keyPress("G"))
+	assert.Empty(t, m.GetTextInputValue())
+	m.HandleUpdate(keyPress("G"))
+	assert.Equal(t, "G", m.GetTextInputValue())
+}
+
+func TestTypingStartsDelayedSearch(t *testing.T) {
+	m := setupTestModelWithResults(0)
+	_, cmd := m.HandleUpdate(keyPress("j"))
+	assert.Equal(t, "j", m.GetTextInputValue(), "letters are typed, not used to navigate")
+	assert.True(t, m.IsSearching())
+	require.NotNil(t, cmd)
+
+	// A start for an older query is ignored
+	assert.Nil(t, startMsg{query: "old"}.Apply(&m))
+	assert.Empty(t, m.query)
+}
+
+func TestResults(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupFilesWithData(t, []byte("one needle\ntwo needle\n"), filepath.Join(root, "a.txt"))
+	m := New(ContentSearchMinHeight, ContentSearchMinWidth)
+	m.Open(root, false)
+	m.textInput.SetValue("needle")
+
+	cmd := startMsg{query: "needle"}.Apply(&m)
+	for cmd != nil {
+		msg, ok := cmd().(resultsMsg)
+		require.True(t, ok)
+		cmd = msg.Apply(&m)
+	}
+	assert.False(t, m.IsSearching())
+	assert.Len(t, m.GetResults(), 2)
+	assert.Nil(t, m.cancel)
+
+	// The next query drops the results
+	m.HandleUpdate(keyPress("x"))
+	assert.Empty(t, m.GetResults())
+}
+
+func TestStaleResultsIgnored(t *testing.T) {
+	m := setupTestModelWithResults(0)
+	msg := resultsMsg{searchID: m.searchID, batch: matchBatch{matches: []Match{{Path: "/root/a", Line: 1}}}}
+	m.stopSearch()
+	assert.Nil(t, msg.Apply(&m))
+	assert.Empty(t, m.GetResults())
+}
+
+func TestActions(t *testing.T) {
+	t.Run("Enter shows the file", func(t *testing.T) {
+		m := setupTestModelWithResults(3)
+		m.HandleUpdate(keyPress("down"))
+		action, _ := m.HandleUpdate(keyPress("enter"))
+		assert.Equal(t, common.ShowFileAction{Path: filepath.Join("/root", "file")}, action)
+		assert.False(t, m.IsOpen())
+	})
+	t.Run("Ctrl+o edits at the line", func(t *testing.T) {
+		m := setupTestModelWithResults(3)
+		m.HandleUpdate(keyPress("down"))
+		action, _ := m.HandleUpdate(keyPress("ctrl+o"))
+		assert.Equal(t, common.EditFileAction{Path: filepath.Join("/root", "file"), Line: 2}, action)
+		assert.False(t, m.IsOpen())
+	})
+	t.Run("Ctrl+o without results keeps the modal", func(t *testing.T) {
+		m := setupTestModelWithResults(0)
+		action, _ := m.HandleUpdate(keyPress("ctrl+o"))
+		assert.Equal(t, common.NoAction{}, action)
+		assert.True(t, m.IsOpen())
+	})
+	t.Run("Esc closes", func(t *testing.T) {
+		m := setupTestModelWithResults(3)
+		action, _ := m.HandleUpdate(keyPress("esc"))
+		assert.Equal(t, common.NoAction{}, action)
+		assert.False(t, m.IsOpen())
+		assert.Empty(t, m.GetResults())
+	})
+}
+
+func TestNavigation(t *testing.T) {
+	m := setupTestModelWithResults(20)
+	visible := m.visibleCount()
+	for range visible {
+		m.navigateDown()
+	}
+	assert.Equal(t, visible, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+	m.navigateUp()
+	m.navigateUp()
+	assert.Equal(t, visible-2, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+
+	m.cursor = 0
+	m.renderIndex = 0
+	m.navigateUp()
+	assert.Equal(t, 19, m.cursor, "wraps to the bottom")
+	assert.Equal(t, 20-visible, m.renderIndex)
+	m.navigateDown()
+	assert.Equal(t, 0, m.cursor, "wraps to the top")
+	assert.Equal(t, 0, m.renderIndex)
+}
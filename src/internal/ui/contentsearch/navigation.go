@@ -0,0 +1,36 @@
+package contentsearch
+
+func (m *Model) navigateUp() {
+	if len(m.results) == 0 {
+		return
+	}
+	if m.cursor > 0 {
+		m.cursor--
+	} else {
+		m.cursor = len(m.results) - 1 // Wrap to bottom
+	}
+	m.updateRenderIndex()
+}
+
+func (m *Model) navigateDown() {
+	if len(m.results) == 0 {
+		return
+	}
+	if m.cursor < len(m.results)-1 {
+		m.cursor++
+	} else {
+		m.cursor = 0 // Wrap to top
+	}
+	m.updateRenderIndex()
+}
+
+// updateRenderIndex keeps the cursor in the visible range
+func (m *Model) updateRenderIndex() {
+	if m.cursor < m.renderIndex {
+		m.renderIndex = m.cursor
+	}
+	if m.cursor >= m.renderIndex+m.visibleCount() {
+		m.renderIndex = m.cursor - m.visibleCount() + 1
+	}
+	m.renderIndex = max(m.renderIndex, 0)
+}
@@ -0,0 +1,73 @@
+package contentsearch
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+)
+
+func (m *Model) Render() string {
+	r := ui.ContentSearchRenderer(m.maxHeight, m.width)
+	r.SetBorderTitle(m.headline)
+	if len(m.results) > 0 {
+		r.SetBorderInfoItems(fmt.Sprintf("%d/%d", m.cursor+1, len(m.results)))
+	}
+
+	r.AddLines(" " + m.textInput.View())
+	r.AddSection()
+
+	endIndex := min(m.renderIndex+m.visibleCount(), len(m.results))
+	// Available width: modal width - borders(2) - padding(1)
+	lineWidth := m.width - 3 //nolint:mnd // borders and padding
+	for i := m.renderIndex; i < endIndex; i++ {
+		line := " " + common.TruncateText(m.formatMatch(m.results[i]), lineWidth, "...")
+		if i == m.cursor {
+			line = common.ModalCursorStyle.Render(line)
+		}
+		r.AddLines(line)
+	}
+	// Keep the status and key hints at the bottom of the modal
+	for range m.visibleCount() - (endIndex - m.renderIndex) {
+		r.AddLines("")
+	}
+
+	r.AddSection()
+	r.AddLines(" "+m.status(), keyHint(common.Hotkeys.ConfirmTyping, "Show")+
+		keyHint(KeyOpenInEditor(), "Edit")+keyHint(common.Hotkeys.CancelTyping, "Close"))
+	return r.Render()
+}
+
+// formatMatch returns the match as path:line: snippet, the path being relative
+// to the searched directory
+func (m *Model) formatMatch(match Match) string {
+	path, err := filepath.Rel(m.root, match.Path)
+	if err != nil {
+		path = match.Path
+	}
+	return fmt.Sprintf("%s:%d: %s", path, match.Line, match.Text)
+}
+
+func (m *Model) status() string {
+	switch {
+	case m.textInput.Value() == "":
+		return "Type to search the files below " + filepath.Base(m.root)
+	case m.searching:
+		return fmt.Sprintf("Searching... %d matches", len(m.results))
+	case m.truncated:
+		return fmt.Sprintf("Stopped at %d matches", len(m.results))
+	case len(m.results) == 0:
+		return "No matches"
+	default:
+		return fmt.Sprintf("%d matches", len(m.results))
+	}
+}
+
+func keyHint(keys []string, text string) string {
+	key := ""
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	return " (" + key + ") " + text
+}
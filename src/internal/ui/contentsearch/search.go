@@ -0,0 +1,138 @@
+package contentsearch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lineMatcher tells whether a line contains the query. The case is ignored,
+// unless the query has an uppercase letter
+type lineMatcher struct {
+	query      []byte
+	ignoreCase bool
+}
+
+func newLineMatcher(query string) lineMatcher {
+	lowerQuery := strings.ToLower(query)
+	return lineMatcher{query: []byte(query), ignoreCase: lowerQuery == query}
+}
+
+func (l lineMatcher) match(line []byte) bool {
+	if l.ignoreCase {
+		return bytes.Contains(bytes.ToLower(line), l.query)
+	}
+	return bytes.Contains(line, l.query)
+}
+
+// search walks the files below root and sends the lines containing the
+// query to results, in batches. The channel is closed once the search is done,
+// or cancelled. Binary files are skipped, and so are the dotfiles unless
+// displayDotFile is set. Symlinks are not followed
+func search(ctx context.Context, root string, query string, displayDotFile bool, results chan<- matchBatch) {
+	defer close(results)
+	matcher := newLineMatcher(query)
+	var pending []Match
+	found := 0
+	send := func(batch matchBatch) bool {
+		select {
+		case results <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// Unreadable directories are skipped
+			slog.Debug("Cannot read during content search", "path", path, "error", err)
+			return nil
+		}
+		if path != root && !displayDotFile && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		matches, err := searchFile(ctx, path, matcher, maxMatches-found)
+		if err != nil {
+			slog.Debug("Cannot search file content", "path", path, "error", err)
+		}
+		pending = append(pending, matches...)
+		found += len(matches)
+		if found >= maxMatches {
+			send(matchBatch{matches: pending, done: true, truncated: true})
+			return filepath.SkipAll
+		}
+		if len(pending) >= matchBatchSize {
+			if !send(matchBatch{matches: pending}) {
+				return ctx.Err()
+			}
+			pending = nil
+		}
+		return nil
+	})
+	if err != nil || found >= maxMatches || ctx.Err() != nil {
+		// Cancelled, or the last batch is already sent
+		return
+	}
+	send(matchBatch{matches: pending, done: true})
+}
+
+// searchFile returns the lines of the file at path that match, at most limit
+// of them. Binary files have none
+func searchFile(ctx context.Context, path string, matcher lineMatcher, limit int) ([]Match, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	// A shorter file is fully read, and gets an io.EOF
+	head, _ := reader.Peek(binaryCheckSize)
+	if bytes.IndexByte(head, 0) != -1 {
+		return nil, nil
+	}
+
+	var matches []Match
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan() && len(matches) < limit; line++ {
+		if matcher.match(scanner.Bytes()) {
+			matches = append(matches, Match{Path: path, Line: line, Text: snippet(scanner.Text())})
+		}
+		// Big files would delay the cancellation
+		if line%1000 == 0 && ctx.Err() != nil {
+			return matches, ctx.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return matches, err
+	}
+	return matches, nil
+}
+
+// snippet returns the line as it can be shown in the modal
+func snippet(line string) string {
+	line = strings.TrimSpace(strings.ToValidUTF8(line, "?"))
+	line = strings.ReplaceAll(line, "\t", " ")
+	if runes := []rune(line); len(runes) > maxSnippetLength {
+		line = string(runes[:maxSnippetLength])
+	}
+	return line
+}
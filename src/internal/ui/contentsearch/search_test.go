@@ -0,0 +1,112 @@
+package contentsearch
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// runSearch runs the search to completion and returns all the batches
+func runSearch(ctx context.Context, root string, query string, displayDotFile bool) []matchBatch {
+	results := make(chan matchBatch)
+	go search(ctx, root, query, displayDotFile, results)
+	var batches []matchBatch
+	for batch := range results {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func allMatches(batches []matchBatch) []Match {
+	var matches []Match
+	for _, batch := range batches {
+		matches = append(matches, batch.matches...)
+	}
+	return matches
+}
+
+func TestSearch(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(root, "sub"), filepath.Join(root, ".hidden"))
+	utils.SetupFilesWithData(t, []byte("first line\nthe Needle here\nnothing\nneedle again\n"),
+		filepath.Join(root, "a.txt"))
+	utils.SetupFilesWithData(t, []byte("\tdeep needle\t\n"), filepath.Join(root, "sub", "b.go"))
+	utils.SetupFilesWithData(t, []byte("needle\x00binary"), filepath.Join(root, "c.bin"))
+	utils.SetupFilesWithData(t, []byte("needle in dotfile"), filepath.Join(root, ".env"))
+	utils.SetupFilesWithData(t, []byte("needle in hidden dir"), filepath.Join(root, ".hidden", "d.txt"))
+
+	t.Run("Smart case and binary files", func(t *testing.T) {
+		batches := runSearch(t.Context(), root, "needle", false)
+		require.NotEmpty(t, batches)
+		last := batches[len(batches)-1]
+		assert.True(t, last.done)
+		assert.False(t, last.truncated)
+		assert.Equal(t, []Match{
+			{Path: filepath.Join(root, "a.txt"), Line: 2, Text: "the Needle here"},
+			{Path: filepath.Join(root, "a.txt"), Line: 4, Text: "needle again"},
+			{Path: filepath.Join(root, "sub", "b.go"), Line: 1, Text: "deep needle"},
+		}, allMatches(batches))
+	})
+
+	t.Run("Uppercase query is case sensitive", func(t *testing.T) {
+		matches := allMatches(runSearch(t.Context(), root, "Needle", false))
+		require.Len(t, matches, 1)
+		assert.Equal(t, 2, matches[0].Line)
+	})
+
+	t.Run("Dotfiles", func(t *testing.T) {
+		matches := allMatches(runSearch(t.Context(), root, "needle in", true))
+		paths := make([]string, 0, len(matches))
+		for _, match := range matches {
+			paths = append(paths, match.Path)
+		}
+		assert.ElementsMatch(t, []string{
+			filepath.Join(root, ".env"),
+			filepath.Join(root, ".hidden", "d.txt"),
+		}, paths)
+	})
+}
+
+func TestSearchLimit(t *testing.T) {
+	root := t.TempDir()
+	content := strings.Repeat("match\n", maxMatches+10)
+	utils.SetupFilesWithData(t, []byte(content), filepath.Join(root, "a.txt"), filepath.Join(root, "b.txt"))
+
+	batches := runSearch(t.Context(), root, "match", false)
+	require.NotEmpty(t, batches)
+	last := batches[len(batches)-1]
+	assert.True(t, last.done)
+	assert.True(t, last.truncated)
+	assert.Len(t, allMatches(batches), maxMatches)
+}
+
+func TestSearchCancel(t *testing.T) {
+	root := t.TempDir()
+	for i := range matchBatchSize * 3 {
+		utils.SetupFilesWithData(t, []byte("match"), filepath.Join(root, "file"+strings.Repeat("x", i)))
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	results := make(chan matchBatch)
+	go search(ctx, root, "match", false, results)
+	first := <-results
+	assert.False(t, first.done)
+	cancel()
+
+	// The search stops without waiting for the batches to be received
+	for batch := range results {
+		assert.False(t, batch.done, "a cancelled search is never done")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "a b", snippet("\t a\tb  "))
+	assert.Equal(t, "a?b", snippet("a\xffb"))
+	assert.Len(t, []rune(snippet(strings.Repeat("é", maxSnippetLength+5))), maxSnippetLength)
+}
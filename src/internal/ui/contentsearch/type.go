@@ -0,0 +1,74 @@
+package contentsearch
+
+import (
+	"context"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// Model of the modal that searches the content of the files below a directory
+type Model struct {
+	headline string
+
+	// State
+	open       bool
+	justOpened bool // Flag to ignore the opening keystroke
+	textInput  textinput.Model
+	// Directory that is searched
+	root           string
+	displayDotFile bool
+	results        []Match
+	cursor         int
+	renderIndex    int
+
+	// Running search, if any. Results of other searches are ignored
+	searchID int
+	// Query of the running search
+	query     string
+	cancel    context.CancelFunc
+	searching bool
+	// The search stopped at maxMatches
+	truncated bool
+
+	width     int
+	maxHeight int
+
+	reqCnt int
+}
+
+// Match is a line of a file that contains the query
+type Match struct {
+	Path string
+	// 1-based
+	Line int
+	// The line, trimmed
+	Text string
+}
+
+// UpdateMsg is implemented by the async messages of the modal
+type UpdateMsg interface {
+	Apply(m *Model) tea.Cmd
+	GetReqID() int
+}
+
+// startMsg is sent once typing paused, to start a search of the query
+type startMsg struct {
+	query string
+	reqID int
+}
+
+// resultsMsg carries a batch of matches of the search searchID
+type resultsMsg struct {
+	searchID int
+	batch    matchBatch
+	results  <-chan matchBatch
+	reqID    int
+}
+
+// matchBatch is what the search sends to the modal. done is set on the last one
+type matchBatch struct {
+	matches   []Match
+	done      bool
+	truncated bool
+}
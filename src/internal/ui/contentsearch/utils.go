@@ -0,0 +1,80 @@
+package contentsearch
+
+import (
+	"log/slog"
+)
+
+// Open opens the modal to search the files below root
+func (m *Model) Open(root string, displayDotFile bool) {
+	m.open = true
+	m.justOpened = true
+	m.root = root
+	m.displayDotFile = displayDotFile
+	m.textInput.SetValue("")
+	_ = m.textInput.Focus()
+}
+
+func (m *Model) Close() {
+	m.stopSearch()
+	m.open = false
+	m.textInput.Blur()
+	m.textInput.SetValue("")
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+func (m *Model) IsSearching() bool {
+	return m.searching
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetMaxHeight() int {
+	return m.maxHeight
+}
+
+func (m *Model) SetWidth(width int) {
+	if width < ContentSearchMinWidth {
+		slog.Warn("Content search initialized with too less width", "width", width)
+		width = ContentSearchMinWidth
+	}
+	m.width = width
+	// Excluding borders(2), SpacePadding(1), and one extra character that is appended
+	// by textInput.View()
+	m.textInput.SetWidth(width - 4) //nolint:mnd // borders, padding and cursor
+}
+
+func (m *Model) SetMaxHeight(maxHeight int) {
+	if maxHeight < ContentSearchMinHeight {
+		slog.Warn("Content search initialized with too less maxHeight", "maxHeight", maxHeight)
+		maxHeight = ContentSearchMinHeight
+	}
+	m.maxHeight = maxHeight
+	m.updateRenderIndex()
+}
+
+func (m *Model) GetResults() []Match {
+	out := make([]Match, len(m.results))
+	copy(out, m.results)
+	return out
+}
+
+// GetSelected returns the match under the cursor, if any
+func (m *Model) GetSelected() (Match, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.results) {
+		return Match{}, false
+	}
+	return m.results[m.cursor], true
+}
+
+func (m *Model) GetTextInputValue() string {
+	return m.textInput.Value()
+}
+
+func (m *Model) visibleCount() int {
+	return m.maxHeight - renderOverhead
+}
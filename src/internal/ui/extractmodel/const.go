@@ -0,0 +1,6 @@
+package extractmodel
+
+const (
+	extractOptionsDefaultWidth  = 32
+	extractOptionsDefaultHeight = 5
+)
@@ -0,0 +1,10 @@
+package extractmodel
+
+func New() Model {
+	return Model{
+		Height: extractOptionsDefaultHeight,
+		Width:  extractOptionsDefaultWidth,
+		Cursor: 0,
+		open:   false,
+	}
+}
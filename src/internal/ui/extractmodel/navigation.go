@@ -0,0 +1,9 @@
+package extractmodel
+
+func (m *Model) ListUp() {
+	m.Cursor = (m.Cursor - 1 + len(m.targets)) % len(m.targets)
+}
+
+func (m *Model) ListDown() {
+	m.Cursor = (m.Cursor + 1 + len(m.targets)) % len(m.targets)
+}
@@ -0,0 +1,24 @@
+package extractmodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpDownModalExtract(t *testing.T) {
+	model := New()
+	model.Open("archive", true)
+	assert.Equal(t, TargetNewDir, model.GetSelectedTarget())
+	model.ListDown()
+	assert.Equal(t, TargetOtherPanel, model.GetSelectedTarget())
+	model.ListDown()
+	assert.Equal(t, TargetHere, model.GetSelectedTarget(), "wraps to the top")
+	model.Close()
+	assert.False(t, model.IsOpen())
+
+	model.Open("archive", false)
+	model.ListUp()
+	model.ListUp()
+	assert.Equal(t, TargetNewDir, model.GetSelectedTarget(), "the other panel is not offered")
+}
@@ -0,0 +1,31 @@
+package extractmodel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func (m *Model) Render() string {
+	var content strings.Builder
+	content.WriteString(common.ModalTitleStyle.Render(" Extract to") + "\n\n")
+	// Cursor, and spaces around the label
+	labelWidth := m.Width - common.BorderPadding - 3
+	for i, target := range m.targets {
+		cursor := " "
+		if i == m.Cursor {
+			cursor = common.FilePanelCursorStyle.Render(icon.Cursor)
+		}
+		label := common.TruncateText(m.targetLabel(target), labelWidth, "...")
+		content.WriteString(cursor + common.ModalStyle.Render(" "+label) + "\n")
+	}
+	bottomBorder := common.GenerateFooterBorder(
+		fmt.Sprintf("%s/%s", strconv.Itoa(m.Cursor+1),
+			strconv.Itoa(len(m.targets))), m.Width-common.BorderPadding)
+
+	return common.SortOptionsModalBorderStyle(m.Height, m.Width,
+		bottomBorder).Render(content.String())
+}
@@ -0,0 +1,27 @@
+package extractmodel
+
+// Target is where the entries of the archive are extracted
+type Target int
+
+const (
+	// Next to the archive
+	TargetHere Target = iota
+	// In a new directory named after the archive, next to it
+	TargetNewDir
+	// In the directory of the other file panel
+	TargetOtherPanel
+)
+
+// Extraction target picker
+type Model struct {
+	Width  int
+	Height int
+	open   bool
+	// Name of the directory of TargetNewDir
+	dirName string
+	// The other panel is only offered when there is one that can be written to
+	targets []Target
+
+	// Cursor has meaning only during open state, its lost on close
+	Cursor int
+}
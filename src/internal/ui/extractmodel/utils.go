@@ -0,0 +1,38 @@
+package extractmodel
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// Open shows the targets, with the new directory dirName preselected, as
+// extracting there never conflicts with existing items
+func (m *Model) Open(dirName string, hasOtherPanel bool) {
+	m.dirName = dirName
+	m.targets = []Target{TargetHere, TargetNewDir}
+	if hasOtherPanel {
+		m.targets = append(m.targets, TargetOtherPanel)
+	}
+	m.Cursor = int(TargetNewDir)
+	m.open = true
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.Cursor = 0
+}
+
+func (m *Model) GetSelectedTarget() Target {
+	return m.targets[m.Cursor]
+}
+
+func (m *Model) targetLabel(target Target) string {
+	switch target {
+	case TargetHere:
+		return "Here"
+	case TargetNewDir:
+		return m.dirName + "/"
+	case TargetOtherPanel:
+		return "Other panel"
+	}
+	return ""
+}
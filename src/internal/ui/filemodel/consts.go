@@ -14,8 +14,14 @@ const (
 	FileModelMinWidth       = filepanel.MinWidth
 	FilePreviewResizingText = "Resizing..."
 	FilePreviewLoadingText  = "Loading..."
+
+	// Names longer than this are truncated in the tab strip
+	TabNameMaxWidth = 20
+	TabStripHeight  = 1
 )
 
 var ErrMaximumPanelCount = errors.New("maximum panel count reached")
 
 var ErrMinimumPanelCount = errors.New("minimum panel count reached")
+
+var ErrMinimumTabCount = errors.New("cannot close the only tab")
@@ -38,6 +38,7 @@ func (m *Model) updateChildComponentHeight() {
 	for i := range m.FilePanels {
 		m.FilePanels[i].SetHeight(m.Height)
 	}
+	m.parentColumn.SetHeight(m.Height)
 }
 
 func (m *Model) updateChildComponentWidth() {
@@ -47,18 +48,30 @@ func (m *Model) updateChildComponentWidth() {
 		return
 	}
 	panelCount := len(m.FilePanels)
+	// The parent column of the miller columns takes the width of a panel
+	columnCount := panelCount
+	if m.MillerColumns {
+		columnCount++
+	}
 	widthForPanels := m.Width
 
 	if m.FilePreview.IsOpen() {
 		// Need to give some width to preview
 		if common.Config.FilePreviewWidth == 0 {
-			// FileModel will be split among `panelCount+1`
-			m.ExpectedPreviewWidth = m.Width / (panelCount + 1)
+			// FileModel will be split among `columnCount+1`
+			m.ExpectedPreviewWidth = m.Width / (columnCount + 1)
 		} else {
 			m.ExpectedPreviewWidth = m.Width / common.Config.FilePreviewWidth
 		}
 		widthForPanels -= m.ExpectedPreviewWidth
 	}
+	m.MaxFilePanel = widthForPanels / filepanel.MinWidth
+	if m.MillerColumns {
+		parentColumnWidth := widthForPanels / columnCount
+		m.parentColumn.SetWidth(parentColumnWidth)
+		widthForPanels -= parentColumnWidth
+		m.MaxFilePanel = max(m.MaxFilePanel-1, 1)
+	}
 
 	panelWidth := widthForPanels / panelCount
 	lastPanelWidth := widthForPanels - (panelCount-1)*panelWidth
@@ -72,7 +85,6 @@ func (m *Model) updateChildComponentWidth() {
 	}
 
 	m.SinglePanelWidth = panelWidth
-	m.MaxFilePanel = widthForPanels / filepanel.MinWidth
 	// Cap at the system maximum
 	if m.MaxFilePanel > common.FilePanelMax {
 		m.MaxFilePanel = common.FilePanelMax
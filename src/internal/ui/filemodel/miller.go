@@ -0,0 +1,124 @@
+package filemodel
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+)
+
+// In the miller columns layout, like in ranger and lf, the parent directory
+// of the focused panel is shown in a column on its left, with the cursor on
+// the directory of the focused panel. The preview on the right completes the
+// three columns. When the parent column is focused, moving its cursor on a
+// directory switches the focused panel to it.
+
+// GetParentColumn returns the column showing the parent directory of the
+// focused panel, or nil if it is not shown
+func (m *Model) GetParentColumn() *filepanel.Model {
+	if !m.MillerColumns {
+		return nil
+	}
+	return &m.parentColumn
+}
+
+// Panels returns the file panels, and the parent column if it is shown
+func (m *Model) Panels() []*filepanel.Model {
+	panels := make([]*filepanel.Model, 0, m.PanelCount()+1)
+	for i := range m.FilePanels {
+		panels = append(panels, &m.FilePanels[i])
+	}
+	if parent := m.GetParentColumn(); parent != nil && !m.parentColumnEmpty() {
+		panels = append(panels, parent)
+	}
+	return panels
+}
+
+// The focused panel is at the root, there is no parent to show
+func (m *Model) parentColumnEmpty() bool {
+	location := m.GetFocusedFilePanel().Location
+	return filepath.Dir(location) == location
+}
+
+func (m *Model) IsParentColumnFocused() bool {
+	return m.parentColumnFocused
+}
+
+// SetParentColumnFocused moves the focus between the parent column and the
+// focused panel. The parent column cannot be focused if it is not shown
+func (m *Model) SetParentColumnFocused(focused bool) {
+	if focused && (m.GetParentColumn() == nil || m.parentColumnEmpty()) {
+		return
+	}
+	if m.parentColumnFocused == focused {
+		return
+	}
+	m.parentColumnFocused = focused
+	m.parentColumn.IsFocused = focused
+	m.GetFocusedFilePanel().IsFocused = !focused
+}
+
+func (m *Model) ParentColumnListUp() {
+	m.parentColumn.ListUp()
+	m.followParentColumn()
+}
+
+func (m *Model) ParentColumnListDown() {
+	m.parentColumn.ListDown()
+	m.followParentColumn()
+}
+
+// followParentColumn switches the focused panel to the directory under the
+// cursor of the parent column. The files are skipped, the focused panel stays
+// on the last directory
+func (m *Model) followParentColumn() {
+	if m.parentColumn.EmptyOrInvalid() {
+		return
+	}
+	item := m.parentColumn.GetFocusedItem()
+	panel := m.GetFocusedFilePanel()
+	if !item.Directory || item.Location == panel.Location {
+		return
+	}
+	if err := panel.UpdateCurrentFilePanelDir(item.Location); err != nil {
+		slog.Error("Error while following the parent column", "location", item.Location, "error", err)
+		return
+	}
+	// Already in sync, the cursor of the parent column is kept as is
+	m.parentColumnOf = panel.Location
+	panel.UpdateElementsIfNeeded(true, m.DisplayDotFiles)
+}
+
+// syncParentColumn makes the parent column show the parent directory of the
+// focused panel, with the cursor on it
+func (m *Model) syncParentColumn(force bool) {
+	if !m.MillerColumns {
+		return
+	}
+	if m.parentColumnEmpty() {
+		m.SetParentColumnFocused(false)
+		return
+	}
+	location := m.GetFocusedFilePanel().Location
+	if location != m.parentColumnOf {
+		if err := m.parentColumn.UpdateCurrentFilePanelDir(filepath.Dir(location)); err != nil {
+			slog.Error("Error while updating the parent column", "location", location, "error", err)
+			return
+		}
+		m.parentColumn.TargetFile = filepath.Base(location)
+		m.parentColumnOf = location
+		force = true
+	}
+	m.parentColumn.UpdateElementsIfNeeded(force, m.DisplayDotFiles)
+}
+
+func (m *Model) renderParentColumn() string {
+	if m.parentColumnEmpty() {
+		r := ui.FilePanelRenderer(m.Height, m.parentColumn.GetWidth(), false)
+		r.AddLines(common.FilePanelNoneText)
+		return r.Render()
+	}
+	return m.parentColumn.Render(m.parentColumn.IsFocused)
+}
@@ -15,6 +15,7 @@ func (m *Model) MoveFocusedPanelBy(delta int) {
 		slog.Error("Unexpected error: fileModel with 0 panels")
 		return
 	}
+	m.SetParentColumnFocused(false)
 	m.GetFocusedFilePanel().IsFocused = false
 	m.FocusedPanelIndex = (m.FocusedPanelIndex + delta + m.PanelCount()) % m.PanelCount()
 	m.FilePanels[m.FocusedPanelIndex].IsFocused = true
@@ -1,13 +1,24 @@
 package filemodel
 
-import "charm.land/lipgloss/v2"
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
 
 func (m *Model) Render() string {
-	f := make([]string, m.PanelCount()+1)
+	f := make([]string, 0, m.PanelCount()+2) //nolint:mnd // panels, parent column and preview
 	for i, filePanel := range m.FilePanels {
-		f[i] = filePanel.Render(filePanel.IsFocused)
+		if m.MillerColumns && i == m.FocusedPanelIndex {
+			f = append(f, m.renderParentColumn())
+		}
+		f = append(f, filePanel.Render(filePanel.IsFocused))
 	}
-	f[m.PanelCount()] = m.GetFilePreviewRender()
+	f = append(f, m.GetFilePreviewRender())
 	return lipgloss.JoinHorizontal(lipgloss.Top, f...)
 }
 
@@ -28,3 +39,33 @@ func (m *Model) GetFilePreviewRender() string {
 	return m.FilePreview.RenderTextWithDimension(
 		FilePreviewResizingText, m.Height, m.ExpectedPreviewWidth)
 }
+
+// RenderTabStrip renders the line of the tabs, shown above the panels when
+// there is more than one tab. The tabs that don't fit are left out, the
+// active one is always shown
+func (m *Model) RenderTabStrip(width int) string {
+	if m.TabCount() <= 1 {
+		return ""
+	}
+	labels := make([]string, m.TabCount())
+	for i := range labels {
+		name := ansi.Truncate(m.TabName(i), TabNameMaxWidth, "…")
+		if i == m.activeTab && m.renamingTab {
+			name = m.tabRename.View()
+		}
+		style := common.TabStyle
+		if i == m.activeTab {
+			style = common.TabActiveStyle
+		}
+		labels[i] = style.Render(" " + strconv.Itoa(i+1) + " " + name + " ")
+	}
+
+	// Tabs left out at the start until the active one fits
+	first := 0
+	for first < m.activeTab && lipgloss.Width(strings.Join(labels[first:m.activeTab+1], "")) > width {
+		first++
+	}
+	strip := strings.Join(labels[first:], "")
+	strip = ansi.Truncate(strip, width, "")
+	return strip + common.TabStyle.Render(strings.Repeat(" ", max(width-lipgloss.Width(strip), 0)))
+}
@@ -0,0 +1,224 @@
+package filemodel
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+)
+
+// Each tab holds its own set of file panels. The panels of the active tab
+// live in FilePanels and FocusedPanelIndex, like without tabs, and the other
+// tabs keep theirs until they are switched to. The clipboard is shared by the
+// tabs, so items copied in a tab can be pasted in another one.
+
+// Tab is a set of file panels
+type Tab struct {
+	// Name given by the user. Empty to name the tab after the directory of
+	// its focused panel
+	Name              string
+	FilePanels        []filepanel.Model
+	FocusedPanelIndex int
+}
+
+func (m *Model) TabCount() int {
+	return max(len(m.tabs), 1)
+}
+
+func (m *Model) ActiveTab() int {
+	return m.activeTab
+}
+
+// TabName returns the name shown for the tab at index
+func (m *Model) TabName(index int) string {
+	if index < len(m.tabs) && m.tabs[index].Name != "" {
+		return m.tabs[index].Name
+	}
+	panels, focusedIndex := m.FilePanels, m.FocusedPanelIndex
+	if index != m.activeTab {
+		panels, focusedIndex = m.tabs[index].FilePanels, m.tabs[index].FocusedPanelIndex
+	}
+	location := panels[focusedIndex].Location
+	if base := filepath.Base(location); base != string(filepath.Separator) {
+		return base
+	}
+	return location
+}
+
+// Tabs returns all the tabs, the active one included
+func (m *Model) Tabs() []Tab {
+	tabs := make([]Tab, m.TabCount())
+	copy(tabs, m.tabs)
+	tabs[m.activeTab].FilePanels = m.FilePanels
+	tabs[m.activeTab].FocusedPanelIndex = m.FocusedPanelIndex
+	return tabs
+}
+
+// NewTab opens a tab with a panel at location, and switches to it
+func (m *Model) NewTab(location string) (tea.Cmd, error) {
+	if _, err := os.Stat(location); err != nil {
+		return nil, fmt.Errorf("cannot access location : %s", location)
+	}
+	focusedPanel := m.GetFocusedFilePanel()
+	panel := filepanel.New(location, true, "", focusedPanel.SortKind, focusedPanel.SortReversed)
+	if len(m.tabs) == 0 {
+		m.tabs = []Tab{{}}
+	}
+	m.tabs = append(m.tabs, Tab{FilePanels: []filepanel.Model{panel}})
+	return m.switchTab(len(m.tabs) - 1), nil
+}
+
+// CloseTab closes the active tab and its panels
+func (m *Model) CloseTab() (tea.Cmd, error) {
+	if m.TabCount() <= 1 {
+		return nil, ErrMinimumTabCount
+	}
+	m.CancelTabRename()
+	m.SetParentColumnFocused(false)
+	for i := range m.FilePanels {
+		m.FilePanels[i].CloseArchive()
+		m.FilePanels[i].StopRecursiveSearch()
+	}
+	closedIndex := m.activeTab
+	m.tabs = append(m.tabs[:closedIndex], m.tabs[closedIndex+1:]...)
+	m.activeTab = max(closedIndex-1, 0)
+	m.loadTab(m.activeTab)
+	return m.layoutTab(), nil
+}
+
+func (m *Model) NextTab() tea.Cmd {
+	return m.MoveActiveTabBy(1)
+}
+
+func (m *Model) PreviousTab() tea.Cmd {
+	return m.MoveActiveTabBy(-1)
+}
+
+func (m *Model) MoveActiveTabBy(delta int) tea.Cmd {
+	if m.TabCount() <= 1 {
+		return nil
+	}
+	return m.switchTab((m.activeTab + delta + m.TabCount()) % m.TabCount())
+}
+
+// SetTabs replaces all the tabs, like when a session is loaded. The panels
+// of a tab beyond the maximum count are left out
+func (m *Model) SetTabs(tabs []Tab, activeIndex int) tea.Cmd {
+	if len(tabs) == 0 {
+		slog.Error("Unexpected error: SetTabs with 0 tabs")
+		return nil
+	}
+	m.CancelTabRename()
+	m.SetParentColumnFocused(false)
+	for _, tab := range m.Tabs() {
+		for i := range tab.FilePanels {
+			tab.FilePanels[i].CloseArchive()
+			tab.FilePanels[i].StopRecursiveSearch()
+		}
+	}
+	// Before the first resize, the maximum count is not known yet
+	dimensionsKnown := m.MaxFilePanel > 0
+	maxPanels := common.FilePanelMax
+	if dimensionsKnown {
+		maxPanels = m.MaxFilePanel
+	}
+	m.tabs = tabs
+	for i := range m.tabs {
+		tab := &m.tabs[i]
+		tab.FilePanels = tab.FilePanels[:min(len(tab.FilePanels), maxPanels)]
+		if tab.FocusedPanelIndex < 0 || tab.FocusedPanelIndex >= len(tab.FilePanels) {
+			tab.FocusedPanelIndex = 0
+		}
+		for j := range tab.FilePanels {
+			tab.FilePanels[j].IsFocused = j == tab.FocusedPanelIndex
+		}
+	}
+	m.activeTab = 0
+	if activeIndex >= 0 && activeIndex < len(m.tabs) {
+		m.activeTab = activeIndex
+	}
+	m.loadTab(m.activeTab)
+	if !dimensionsKnown {
+		return nil
+	}
+	return m.layoutTab()
+}
+
+// switchTab keeps the panels of the active tab in its entry, and makes the
+// tab at index active
+func (m *Model) switchTab(index int) tea.Cmd {
+	if index == m.activeTab {
+		return nil
+	}
+	m.CancelTabRename()
+	m.SetParentColumnFocused(false)
+	// Only the panels of the active tab get the results of their searches.
+	// The searches start again when the tab is back
+	for i := range m.FilePanels {
+		m.FilePanels[i].StopRecursiveSearch()
+	}
+	m.tabs[m.activeTab].FilePanels = m.FilePanels
+	m.tabs[m.activeTab].FocusedPanelIndex = m.FocusedPanelIndex
+	m.loadTab(index)
+	return m.layoutTab()
+}
+
+// loadTab moves the panels of the tab at index to FilePanels
+func (m *Model) loadTab(index int) {
+	m.activeTab = index
+	m.FilePanels = m.tabs[index].FilePanels
+	m.FocusedPanelIndex = m.tabs[index].FocusedPanelIndex
+	m.tabs[index].FilePanels = nil
+}
+
+// layoutTab sets the dimensions of the panels of the tab just switched to,
+// and reads them again, as their directory was not watched meanwhile
+func (m *Model) layoutTab() tea.Cmd {
+	for i := range m.FilePanels {
+		m.FilePanels[i].SetHeight(m.Height)
+	}
+	m.updateChildComponentWidth()
+	m.UpdateFilePanelsIfNeeded(true)
+	return m.ensurePreviewDimensionsSync()
+}
+
+func (m *Model) IsRenamingTab() bool {
+	return m.renamingTab
+}
+
+// StartTabRename shows an input to rename the active tab in the tab strip.
+// There is no strip to show it with a single tab
+func (m *Model) StartTabRename() {
+	if m.TabCount() <= 1 {
+		return
+	}
+	m.renamingTab = true
+	m.tabRename = common.GenerateTabRenameTextInput(m.TabName(m.activeTab), TabNameMaxWidth)
+}
+
+func (m *Model) UpdateTabRename(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.tabRename, cmd = m.tabRename.Update(msg)
+	return cmd
+}
+
+// ConfirmTabRename names the active tab. An empty name names it after the
+// directory of its focused panel again
+func (m *Model) ConfirmTabRename() {
+	if !m.renamingTab {
+		return
+	}
+	m.tabs[m.activeTab].Name = strings.TrimSpace(m.tabRename.Value())
+	m.CancelTabRename()
+}
+
+func (m *Model) CancelTabRename() {
+	m.tabRename.Blur()
+	m.renamingTab = false
+}
@@ -1,6 +1,8 @@
 package filemodel
 
 import (
+	"charm.land/bubbles/v2/textinput"
+
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/preview"
 )
@@ -20,4 +22,17 @@ type Model struct {
 	FocusedPanelIndex    int
 	ioReqCnt             int
 	DisplayDotFiles      bool
+
+	// Miller columns layout. See miller.go
+	MillerColumns       bool
+	parentColumn        filepanel.Model
+	parentColumnFocused bool
+	// Location of the focused panel the parentColumn was synced with
+	parentColumnOf string
+
+	// Tabs, see tabs.go. The entry of the active tab has no panels
+	tabs        []Tab
+	activeTab   int
+	renamingTab bool
+	tabRename   textinput.Model
 }
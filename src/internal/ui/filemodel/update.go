@@ -20,6 +20,7 @@ func (m *Model) CreateNewFilePanel(location string) (tea.Cmd, error) {
 	if _, err := os.Stat(location); err != nil {
 		return nil, fmt.Errorf("cannot access location : %s", location)
 	}
+	m.SetParentColumnFocused(false)
 
 	m.FilePanels = append(m.FilePanels, filepanel.New(
 		location, false, "", m.GetFocusedFilePanel().SortKind,
@@ -40,7 +41,10 @@ func (m *Model) CloseFilePanel() (tea.Cmd, error) {
 	if m.PanelCount() <= 1 {
 		return nil, ErrMinimumPanelCount
 	}
+	m.SetParentColumnFocused(false)
 
+	m.FilePanels[m.FocusedPanelIndex].CloseArchive()
+	m.FilePanels[m.FocusedPanelIndex].StopRecursiveSearch()
 	m.FilePanels = append(m.FilePanels[:m.FocusedPanelIndex],
 		m.FilePanels[m.FocusedPanelIndex+1:]...)
 
@@ -53,6 +57,13 @@ func (m *Model) CloseFilePanel() (tea.Cmd, error) {
 	return m.ensurePreviewDimensionsSync(), nil
 }
 
+func (m *Model) SetFilePreviewOpen(open bool) tea.Cmd {
+	if m.FilePreview.IsOpen() == open {
+		return nil
+	}
+	return m.ToggleFilePreviewPanel()
+}
+
 func (m *Model) ToggleFilePreviewPanel() tea.Cmd {
 	m.FilePreview.ToggleOpen()
 	m.updateChildComponentWidth()
@@ -121,7 +132,14 @@ func (m *Model) GetFilePreviewCmd(forcePreviewRender bool) tea.Cmd {
 	slog.Debug("Submitting file preview render request", "id", reqCnt,
 		"path", selectedItem.Location, "w", width, "h", height)
 
+	// Saved as well, the panel may leave the archive before the Cmd runs
+	archive := panel.Archive()
 	return func() tea.Msg {
+		if archive != nil {
+			content, rawTransmit := m.FilePreview.RenderArchiveEntry(archive, selectedItem.Location, width, height)
+			return preview.NewUpdateMsg(selectedItem.Location, content, rawTransmit,
+				width, height, reqCnt)
+		}
 		content, rawTransmit := m.FilePreview.RenderWithPath(selectedItem.Location, width, height, fullModalWidth)
 		return preview.NewUpdateMsg(selectedItem.Location, content, rawTransmit,
 			width, height, reqCnt)
@@ -137,4 +155,5 @@ func (m *Model) UpdateFilePanelsIfNeeded(force bool) {
 	for i := range m.FilePanels {
 		m.FilePanels[i].UpdateElementsIfNeeded(force, m.DisplayDotFiles)
 	}
+	m.syncParentColumn(force)
 }
@@ -4,17 +4,30 @@ import (
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/preview"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 )
 
 func (m *Model) GetFocusedFilePanel() *filepanel.Model {
 	return &m.FilePanels[m.FocusedPanelIndex]
 }
 
+// GetOtherFilePanel returns the panel after the focused one, where items
+// are sent between panels. It is nil when there is only one panel
+func (m *Model) GetOtherFilePanel() *filepanel.Model {
+	if m.PanelCount() <= 1 {
+		return nil
+	}
+	return &m.FilePanels[(m.FocusedPanelIndex+1)%m.PanelCount()]
+}
+
 func New(firstPanelPaths []string, toggleDotFile bool) Model {
 	return Model{
 		FilePanels:       filepanel.FilePanelSlice(firstPanelPaths),
 		FilePreview:      preview.New(),
 		SinglePanelWidth: common.DefaultFilePanelWidth,
 		DisplayDotFiles:  toggleDotFile,
+		MillerColumns:    common.Config.MillerColumns,
+		parentColumn: filepanel.New("", false, "",
+			sortmodel.SortKind(common.Config.DefaultSortType), common.Config.SortOrderReversed),
 	}
 }
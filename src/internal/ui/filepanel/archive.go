@@ -0,0 +1,91 @@
+package filepanel
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/yorukot/superfile/src/internal/archivefs"
+)
+
+// A panel can show the entries of an archive instead of a directory. Such a
+// panel is read-only, and its Location and the Location of its elements are
+// virtual paths, see archivefs
+
+func (m *Model) InArchive() bool {
+	return m.archive != nil
+}
+
+// Archive is the archive being browsed, nil if the panel shows a directory
+func (m *Model) Archive() *archivefs.FS {
+	return m.archive
+}
+
+func (m *Model) CloseArchive() {
+	if m.archive == nil {
+		return
+	}
+	if err := m.archive.Close(); err != nil {
+		slog.Error("Error while closing archive", "archive", m.archive.ArchivePath(), "error", err)
+	}
+	m.archive = nil
+}
+
+func (m *Model) readDir(location string) ([]os.DirEntry, error) {
+	return m.readDirFunc()(location)
+}
+
+// readDirFunc returns a readDirFunc that can be used outside of the Update
+// loop, as it doesn't access the panel
+func (m *Model) readDirFunc() readDirFunc {
+	archive := m.archive
+	return func(location string) ([]os.DirEntry, error) {
+		if archive != nil {
+			if inner, ok := archive.Contains(location); ok {
+				return archive.ReadDir(inner)
+			}
+		}
+		return os.ReadDir(location)
+	}
+}
+
+// resolveArchive returns the archive to be used to browse path, which is
+// the current one if path is inside it, or nil if path is a directory
+func (m *Model) resolveArchive(path string) (*archivefs.FS, error) {
+	if m.archive != nil {
+		if inner, ok := m.archive.Contains(path); ok {
+			return m.archive, checkArchiveDir(m.archive, inner, path)
+		}
+	}
+	info, statErr := os.Stat(path)
+	if statErr == nil && info.IsDir() {
+		return nil, nil //nolint:nilnil // A directory doesn't need an archive
+	}
+	archivePath, inner, ok := archivefs.Split(path)
+	if !ok {
+		if statErr != nil {
+			return nil, fmt.Errorf("%s : no such file or directory, stats err : %w", path, statErr)
+		}
+		return nil, fmt.Errorf("%s is not a directory", path)
+	}
+	archive, err := archivefs.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkArchiveDir(archive, inner, path); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	return archive, nil
+}
+
+func checkArchiveDir(archive *archivefs.FS, inner string, path string) error {
+	info, err := archive.Stat(inner)
+	if err != nil {
+		return fmt.Errorf("%s : no such file or directory, stats err : %w", path, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+	return nil
+}
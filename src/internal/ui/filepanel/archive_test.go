@@ -0,0 +1,82 @@
+package filepanel
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+)
+
+func setupZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+	zw := zip.NewWriter(out)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func TestArchiveBrowsing(t *testing.T) {
+	curTestDir := t.TempDir()
+	zipPath := filepath.Join(curTestDir, "test.zip")
+	setupZip(t, zipPath, map[string]string{
+		"b.txt":         "bb",
+		"a.txt":         "a",
+		"dir/inner.txt": "inner",
+	})
+
+	m := Model{
+		Location:         curTestDir,
+		SortKind:         sortmodel.SortByName,
+		DirectoryRecords: make(map[string]directoryRecord),
+		selected:         make(map[string]int),
+	}
+	require.NoError(t, m.UpdateCurrentFilePanelDir(zipPath))
+	require.True(t, m.InArchive())
+	m.UpdateElementsIfNeeded(true, true)
+
+	var names []string
+	for i := range m.ElemCount() {
+		names = append(names, m.GetElementAtIdx(i).Name)
+	}
+	assert.Equal(t, []string{"dir", "a.txt", "b.txt"}, names)
+	assert.True(t, m.GetElementAtIdx(0).Directory)
+	assert.Equal(t, filepath.Join(zipPath, "b.txt"), m.GetElementAtIdx(2).Location)
+	assert.Equal(t, int64(2), m.GetElementAtIdx(2).Info.Size())
+
+	require.NoError(t, m.UpdateCurrentFilePanelDir(filepath.Join(zipPath, "dir")))
+	archive := m.Archive()
+	m.UpdateElementsIfNeeded(true, true)
+	require.Equal(t, 1, m.ElemCount())
+	assert.Equal(t, "inner.txt", m.GetFocusedItem().Name)
+
+	require.Error(t, m.UpdateCurrentFilePanelDir(filepath.Join(zipPath, "a.txt")),
+		"files inside the archive are not directories")
+	assert.Same(t, archive, m.Archive(), "the archive is kept while browsing it")
+
+	// Another panel, like a split of this one, opens the archive on its own
+	other := Model{}
+	otherArchive, err := other.resolveArchive(m.Location)
+	require.NoError(t, err)
+	require.NotNil(t, otherArchive)
+	assert.NotSame(t, archive, otherArchive)
+	require.NoError(t, otherArchive.Close())
+
+	require.NoError(t, m.ParentDirectory())
+	assert.True(t, m.InArchive())
+	require.NoError(t, m.ParentDirectory())
+	assert.False(t, m.InArchive(), "the archive is closed after leaving it")
+	assert.Equal(t, "test.zip", m.TargetFile)
+}
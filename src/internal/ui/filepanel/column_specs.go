@@ -0,0 +1,156 @@
+package filepanel
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// columnSpec describes an extra column of the file panel, that can be chosen
+// in file_panel_columns
+type columnSpec struct {
+	header string
+	// The width is fixed when minWidth equals maxWidth. Otherwise the column
+	// fits its widest value, between the two
+	minWidth int
+	maxWidth int
+	align    lipgloss.Position
+	// Empty when the value is not available, f.e. on another platform
+	value func(m *Model, elem Element) string
+}
+
+// Extra columns by their name in the config
+//
+//nolint:gochecknoglobals // Read-only registry of the columns
+var columnSpecs = map[string]columnSpec{
+	common.ColumnSize:             fixedColumn("Size", FileSizeColumnWidth, lipgloss.Right, sizeValue),
+	common.ColumnModifyTime:       fixedColumn("Modify time", TimeColumnWidth, lipgloss.Right, modifyTimeValue),
+	common.ColumnPermissions:      fixedColumn("Permission", PermissionsColumnWidth, lipgloss.Right, permissionsValue),
+	common.ColumnOctalPermissions: fixedColumn("Octal", OctalPermissionsColumnWidth, lipgloss.Right, octalPermissionsValue),
+	common.ColumnOwner:            fittedColumn("Owner", IDColumnMaxWidth, lipgloss.Left, ownerValue),
+	common.ColumnGroup:            fittedColumn("Group", IDColumnMaxWidth, lipgloss.Left, groupValue),
+	common.ColumnInode:            fittedColumn("Inode", InodeColumnMaxWidth, lipgloss.Right, inodeValue),
+	common.ColumnLinks:            fixedColumn("Links", LinksColumnWidth, lipgloss.Right, linksValue),
+	common.ColumnLinkTarget:       fittedColumn("Link target", LinkTargetColumnMaxWidth, lipgloss.Left, linkTargetValue),
+	common.ColumnAccessTime:       fixedColumn("Access time", TimeColumnWidth, lipgloss.Right, accessTimeValue),
+	common.ColumnChangeTime:       fixedColumn("Change time", TimeColumnWidth, lipgloss.Right, changeTimeValue),
+	common.ColumnBirthTime:        fixedColumn("Birth time", TimeColumnWidth, lipgloss.Right, birthTimeValue),
+	common.ColumnItemCount:        fixedColumn("Items", ItemCountColumnWidth, lipgloss.Right, itemCountValue),
+}
+
+func fixedColumn(header string, width int, align lipgloss.Position,
+	value func(m *Model, elem Element) string) columnSpec {
+	return columnSpec{header: header, minWidth: width, maxWidth: width, align: align, value: value}
+}
+
+// fittedColumn makes a column as wide as its widest value, but not less than
+// its header
+func fittedColumn(header string, maxWidth int, align lipgloss.Position,
+	value func(m *Model, elem Element) string) columnSpec {
+	return columnSpec{header: header, minWidth: len(header), maxWidth: maxWidth, align: align, value: value}
+}
+
+func sizeValue(m *Model, elem Element) string {
+	if elem.Info.IsDir() {
+		// Empty until computed, if enabled
+		size, ok := m.DirSizes.Size(elem.Location, elem.Info.ModTime())
+		if !ok {
+			return ""
+		}
+		return common.FormatFileSize(size)
+	}
+	return common.FormatFileSize(elem.Info.Size())
+}
+
+// TODO: make time template configurable
+func formatColumnTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func modifyTimeValue(_ *Model, elem Element) string {
+	return formatColumnTime(elem.Info.ModTime())
+}
+
+func permissionsValue(_ *Model, elem Element) string {
+	return elem.Info.Mode().String()
+}
+
+func octalPermissionsValue(_ *Model, elem Element) string {
+	return "0" + strconv.FormatUint(uint64(elem.Info.Mode().Perm()), 8)
+}
+
+func ownerValue(_ *Model, elem Element) string {
+	return fileOwner(elem.Info)
+}
+
+func groupValue(_ *Model, elem Element) string {
+	return fileGroup(elem.Info)
+}
+
+// FileOwnership returns the names of the owner and the group of the item, empty
+// where they are not known, like on Windows
+func FileOwnership(info os.FileInfo) (string, string) {
+	return fileOwner(info), fileGroup(info)
+}
+
+func inodeValue(_ *Model, elem Element) string {
+	inode, ok := fileInode(elem.Info)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatUint(inode, 10)
+}
+
+func linksValue(_ *Model, elem Element) string {
+	links, ok := fileLinks(elem.Info)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatUint(links, 10)
+}
+
+func linkTargetValue(m *Model, elem Element) string {
+	if elem.Info.Mode()&os.ModeSymlink == 0 || m.InArchive() {
+		return ""
+	}
+	target, err := os.Readlink(elem.Location)
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+func accessTimeValue(_ *Model, elem Element) string {
+	return formatColumnTime(fileAccessTime(elem.Info))
+}
+
+func changeTimeValue(_ *Model, elem Element) string {
+	return formatColumnTime(fileChangeTime(elem.Info))
+}
+
+func birthTimeValue(m *Model, elem Element) string {
+	if m.InArchive() {
+		return ""
+	}
+	return formatColumnTime(fileBirthTime(elem.Location, elem.Info))
+}
+
+// itemCountValue returns the count of items in a directory, hidden ones
+// included
+func itemCountValue(m *Model, elem Element) string {
+	if !elem.Directory {
+		return ""
+	}
+	entries, err := m.readDir(elem.Location)
+	if err != nil {
+		return ""
+	}
+	return strconv.Itoa(len(entries))
+}
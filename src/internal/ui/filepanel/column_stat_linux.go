@@ -0,0 +1,38 @@
+//go:build linux
+
+package filepanel
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func fileAccessTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(stat.Atim.Unix())
+}
+
+func fileChangeTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(stat.Ctim.Unix())
+}
+
+// fileBirthTime needs a statx, as stat does not give the birth time on Linux.
+// It is zero when the filesystem does not record it
+func fileBirthTime(location string, _ os.FileInfo) time.Time {
+	var stat unix.Statx_t
+	err := unix.Statx(unix.AT_FDCWD, location, unix.AT_SYMLINK_NOFOLLOW, unix.STATX_BTIME, &stat)
+	if err != nil || stat.Mask&unix.STATX_BTIME == 0 {
+		return time.Time{}
+	}
+	return time.Unix(stat.Btime.Sec, int64(stat.Btime.Nsec))
+}
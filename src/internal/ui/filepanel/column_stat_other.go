@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+
+package filepanel
+
+import (
+	"os"
+	"time"
+)
+
+// TODO: need realisation
+func fileAccessTime(_ os.FileInfo) time.Time {
+	return time.Time{}
+}
+
+func fileChangeTime(_ os.FileInfo) time.Time {
+	return time.Time{}
+}
+
+func fileBirthTime(_ string, _ os.FileInfo) time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,80 @@
+//go:build !windows
+
+package filepanel
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// Names of the users and groups by id, as looking them up can read files
+//
+//nolint:gochecknoglobals // Cache shared by the panels
+var idNames = struct {
+	sync.Mutex
+	users  map[uint32]string
+	groups map[uint32]string
+}{users: make(map[uint32]string), groups: make(map[uint32]string)}
+
+// lookupIDName returns the name of the user or group id, or the id itself if
+// it has no name
+func lookupIDName(names map[uint32]string, id uint32, lookup func(id string) (string, error)) string {
+	idNames.Lock()
+	defer idNames.Unlock()
+	if name, ok := names[id]; ok {
+		return name
+	}
+	name, err := lookup(strconv.FormatUint(uint64(id), 10))
+	if err != nil {
+		name = strconv.FormatUint(uint64(id), 10)
+	}
+	names[id] = name
+	return name
+}
+
+func fileOwner(info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	return lookupIDName(idNames.users, stat.Uid, func(id string) (string, error) {
+		usr, err := user.LookupId(id)
+		if err != nil {
+			return "", err
+		}
+		return usr.Username, nil
+	})
+}
+
+func fileGroup(info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	return lookupIDName(idNames.groups, stat.Gid, func(id string) (string, error) {
+		grp, err := user.LookupGroupId(id)
+		if err != nil {
+			return "", err
+		}
+		return grp.Name, nil
+	})
+}
+
+func fileInode(info os.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return stat.Ino, true
+}
+
+func fileLinks(info os.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(stat.Nlink), true //nolint:unconvert // Not an uint64 on all the platforms
+}
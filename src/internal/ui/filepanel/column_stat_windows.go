@@ -0,0 +1,46 @@
+//go:build windows
+
+package filepanel
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+func fileOwner(_ os.FileInfo) string {
+	return ""
+}
+
+func fileGroup(_ os.FileInfo) string {
+	return ""
+}
+
+func fileInode(_ os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+func fileLinks(_ os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+func fileAccessTime(info os.FileInfo) time.Time {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(0, data.LastAccessTime.Nanoseconds())
+}
+
+// Windows does not record a change time of the metadata
+func fileChangeTime(_ os.FileInfo) time.Time {
+	return time.Time{}
+}
+
+func fileBirthTime(_ string, info os.FileInfo) time.Time {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(0, data.CreationTime.Nanoseconds())
+}
@@ -21,9 +21,13 @@ func (m *Model) renderFileName(indexElement int, columnWidth int) string {
 	}
 
 	selectBox := m.renderSelectBox(isSelected)
+	treePrefix := m.treePrefix(elem)
+	gitMarker := m.gitMarker(elem)
+	compareMarker := m.compareMarker(elem)
 
 	// Calculate the actual prefix width for proper alignment
-	prefixWidth := ansi.StringWidth(cursor+" ") + ansi.StringWidth(selectBox)
+	prefixWidth := ansi.StringWidth(cursor+" ") + ansi.StringWidth(selectBox) + ansi.StringWidth(treePrefix) +
+		ansi.StringWidth(gitMarker) + ansi.StringWidth(compareMarker)
 	isLink := false
 	if elem.Info != nil {
 		isLink = elem.Info.Mode()&os.ModeSymlink != 0
@@ -36,7 +40,11 @@ func (m *Model) renderFileName(indexElement int, columnWidth int) string {
 		isSelected,
 		common.FilePanelBGColor,
 	)
-	return common.FilePanelCursorStyle.Render(cursor+" ") + selectBox + renderedName
+	if treePrefix != "" {
+		treePrefix = common.FilePanelStyle.Render(treePrefix)
+	}
+	return common.FilePanelCursorStyle.Render(cursor+" ") + selectBox + treePrefix + renderedName + gitMarker +
+		compareMarker
 }
 
 // The renderer of delimiter spaces. It has a strict fixed size that depends only on the delimiter string.
@@ -51,46 +59,52 @@ func (m *Model) renderDelimiter(indexElement int, columnWidth int) string {
 	)
 }
 
-func (m *Model) renderFileSize(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	sizeValue := common.FormatFileSize(elem.Info.Size())
-	if elem.Info.IsDir() {
-		sizeValue = ""
+// columnValue returns the value of the column name for elem. The values are
+// kept until the elements are read again, as some need syscalls
+func (m *Model) columnValue(name string, spec columnSpec, elem Element) string {
+	values, ok := m.columnValues[name]
+	if !ok {
+		if m.columnValues == nil {
+			m.columnValues = make(map[string]map[string]string)
+		}
+		values = make(map[string]string)
+		m.columnValues[name] = values
 	}
-	return common.FilePanelItemRender(
-		sizeValue,
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+	value, ok := values[elem.Location]
+	if !ok {
+		value = spec.value(m, elem)
+		values[elem.Location] = value
+	}
+	return value
 }
 
-// TODO: make time template configurable
-func (m *Model) renderModifyTime(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	modifyTime := elem.Info.ModTime().Format("2006-01-02 15:04")
-	return common.FilePanelItemRender(
-		modifyTime,
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+func (m *Model) columnRenderer(name string, spec columnSpec) columnRenderer {
+	return func(indexElement int, columnWidth int) string {
+		elem := m.GetElementAtIdx(indexElement)
+		return common.FilePanelItemRender(
+			m.columnValue(name, spec, elem),
+			columnWidth,
+			m.CheckSelected(elem.Location),
+			common.FilePanelBGColor,
+			spec.align,
+		)
+	}
 }
 
-func (m *Model) renderPermissions(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	return common.FilePanelItemRender(
-		elem.Info.Mode().String(),
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+// columnWidth returns the width of the column following its policy: a fixed
+// width, or the one of its widest value, within its bounds
+func (m *Model) columnWidth(name string, spec columnSpec) int {
+	if spec.minWidth == spec.maxWidth {
+		return spec.minWidth
+	}
+	width := spec.minWidth
+	for _, elem := range m.element {
+		width = max(width, ansi.StringWidth(m.columnValue(name, spec, elem)))
+		if width >= spec.maxWidth {
+			return spec.maxWidth
+		}
+	}
+	return width
 }
 
 func (cd *columnDefinition) Render(index int) string {
@@ -107,30 +121,15 @@ func (cd *columnDefinition) RenderHeader() string {
 	)
 }
 
-func (m *Model) makeColumns(columnThreshold int, fileNameRatio int) []columnDefinition {
-	// TODO: make column set configurable
-	// Note: May use a predefined slice for efficiency. This content is static
-	extraColumns := []columnDefinition{
-		{
-			Name:         "Size",
-			columnRender: m.renderFileSize,
-			Size:         FileSizeColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
-		{
-			Name:         "Modify time",
-			columnRender: m.renderModifyTime,
-			Size:         ModifyTimeSizeColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
-		{
-			Name:         "Permission",
-			columnRender: m.renderPermissions,
-			Size:         PermissionsColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
-	}
-	maxColumns := min(columnThreshold, len(extraColumns))
+// updateColumns makes the columns again, after a change of the width or of
+// the elements
+func (m *Model) updateColumns() {
+	m.columns = m.makeColumns(common.Config.FilePanelColumns, common.Config.FilePanelExtraColumns,
+		common.Config.FilePanelNamePercent)
+}
+
+func (m *Model) makeColumns(columnNames []string, columnThreshold int, fileNameRatio int) []columnDefinition {
+	maxColumns := min(columnThreshold, len(columnNames))
 	columns := []columnDefinition{
 		{
 			Name:         strings.Repeat(" ", ansi.StringWidth(emptyCursor+" ")) + "Name",
@@ -145,7 +144,18 @@ func (m *Model) makeColumns(columnThreshold int, fileNameRatio int) []columnDefi
 	// Hence, we need this check. Our constraints on Width and ratio guarantee it to be > 0 though
 	minWidthForNameColumn = min(minWidthForNameColumn, m.GetContentWidth())
 
-	for _, col := range extraColumns[0:maxColumns] {
+	for _, name := range columnNames[0:maxColumns] {
+		spec, ok := columnSpecs[name]
+		if !ok {
+			// Rejected when loading the config
+			continue
+		}
+		col := columnDefinition{
+			Name:         spec.header,
+			columnRender: m.columnRenderer(name, spec),
+			Size:         m.columnWidth(name, spec),
+			HeaderAlign:  lipgloss.Center,
+		}
 		widthExtraColumn := ansi.StringWidth(ColumnDelimiter) + col.Size
 
 		// This condition checks that can we borrow some width from first column for additional columns?
@@ -0,0 +1,85 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// columnHeaders returns the headers of the extra columns
+func columnHeaders(m *Model) []string {
+	var headers []string
+	for _, column := range m.columns[1:] {
+		if column.Name != "" {
+			headers = append(headers, column.Name)
+		}
+	}
+	return headers
+}
+
+func TestColumns(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	file1 := filepath.Join(curTestDir, "file1.txt")
+	link := filepath.Join(curTestDir, "link")
+	linkTarget := "a_rather_long_target_name.txt"
+	utils.SetupDirectories(t, dir1)
+	utils.SetupFiles(t, file1, filepath.Join(dir1, "a.txt"), filepath.Join(dir1, ".b.txt"))
+	require.NoError(t, os.Chmod(file1, 0o640))
+	if runtime.GOOS != utils.OsWindows {
+		require.NoError(t, os.Symlink(linkTarget, link))
+	}
+
+	origConfig := common.Config
+	t.Cleanup(func() { common.Config = origConfig })
+	common.Config.FilePanelColumns = []string{
+		common.ColumnOctalPermissions, common.ColumnItemCount, common.ColumnLinkTarget, common.ColumnSize,
+	}
+	common.Config.FilePanelExtraColumns = 3
+	common.Config.FilePanelNamePercent = 25
+
+	m := treeTestModel(curTestDir)
+	m.PanelMode = BrowserMode
+	m.UpdateElementsIfNeeded(true, true)
+	m.SetWidth(200)
+	require.True(t, m.NeedRenderHeaders())
+	assert.Equal(t, []string{"Octal", "Items", "Link target"}, columnHeaders(&m),
+		"the configured columns, in order, up to file_panel_extra_columns")
+
+	value := func(name string, location string) string {
+		for _, elem := range m.element {
+			if elem.Location == location {
+				return m.columnValue(name, columnSpecs[name], elem)
+			}
+		}
+		require.Failf(t, "no element", "%s is not in the panel", location)
+		return ""
+	}
+	assert.Equal(t, "2", value(common.ColumnItemCount, dir1), "hidden items are counted")
+	assert.Empty(t, value(common.ColumnItemCount, file1))
+	assert.Empty(t, value(common.ColumnLinkTarget, file1))
+	if runtime.GOOS != utils.OsWindows {
+		assert.Equal(t, "0640", value(common.ColumnOctalPermissions, file1))
+		assert.Equal(t, linkTarget, value(common.ColumnLinkTarget, link))
+		assert.Equal(t, len(linkTarget), m.columns[len(m.columns)-1].Size, "fits the widest link target")
+	}
+
+	t.Run("Fixed width", func(t *testing.T) {
+		assert.Equal(t, OctalPermissionsColumnWidth, m.columns[2].Size)
+	})
+
+	t.Run("Narrow panel", func(t *testing.T) {
+		if runtime.GOOS == utils.OsWindows {
+			t.Skip("No link target to make the column wide")
+		}
+		m.SetWidth(30)
+		assert.Equal(t, []string{"Octal", "Items"}, columnHeaders(&m), "the columns not fitting are left out")
+	})
+}
@@ -0,0 +1,75 @@
+package filepanel
+
+import (
+	"strconv"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dircompare"
+)
+
+// When two directories are compared, the status of the items of the panels
+// in either tree is shown after their name, and the count of differences in
+// the footer. The comparison is run in the background and set in Compare,
+// see dircompare
+
+// compareMarker returns the marker of the status of elem compared with the
+// other tree, and an empty string if it has none
+func (m *Model) compareMarker(elem Element) string {
+	status, ok := m.Compare.StatusOf(elem.Location)
+	if !ok {
+		return ""
+	}
+	marker, style := compareSameMarker, common.CompareSameStyle
+	switch status {
+	case dircompare.Same:
+	case dircompare.Different:
+		marker, style = compareDifferentMarker, common.CompareDifferentStyle
+	case dircompare.OnlyHere:
+		marker, style = compareOnlyHereMarker, common.CompareOnlyHereStyle
+	case dircompare.Newer:
+		marker, style = compareNewerMarker, common.CompareNewerStyle
+	case dircompare.Older:
+		marker, style = compareOlderMarker, common.CompareOlderStyle
+	}
+	return common.FilePanelStyle.Render(" ") + style.Render(marker)
+}
+
+// compareFooterInfo returns the count of differences between the trees, or
+// that they are being compared
+func (m *Model) compareFooterInfo() string {
+	if m.Compare == nil {
+		return ""
+	}
+	info := "comparing"
+	if !m.Compare.Running() {
+		info = strconv.Itoa(m.Compare.Differences()) + " diff"
+	}
+	return icon.Compare + icon.Space + info
+}
+
+// SelectDifferences switches to the select mode, and selects the items that
+// are not in the other tree, or the files that differ and are not older.
+// Copying them to the other tree makes it the same. The directories in both
+// trees are left out, as overwriting them would remove what is only in the
+// other one: their differences are selected from inside. Returns the count of
+// items selected
+func (m *Model) SelectDifferences() int {
+	if m.Compare == nil {
+		return 0
+	}
+	if m.PanelMode != SelectMode {
+		m.ChangeFilePanelMode()
+	}
+	m.ResetSelected()
+	count := 0
+	for _, elem := range m.element {
+		status, ok := m.Compare.StatusOf(elem.Location)
+		if ok && (status == dircompare.OnlyHere ||
+			!elem.Directory && (status == dircompare.Different || status == dircompare.Newer)) {
+			m.SetSelected(elem.Location)
+			count++
+		}
+	}
+	return count
+}
@@ -11,10 +11,17 @@ const (
 	MinHeight      = contentPadding + common.BorderPadding + 1
 	MinWidth       = 18 // minimal width for rename input to render
 
-	FileSizeColumnWidth       = 15
-	ModifyTimeSizeColumnWidth = 18
-	PermissionsColumnWidth    = 12
-	ColumnHeaderHeight        = 1
+	FileSizeColumnWidth         = 15
+	TimeColumnWidth             = 18
+	PermissionsColumnWidth      = 12
+	OctalPermissionsColumnWidth = 7
+	LinksColumnWidth            = 6
+	ItemCountColumnWidth        = 8
+	// Maximum width of the columns fitting their values
+	IDColumnMaxWidth         = 16
+	InodeColumnMaxWidth      = 20
+	LinkTargetColumnMaxWidth = 40
+	ColumnHeaderHeight       = 1
 
 	// Delimiter between columns in the file panel.
 	ColumnDelimiter      = "  "
@@ -24,4 +31,31 @@ const (
 	nonFocussedPanelReRenderTime = 3 * time.Second
 
 	emptyCursor = " "
+
+	// Count of walked items matched with the query at once by a recursive search
+	recursiveSearchBatchSize = 256
+	// A recursive search stops once it found that many items
+	maxRecursiveSearchResults = 10000
+
+	// Maximum count of directories kept in each of the back and forward
+	// lists of the navigation history
+	maxHistoryEntries = 100
+
+	// Markers of the git status of the items, like in `git status --short`
+	gitModifiedMarker   = "M"
+	gitStagedMarker     = "+"
+	gitUntrackedMarker  = "?"
+	gitIgnoredMarker    = "!"
+	gitConflictedMarker = "U"
+	// Shown in the footer when there are changes in the work tree
+	gitDirtyMarker = "*"
+	// Shown in the footer instead of the branch when the HEAD is detached
+	gitDetachedLabel = "(detached)"
+
+	// Markers of the status of the items compared with the other directory
+	compareSameMarker      = "="
+	compareDifferentMarker = "~"
+	compareOnlyHereMarker  = "+"
+	compareNewerMarker     = ">"
+	compareOlderMarker     = "<"
 )
@@ -0,0 +1,52 @@
+package filepanel
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+)
+
+// When enabled, the total size of the directories is computed in the
+// background, see dirsize. It is shown in the size column, and used to sort
+// by size
+
+// NextUnsizedDir returns the next directory to compute the size of, starting
+// from the ones on screen
+func (m *Model) NextUnsizedDir() (string, time.Time, bool) {
+	count := m.ElemCount()
+	for i := range count {
+		elem := m.element[(m.renderIndex+i)%count]
+		// The symlinks to directories are not followed
+		if !elem.Info.IsDir() {
+			continue
+		}
+		if _, ok := m.DirSizes.Size(elem.Location, elem.Info.ModTime()); !ok {
+			return elem.Location, elem.Info.ModTime(), true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// DirSizeComputed updates the panel after the size of the directory at path
+// is computed
+func (m *Model) DirSizeComputed(path string) {
+	if !slices.Contains(m.ShownDirs(), filepath.Dir(path)) {
+		return
+	}
+	delete(m.columnValues, common.ColumnSize)
+	// The tree is sorted by level, and the results of a recursive search are
+	// not sorted
+	if m.SortKind != sortmodel.SortBySize || m.IsTreeView() || m.InRecursiveResults() || m.Empty() {
+		return
+	}
+	// Keep the cursor on the same item
+	focused := m.GetFocusedItem().Location
+	sort.SliceStable(m.element, getOrderingFunc(m.element, m.SortOptions(), m.readDir, m.DirSizes))
+	if idx := m.FindElementIndexByLocation(focused); idx != -1 {
+		m.scrollToCursor(idx)
+	}
+}
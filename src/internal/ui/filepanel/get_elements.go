@@ -15,11 +15,23 @@ import (
 // our unit_test TestReturnDirElement
 // getDirectoryElements returns the directory elements for the panel's current location
 func (m *Model) getDirectoryElements(displayDotFile bool) []Element {
-	dirEntries, err := os.ReadDir(m.Location)
+	elements, err := m.readDirElements(m.Location, displayDotFile)
 	if err != nil {
 		slog.Error("Error while returning folder elements", "error", err)
 		return nil
 	}
+	if m.IsTreeView() {
+		return m.expandTree(elements, displayDotFile)
+	}
+	return elements
+}
+
+// readDirElements returns the sorted elements of the directory at location
+func (m *Model) readDirElements(location string, displayDotFile bool) ([]Element, error) {
+	dirEntries, err := m.readDir(location)
+	if err != nil {
+		return nil, err
+	}
 
 	dirEntries = slices.DeleteFunc(dirEntries, func(e os.DirEntry) bool {
 		// Entries not needed to be considered
@@ -29,15 +41,15 @@ func (m *Model) getDirectoryElements(displayDotFile bool) []Element {
 
 	// No files/directories to process
 	if len(dirEntries) == 0 {
-		return nil
+		return nil, nil
 	}
-	return sortFileElement(m.SortKind, m.SortReversed, dirEntries, m.Location)
+	return m.sortFileElement(dirEntries, location), nil
 }
 
 // getDirectoryElementsBySearch returns filtered directory elements based on search string
 func (m *Model) getDirectoryElementsBySearch(displayDotFile bool) []Element {
 	searchString := m.SearchBar.Value()
-	items, err := os.ReadDir(m.Location)
+	items, err := m.readDir(m.Location)
 	if err != nil {
 		slog.Error("Error while return folder element function", "error", err)
 		return nil
@@ -71,11 +83,15 @@ func (m *Model) getDirectoryElementsBySearch(displayDotFile bool) []Element {
 		dirElements = append(dirElements, resultItem)
 	}
 
-	return sortFileElement(m.SortKind, m.SortReversed, dirElements, m.Location)
+	return m.sortFileElement(dirElements, m.Location)
 }
 
 // Helper to decide whether to skip updating a panel this tick.
 func (m *Model) shouldSkipPanelUpdate(nowTime time.Time) bool {
+	if m.Watched {
+		// Only re-read when it changes
+		return !m.NeedsReRender()
+	}
 	if !m.IsFocused {
 		return nowTime.Sub(m.LastTimeGetElement) < nonFocussedPanelReRenderTime
 	}
@@ -88,9 +104,12 @@ func (m *Model) shouldSkipPanelUpdate(nowTime time.Time) bool {
 
 func (m *Model) UpdateElementsIfNeeded(force bool, displayDotFile bool) {
 	nowTime := time.Now()
-	if force || !m.shouldSkipPanelUpdate(nowTime) {
+	if force || m.sortChanged || !m.shouldSkipPanelUpdate(nowTime) {
 		// Load elements for this panel (with/without search filter)
 		m.element = m.getElements(displayDotFile)
+		m.sortChanged = false
+		m.columnValues = nil
+		m.updateColumns()
 		// Update file panel list
 		m.LastTimeGetElement = nowTime
 
@@ -108,7 +127,13 @@ func (m *Model) UpdateElementsIfNeeded(force bool, displayDotFile bool) {
 
 // Retrieves elements for a panel based on search bar value and sort options.
 func (m *Model) getElements(displayDotFile bool) []Element {
+	if m.InRecursiveResults() {
+		return m.recursive.elements
+	}
 	if m.SearchBar.Value() != "" {
+		if m.IsTreeView() {
+			return m.getTreeElementsBySearch(displayDotFile)
+		}
 		return m.getDirectoryElementsBySearch(displayDotFile)
 	}
 	return m.getDirectoryElements(displayDotFile)
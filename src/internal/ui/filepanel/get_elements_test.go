@@ -65,6 +65,7 @@ func TestReturnDirElement(t *testing.T) {
 		dotFiles          bool
 		sortKind          sortmodel.SortKind
 		reversed          bool
+		directories       sortmodel.DirectoriesOrder
 		searchString      string
 		expectedElemNames []string
 	}{
@@ -132,6 +133,16 @@ func TestReturnDirElement(t *testing.T) {
 			expectedElemNames: []string{"dirNatural", "1.json", "file2.txt", "abc",
 				"xyz.json", "file1.txt", "aBcD", "dir1", "dir2"},
 		},
+		{
+			name:        "Sort by Date, directories first",
+			location:    curTestDir,
+			dotFiles:    false,
+			sortKind:    sortmodel.SortByDate,
+			reversed:    false,
+			directories: sortmodel.DirectoriesFirst,
+			expectedElemNames: []string{"dirNatural", "dir1", "dir2", "1.json", "file2.txt", "abc",
+				"xyz.json", "file1.txt", "aBcD"},
+		},
 		{
 			name:     "Sort by Type",
 			location: curTestDir,
@@ -201,6 +212,7 @@ func TestReturnDirElement(t *testing.T) {
 			panel.Location = tt.location
 			panel.SortKind = tt.sortKind
 			panel.SortReversed = tt.reversed
+			panel.Directories = tt.directories
 			panel.SearchBar.SetValue(tt.searchString)
 			var res []Element
 			if tt.searchString == "" {
@@ -0,0 +1,63 @@
+package filepanel
+
+import (
+	"strconv"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+)
+
+// When the panel is in a git work tree, the git status of the items is shown
+// after their name, and the branch in the footer. The status is read in the
+// background and set in Git, see gitstatus
+
+// gitMarker returns the marker of the git status of elem. It is blank for
+// unmodified items, and empty outside of a work tree
+func (m *Model) gitMarker(elem Element) string {
+	if m.Git == nil {
+		return ""
+	}
+	var marker string
+	var style lipgloss.Style
+	switch m.Git.StatusOf(elem.Location) {
+	case gitstatus.Modified:
+		marker, style = gitModifiedMarker, common.GitModifiedStyle
+	case gitstatus.Staged:
+		marker, style = gitStagedMarker, common.GitStagedStyle
+	case gitstatus.Untracked:
+		marker, style = gitUntrackedMarker, common.GitUntrackedStyle
+	case gitstatus.Ignored:
+		marker, style = gitIgnoredMarker, common.GitIgnoredStyle
+	case gitstatus.Conflicted:
+		marker, style = gitConflictedMarker, common.GitConflictedStyle
+	case gitstatus.Unmodified:
+		return common.FilePanelStyle.Render("  ")
+	}
+	return common.FilePanelStyle.Render(" ") + style.Render(marker)
+}
+
+// gitFooterInfo returns the branch, the commits ahead and behind its upstream,
+// and whether there are changes
+func (m *Model) gitFooterInfo() string {
+	if m.Git == nil {
+		return ""
+	}
+	branch := m.Git.Branch
+	if branch == "" {
+		branch = gitDetachedLabel
+	}
+	info := icon.GitBranch + icon.Space + branch
+	if m.Git.Ahead > 0 {
+		info += " " + icon.GitAhead + strconv.Itoa(m.Git.Ahead)
+	}
+	if m.Git.Behind > 0 {
+		info += " " + icon.GitBehind + strconv.Itoa(m.Git.Behind)
+	}
+	if m.Git.Dirty {
+		info += " " + gitDirtyMarker
+	}
+	return info
+}
@@ -0,0 +1,77 @@
+package filepanel
+
+import (
+	"log/slog"
+)
+
+// Each panel keeps the directories it visited, to go back and forward
+// through them like in a web browser. Going back also puts the cursor back on
+// the item it was on.
+
+type historyEntry struct {
+	location string
+	// Name of the item under the cursor when the directory was left
+	target string
+}
+
+type navigationHistory struct {
+	back    []historyEntry
+	forward []historyEntry
+}
+
+// visit records the directory left for a new one. The directories gone back
+// from cannot be gone forward to anymore
+func (h *navigationHistory) visit(entry historyEntry) {
+	h.back = pushHistoryEntry(h.back, entry)
+	h.forward = nil
+}
+
+func pushHistoryEntry(entries []historyEntry, entry historyEntry) []historyEntry {
+	if len(entries) >= maxHistoryEntries {
+		entries = entries[len(entries)-maxHistoryEntries+1:]
+	}
+	return append(entries, entry)
+}
+
+func (m *Model) historyEntry() historyEntry {
+	entry := historyEntry{location: m.Location}
+	if !m.EmptyOrInvalid() {
+		entry.target = m.GetFocusedItem().Name
+	}
+	return entry
+}
+
+// HistoryBack goes to the directory visited before the current one. Returns
+// false if there is none
+func (m *Model) HistoryBack() bool {
+	return m.moveInHistory(&m.history.back, &m.history.forward)
+}
+
+// HistoryForward goes to the directory gone back from. Returns false if
+// there is none
+func (m *Model) HistoryForward() bool {
+	return m.moveInHistory(&m.history.forward, &m.history.back)
+}
+
+// moveInHistory goes to the last directory of from, and records the current
+// one in to. The directories that cannot be opened anymore are skipped
+func (m *Model) moveInHistory(from *[]historyEntry, to *[]historyEntry) bool {
+	current := m.historyEntry()
+	for len(*from) > 0 {
+		entry := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+		if entry.location == m.Location {
+			continue
+		}
+		if err := m.changeDirectory(entry.location); err != nil {
+			slog.Debug("Skipping directory of the navigation history", "location", entry.location, "error", err)
+			continue
+		}
+		if entry.target != "" {
+			m.TargetFile = entry.target
+		}
+		*to = pushHistoryEntry(*to, current)
+		return true
+	}
+	return false
+}
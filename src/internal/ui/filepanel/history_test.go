@@ -0,0 +1,95 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/bubbles/v2/textinput"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestNavigationHistory(t *testing.T) {
+	root := t.TempDir()
+	dirA := filepath.Join(root, "a")
+	dirB := filepath.Join(root, "b")
+	utils.SetupDirectories(t, dirA, dirB)
+	utils.SetupFiles(t, filepath.Join(root, "x.txt"), filepath.Join(root, "y.txt"), filepath.Join(dirA, "in_a.txt"))
+
+	m := Model{
+		Location:         root,
+		PanelMode:        BrowserMode,
+		DirectoryRecords: make(map[string]directoryRecord),
+		selected:         make(map[string]int),
+		SearchBar:        textinput.New(),
+		height:           20,
+	}
+	load := func() {
+		m.UpdateElementsIfNeeded(true, false)
+	}
+	load()
+	assert.False(t, m.HistoryBack(), "nothing visited yet")
+
+	// Cursor on x.txt
+	m.ListDown()
+	m.ListDown()
+	require.Equal(t, "x.txt", m.GetFocusedItem().Name)
+	require.NoError(t, m.UpdateCurrentFilePanelDir(dirA))
+	load()
+	require.NoError(t, m.UpdateCurrentFilePanelDir(dirA), "same directory not recorded")
+	require.NoError(t, m.ParentDirectory())
+	load()
+	require.NoError(t, m.UpdateCurrentFilePanelDir(dirB))
+	load()
+
+	require.True(t, m.HistoryBack())
+	load()
+	assert.Equal(t, root, m.Location)
+	assert.Equal(t, "a", m.GetFocusedItem().Name, "cursor back on the directory left")
+
+	require.True(t, m.HistoryBack())
+	load()
+	assert.Equal(t, dirA, m.Location)
+	require.True(t, m.HistoryBack())
+	load()
+	assert.Equal(t, root, m.Location)
+	assert.Equal(t, "x.txt", m.GetFocusedItem().Name, "cursor restored")
+	assert.False(t, m.HistoryBack())
+
+	require.True(t, m.HistoryForward())
+	load()
+	assert.Equal(t, dirA, m.Location)
+	require.True(t, m.HistoryForward())
+	load()
+	assert.Equal(t, root, m.Location)
+
+	// A new directory drops the directories gone back from
+	require.NoError(t, m.UpdateCurrentFilePanelDir(dirA))
+	load()
+	assert.False(t, m.HistoryForward())
+
+	t.Run("Removed directories skipped", func(t *testing.T) {
+		require.NoError(t, m.UpdateCurrentFilePanelDir(dirB))
+		load()
+		require.NoError(t, m.UpdateCurrentFilePanelDir(root))
+		load()
+		require.NoError(t, os.Remove(dirB))
+		require.True(t, m.HistoryBack())
+		load()
+		assert.Equal(t, dirA, m.Location)
+	})
+
+	t.Run("Maximum count of entries", func(t *testing.T) {
+		for i := range maxHistoryEntries + 5 {
+			dir := dirA
+			if i%2 == 0 {
+				dir = root
+			}
+			require.NoError(t, m.UpdateCurrentFilePanelDir(dir))
+		}
+		assert.Len(t, m.history.back, maxHistoryEntries)
+	})
+}
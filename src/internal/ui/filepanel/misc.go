@@ -8,6 +8,8 @@ func (p PanelMode) String() string {
 		return "selectMode"
 	case BrowserMode:
 		return "browserMode"
+	case TreeMode:
+		return "treeMode"
 	default:
 		return common.InvalidTypeString
 	}
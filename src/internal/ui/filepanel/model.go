@@ -33,12 +33,13 @@ func defaultFilePanel(path string, focused bool) Model {
 }
 
 func New(location string, focused bool, targetFile string, sortKind sortmodel.SortKind, sortReversed bool) Model {
-	return Model{
+	m := Model{
 		cursor:           0,
 		renderIndex:      0,
 		Location:         location,
 		SortKind:         sortKind,
 		SortReversed:     sortReversed,
+		Directories:      sortmodel.DefaultDirectoriesOrder(),
 		PanelMode:        BrowserMode,
 		IsFocused:        focused,
 		DirectoryRecords: make(map[string]directoryRecord),
@@ -48,4 +49,9 @@ func New(location string, focused bool, targetFile string, sortKind sortmodel.So
 		height:           MinHeight,
 		selected:         make(map[string]int),
 	}
+	// The location can be inside an archive, f.e. for a split of a panel browsing one
+	if archive, err := m.resolveArchive(location); err == nil {
+		m.archive = archive
+	}
+	return m
 }
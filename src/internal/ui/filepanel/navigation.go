@@ -2,6 +2,7 @@ package filepanel
 
 import (
 	"fmt"
+	"path/filepath"
 )
 
 func (m *Model) scrollToCursor(cursor int) {
@@ -79,7 +80,11 @@ func (m *Model) ItemSelectDown() {
 
 // Applies targetFile cursor positioning, if configured for the panel.
 func (m *Model) applyTargetFileCursor() {
-	idx := m.FindElementIndexByName(m.TargetFile)
+	// By location first, as the tree can have items of the same name
+	idx := m.FindElementIndexByLocation(filepath.Join(m.Location, m.TargetFile))
+	if idx == -1 {
+		idx = m.FindElementIndexByName(m.TargetFile)
+	}
 	if idx != -1 {
 		m.scrollToCursor(idx)
 	}
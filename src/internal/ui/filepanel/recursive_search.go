@@ -0,0 +1,245 @@
+package filepanel
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// In recursive mode, the search bar searches the whole tree below Location
+// instead of the current directory. The tree is walked in the background,
+// one depth at a time, and the matching items are streamed to the panel as a
+// flat list, named by their path relative to Location
+
+// IDs of the recursive searches, unique across all panels
+var lastRecursiveSearchID atomic.Uint64 //nolint:gochecknoglobals // Shared counter, not a config
+
+// RecursiveSearchBatch is a batch of results of the recursive search SearchID.
+// Done is set on the last batch of the search
+type RecursiveSearchBatch struct {
+	SearchID uint64
+	Elements []Element
+	Done     bool
+}
+
+type recursiveSearch struct {
+	enabled bool
+	// 0 when no search is running nor done
+	id     uint64
+	cancel context.CancelFunc
+	// What the results are for. The search is restarted when they change
+	query          string
+	displayDotFile bool
+	elements       []Element
+	done           bool
+}
+
+func (m *Model) IsRecursiveSearch() bool {
+	return m.recursive.enabled
+}
+
+// ToggleRecursiveSearch switches the search bar between searching the
+// current directory and searching the whole tree below it
+func (m *Model) ToggleRecursiveSearch() {
+	m.StopRecursiveSearch()
+	m.recursive.enabled = !m.recursive.enabled
+	if m.recursive.enabled {
+		m.SearchBar.Prompt = common.FilePanelTopDirectoryIconStyle.Render(icon.Search + icon.Space + "**/")
+		m.SearchBar.Placeholder = "(" + common.Hotkeys.SearchBar[0] + ") Search in subdirectories"
+	} else {
+		searchBar := common.GenerateSearchBar()
+		m.SearchBar.Prompt = searchBar.Prompt
+		m.SearchBar.Placeholder = searchBar.Placeholder
+	}
+}
+
+// RecursiveSearchRunning tells whether results are still expected
+func (m *Model) RecursiveSearchRunning() bool {
+	return m.recursive.id != 0 && !m.recursive.done
+}
+
+// StopRecursiveSearch cancels the running search, if any, and drops its results
+func (m *Model) StopRecursiveSearch() {
+	if m.recursive.cancel != nil {
+		m.recursive.cancel()
+	}
+	m.recursive = recursiveSearch{enabled: m.recursive.enabled}
+}
+
+// SyncRecursiveSearch starts a new search when the search bar, or the
+// dotfile setting, changed since the last one. It returns the channel the
+// results of the new search are sent to, or nil if no search was started
+func (m *Model) SyncRecursiveSearch(displayDotFile bool, maxDepth int) <-chan RecursiveSearchBatch {
+	query := m.SearchBar.Value()
+	if !m.recursive.enabled || query == "" {
+		if m.recursive.id != 0 {
+			m.StopRecursiveSearch()
+		}
+		return nil
+	}
+	if m.recursive.id != 0 && m.recursive.query == query && m.recursive.displayDotFile == displayDotFile {
+		return nil
+	}
+
+	m.StopRecursiveSearch()
+	ctx, cancel := context.WithCancel(context.Background())
+	id := lastRecursiveSearchID.Add(1)
+	m.recursive.id = id
+	m.recursive.cancel = cancel
+	m.recursive.query = query
+	m.recursive.displayDotFile = displayDotFile
+
+	results := make(chan RecursiveSearchBatch)
+	go walkRecursiveSearch(ctx, recursiveWalk{
+		id:             id,
+		root:           m.Location,
+		query:          query,
+		displayDotFile: displayDotFile,
+		maxDepth:       maxDepth,
+		readDir:        m.readDirFunc(),
+	}, results)
+	// Drop the results of the previous search right away
+	m.UpdateElementsIfNeeded(true, displayDotFile)
+	return results
+}
+
+// ApplyRecursiveSearchBatch adds the results to the panel. It returns false
+// if the batch is of a search that was stopped, or replaced
+func (m *Model) ApplyRecursiveSearchBatch(batch RecursiveSearchBatch) bool {
+	if batch.SearchID == 0 || batch.SearchID != m.recursive.id {
+		return false
+	}
+	m.recursive.elements = append(m.recursive.elements, batch.Elements...)
+	m.recursive.done = batch.Done
+	m.UpdateElementsIfNeeded(true, m.recursive.displayDotFile)
+	return true
+}
+
+// InRecursiveResults tells whether the panel shows the results of a recursive search
+func (m *Model) InRecursiveResults() bool {
+	return m.recursive.enabled && m.SearchBar.Value() != ""
+}
+
+// LeaveRecursiveResults clears the search bar, so the panel shows its
+// directory again
+func (m *Model) LeaveRecursiveResults() {
+	m.StopRecursiveSearch()
+	m.SearchBar.SetValue("")
+}
+
+// recursiveWalk is what walkRecursiveSearch needs to know of the panel, as
+// the panel itself must not be accessed outside of the Update loop
+type recursiveWalk struct {
+	id             uint64
+	root           string
+	query          string
+	displayDotFile bool
+	maxDepth       int
+	readDir        readDirFunc
+}
+
+// walkRecursiveSearch walks the directories below w.root, breadth first,
+// and sends the items matching the query to results, in batches. The
+// channel is closed once the walk is done, or cancelled. Symlinks to
+// directories are listed but not walked, so a loop of symlinks can't be
+// walked forever
+func walkRecursiveSearch(ctx context.Context, w recursiveWalk, results chan<- RecursiveSearchBatch) {
+	defer close(results)
+	var candidates []Element
+	found := 0
+	// Filters the candidates with the query, and sends the matches
+	send := func(done bool) bool {
+		batch := RecursiveSearchBatch{SearchID: w.id, Elements: matchRecursiveCandidates(w.query, candidates),
+			Done: done}
+		candidates = candidates[:0]
+		found += len(batch.Elements)
+		if len(batch.Elements) == 0 && !done {
+			return true
+		}
+		select {
+		case results <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	dirs := []string{w.root}
+	for depth := 1; depth <= w.maxDepth && len(dirs) > 0; depth++ {
+		var nextDirs []string
+		for _, dir := range dirs {
+			if ctx.Err() != nil {
+				return
+			}
+			entries, err := w.readDir(dir)
+			if err != nil {
+				slog.Debug("Cannot read directory during recursive search", "path", dir, "error", err)
+				continue
+			}
+			for _, entry := range entries {
+				if !w.displayDotFile && strings.HasPrefix(entry.Name(), ".") {
+					continue
+				}
+				info, err := entry.Info()
+				if err != nil {
+					continue
+				}
+				location := filepath.Join(dir, entry.Name())
+				if entry.IsDir() {
+					nextDirs = append(nextDirs, location)
+				}
+				name, err := filepath.Rel(w.root, location)
+				if err != nil {
+					continue
+				}
+				candidates = append(candidates, Element{
+					Name:      name,
+					Location:  location,
+					Directory: entry.IsDir() || isSymlinkToDir(dir, info, entry.Name()),
+					Info:      info,
+				})
+			}
+			if len(candidates) >= recursiveSearchBatchSize && !send(false) {
+				return
+			}
+			if found >= maxRecursiveSearchResults {
+				slog.Debug("Recursive search stopped at the maximum count of results", "root", w.root,
+					"query", w.query)
+				send(true)
+				return
+			}
+		}
+		dirs = nextDirs
+	}
+	send(true)
+}
+
+// matchRecursiveCandidates returns the candidates whose name, without its
+// directory, matches the query, in the order of the walk
+func matchRecursiveCandidates(query string, candidates []Element) []Element {
+	if len(candidates) == 0 {
+		return nil
+	}
+	names := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		names[i] = filepath.Base(candidate.Name)
+	}
+	matches := utils.FzfSearch(query, names)
+	indexes := make([]int, len(matches))
+	for i, match := range matches {
+		indexes[i] = int(match.HayIndex)
+	}
+	slices.Sort(indexes)
+	elements := make([]Element, len(indexes))
+	for i, index := range indexes {
+		elements[i] = candidates[index]
+	}
+	return elements
+}
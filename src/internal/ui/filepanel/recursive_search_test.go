@@ -0,0 +1,148 @@
+package filepanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/bubbles/v2/textinput"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// collectRecursiveSearch runs the walk synchronously and returns the names
+// of its results
+func collectRecursiveSearch(ctx context.Context, w recursiveWalk) []string {
+	results := make(chan RecursiveSearchBatch)
+	go walkRecursiveSearch(ctx, w, results)
+	names := []string{}
+	for batch := range results {
+		for _, elem := range batch.Elements {
+			names = append(names, elem.Name)
+		}
+	}
+	return names
+}
+
+func TestWalkRecursiveSearch(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(root, "a", "b", "c"), filepath.Join(root, ".hidden"))
+	utils.SetupFiles(t,
+		filepath.Join(root, "note.txt"),
+		filepath.Join(root, "a", "note.md"),
+		filepath.Join(root, "a", "b", "c", "note.go"),
+		filepath.Join(root, "a", "other"),
+		filepath.Join(root, ".hidden", "note"),
+	)
+
+	testdata := []struct {
+		name           string
+		query          string
+		displayDotFile bool
+		maxDepth       int
+		expected       []string
+	}{
+		{
+			name:     "Shallow results come first",
+			query:    "note",
+			maxDepth: 10,
+			expected: []string{"note.txt", filepath.Join("a", "note.md"), filepath.Join("a", "b", "c", "note.go")},
+		},
+		{
+			name:     "Depth limit",
+			query:    "note",
+			maxDepth: 2,
+			expected: []string{"note.txt", filepath.Join("a", "note.md")},
+		},
+		{
+			name:           "Dotfiles",
+			query:          "note",
+			displayDotFile: true,
+			maxDepth:       2,
+			expected: []string{"note.txt", filepath.Join(".hidden", "note"),
+				filepath.Join("a", "note.md")},
+		},
+		{
+			name:     "Directories match too",
+			query:    "c",
+			maxDepth: 10,
+			expected: []string{filepath.Join("a", "b", "c")},
+		},
+		{
+			name:     "No match",
+			query:    "zzz",
+			maxDepth: 10,
+			expected: []string{},
+		},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{Location: root}
+			names := collectRecursiveSearch(t.Context(), recursiveWalk{
+				id:             1,
+				root:           root,
+				query:          tt.query,
+				displayDotFile: tt.displayDotFile,
+				maxDepth:       tt.maxDepth,
+				readDir:        m.readDirFunc(),
+			})
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestWalkRecursiveSearchCancelled(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupFiles(t, filepath.Join(root, "file"))
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	names := collectRecursiveSearch(ctx, recursiveWalk{
+		id:       1,
+		root:     root,
+		query:    "file",
+		maxDepth: 10,
+		readDir:  func(location string) ([]os.DirEntry, error) { return os.ReadDir(location) },
+	})
+	assert.Empty(t, names, "a cancelled walk sends nothing")
+}
+
+func TestRecursiveSearchLifeCycle(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(root, "dir"))
+	utils.SetupFiles(t, filepath.Join(root, "dir", "file"))
+	m := Model{
+		Location:         root,
+		DirectoryRecords: make(map[string]directoryRecord),
+		selected:         make(map[string]int),
+		SearchBar:        textinput.New(),
+	}
+
+	m.SearchBar.SetValue("file")
+	assert.Nil(t, m.SyncRecursiveSearch(false, 10), "no search outside of recursive mode")
+	// The prompt set by ToggleRecursiveSearch needs the hotkeys
+	m.recursive.enabled = true
+	results := m.SyncRecursiveSearch(false, 10)
+	require.NotNil(t, results)
+	assert.Nil(t, m.SyncRecursiveSearch(false, 10), "the query didn't change")
+	assert.True(t, m.RecursiveSearchRunning())
+
+	batch := <-results
+	assert.True(t, batch.Done)
+	require.True(t, m.ApplyRecursiveSearchBatch(batch))
+	assert.False(t, m.RecursiveSearchRunning())
+	require.Equal(t, 1, m.ElemCount())
+	assert.Equal(t, filepath.Join("dir", "file"), m.GetFocusedItem().Name)
+	assert.Equal(t, filepath.Join(root, "dir", "file"), m.GetFocusedItem().Location)
+
+	// A new query replaces the search, and the batches of the old one are ignored
+	m.SearchBar.SetValue("dir")
+	require.NotNil(t, m.SyncRecursiveSearch(false, 10))
+	assert.False(t, m.ApplyRecursiveSearchBatch(batch))
+
+	m.LeaveRecursiveResults()
+	assert.False(t, m.RecursiveSearchRunning())
+	assert.True(t, m.IsRecursiveSearch(), "the mode is kept")
+}
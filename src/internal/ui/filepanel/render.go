@@ -3,6 +3,7 @@ package filepanel
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/yorukot/superfile/src/config/icon"
@@ -59,13 +60,27 @@ func (m *Model) renderFooter(r *rendering.Renderer, selectedCount uint) {
 		sortLabel = sortIcon + " " + sortLabel
 	}
 
+	// The git and comparison info are left out first when there is not enough
+	// space
+	extraInfo := slices.DeleteFunc([]string{m.gitFooterInfo(), m.compareFooterInfo()},
+		func(info string) bool { return info == "" })
+	withExtraInfo := func(items ...string) []string {
+		return append(append(items[:len(items)-1:len(items)-1], extraInfo...), items[len(items)-1])
+	}
+
 	if common.Config.ShowPanelFooterInfo {
-		r.SetBorderInfoItems(sortLabel, modeLabel, cursorStr)
+		r.SetBorderInfoItems(withExtraInfo(sortLabel, modeLabel, cursorStr)...)
+		if r.AreInfoItemsTruncated() {
+			r.SetBorderInfoItems(withExtraInfo(sortIcon, modeIcon, cursorStr)...)
+		}
 		if r.AreInfoItemsTruncated() {
 			r.SetBorderInfoItems(sortIcon, modeIcon, cursorStr)
 		}
 	} else {
-		r.SetBorderInfoItems(cursorStr)
+		r.SetBorderInfoItems(withExtraInfo(cursorStr)...)
+		if r.AreInfoItemsTruncated() {
+			r.SetBorderInfoItems(cursorStr)
+		}
 	}
 }
 
@@ -112,6 +127,8 @@ func (m *Model) getPanelModeInfo(selectedCount uint) (string, string) {
 		return "Browser", icon.Browser
 	case SelectMode:
 		return "Select" + icon.Space + fmt.Sprintf("(%d)", selectedCount), icon.Select
+	case TreeMode:
+		return "Tree", icon.Tree
 	default:
 		return "", ""
 	}
@@ -122,6 +139,10 @@ func (m *Model) getCursorString() string {
 	if !m.Empty() {
 		cursor++ // Convert to 1-based
 	}
+	// More results of the recursive search are coming
+	if m.RecursiveSearchRunning() {
+		return fmt.Sprintf("%d/%d+", cursor, m.ElemCount())
+	}
 	return fmt.Sprintf("%d/%d", cursor, m.ElemCount())
 }
 
@@ -144,6 +165,10 @@ func (m *Model) renderSelectBox(isSelected bool) string {
 
 // Checks whether a panel needs re-render due to being invalid or due to directory change
 func (m *Model) NeedsReRender() bool {
+	if m.InRecursiveResults() {
+		// The results are in subdirectories, and are updated as they come
+		return false
+	}
 	if !m.EmptyOrInvalid() {
 		return filepath.Dir(m.GetFirstElement().Location) != m.Location
 	}
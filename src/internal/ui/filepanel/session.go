@@ -0,0 +1,41 @@
+package filepanel
+
+import (
+	"github.com/yorukot/superfile/src/internal/session"
+)
+
+// SessionState returns the state of the panel kept in a session
+func (m *Model) SessionState() session.Panel {
+	state := session.Panel{
+		Location: m.Location,
+		Sort:     m.SortOptions(),
+		Tree:     m.IsTreeView(),
+		Select:   m.PanelMode == SelectMode,
+		Search:   m.SearchBar.Value(),
+	}
+	if !m.EmptyOrInvalid() {
+		state.Target = m.GetFocusedItem().Name
+	}
+	return state
+}
+
+// FromSession makes a panel in the state kept in a session. The cursor is
+// moved on the target once the elements are read. Returns an error if the
+// location cannot be browsed anymore
+func FromSession(state session.Panel, focused bool) (Model, error) {
+	m := New(state.Location, focused, state.Target, state.Sort.Kind, state.Sort.Reversed)
+	// New leaves the archive unset when the location cannot be browsed
+	if _, err := m.resolveArchive(state.Location); err != nil {
+		m.CloseArchive()
+		return Model{}, err
+	}
+	m.Directories = state.Sort.Directories
+	if state.Tree {
+		m.ToggleTreeMode()
+	}
+	if state.Select {
+		m.ChangeFilePanelMode()
+	}
+	m.SearchBar.SetValue(state.Search)
+	return m, nil
+}
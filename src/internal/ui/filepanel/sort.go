@@ -1,116 +1,198 @@
 package filepanel
 
 import (
+	"cmp"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fvbommel/sortorder"
 
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 )
 
-func getOrderingFunc(elements []Element, reversed bool, sortKind sortmodel.SortKind) sliceOrderFunc {
-	var order func(i, j int) bool
+// readDirFunc lists the children of a directory, either on disk or inside an archive
+type readDirFunc func(location string) ([]os.DirEntry, error)
+
+// elementCompareFunc compares two elements, like strings.Compare
+type elementCompareFunc func(a, b Element) int
+
+// getOrderingFunc orders the elements following opts. The directories come
+// first if opts.ListDirectoriesFirst, and the names break the ties
+func getOrderingFunc(elements []Element, opts sortmodel.Options, readDir readDirFunc,
+	dirSizes *dirsize.Cache) sliceOrderFunc {
+	compare := getCompareFunc(opts.Kind, readDir, dirSizes)
+	directoriesFirst := opts.ListDirectoriesFirst()
+	return func(i, j int) bool {
+		// One of them is a directory, and other is not
+		if directoriesFirst && elements[i].Directory != elements[j].Directory {
+			return elements[i].Directory
+		}
+		result := compare(elements[i], elements[j])
+		if result == 0 {
+			result = compareNames(elements[i].Name, elements[j].Name)
+		}
+		if result == 0 {
+			// Same names but for the case
+			result = strings.Compare(elements[i].Name, elements[j].Name)
+		}
+		if opts.Reversed {
+			return result > 0
+		}
+		return result < 0
+	}
+}
+
+func getCompareFunc(sortKind sortmodel.SortKind, readDir readDirFunc, dirSizes *dirsize.Cache) elementCompareFunc {
 	switch sortKind {
 	case sortmodel.SortByName:
-		order = func(i, j int) bool {
-			// One of them is a directory, and other is not
-			if elements[i].Directory != elements[j].Directory {
-				return elements[i].Directory
-			}
-			if common.Config.CaseSensitiveSort {
-				return elements[i].Name < elements[j].Name != reversed
-			}
-			return strings.ToLower(elements[i].Name) < strings.ToLower(elements[j].Name) != reversed
+		return func(a, b Element) int {
+			return compareNames(a.Name, b.Name)
 		}
 	case sortmodel.SortBySize:
-		order = getSizeOrderingFunc(elements, reversed)
+		if dirSizes != nil {
+			return getTotalSizeCompareFunc(dirSizes)
+		}
+		return getSizeCompareFunc(readDir)
 	case sortmodel.SortByDate:
-		order = func(i, j int) bool {
-			return elements[i].Info.ModTime().After(elements[j].Info.ModTime()) != reversed
+		// The most recent first
+		return func(a, b Element) int {
+			return b.Info.ModTime().Compare(a.Info.ModTime())
 		}
 	case sortmodel.SortByType:
-		order = getTypeOrderingFunc(elements, reversed)
+		return compareByKey(func(elem Element) string {
+			if elem.Directory {
+				return ""
+			}
+			return strings.ToLower(filepath.Ext(elem.Name))
+		})
 	case sortmodel.SortByNatural:
-		order = func(i, j int) bool {
-			// One of them is a directory, and other is not
-			if elements[i].Directory != elements[j].Directory {
-				return elements[i].Directory
+		return func(a, b Element) int {
+			nameA, nameB := caseFolded(a.Name), caseFolded(b.Name)
+			switch {
+			case sortorder.NaturalLess(nameA, nameB):
+				return -1
+			case sortorder.NaturalLess(nameB, nameA):
+				return 1
+			default:
+				return 0
 			}
-			if common.Config.CaseSensitiveSort {
-				return sortorder.NaturalLess(elements[i].Name, elements[j].Name) != reversed
+		}
+	case sortmodel.SortByExtension:
+		return compareByKey(func(elem Element) string {
+			if elem.Directory {
+				return ""
 			}
-			return sortorder.NaturalLess(
-				strings.ToLower(elements[i].Name),
-				strings.ToLower(elements[j].Name),
-			) != reversed
+			return fullExtension(elem.Name)
+		})
+	case sortmodel.SortByBirthTime:
+		birthTime := memoize(func(elem Element) time.Time {
+			return fileBirthTime(elem.Location, elem.Info)
+		})
+		return func(a, b Element) int {
+			return birthTime(b).Compare(birthTime(a))
+		}
+	case sortmodel.SortByAccessTime:
+		return func(a, b Element) int {
+			return fileAccessTime(b.Info).Compare(fileAccessTime(a.Info))
+		}
+	case sortmodel.SortByVersion:
+		return func(a, b Element) int {
+			return compareVersions(caseFolded(a.Name), caseFolded(b.Name))
 		}
 	}
-	return order
+	return func(_, _ Element) int { return 0 }
 }
 
-func getSizeOrderingFunc(elements []Element, reversed bool) sliceOrderFunc {
-	return func(i, j int) bool {
-		// Directories at the top sorted by direct child count (not recursive)
-		// Files sorted by size
-
-		// One of them is a directory, and other is not
-		if elements[i].Directory != elements[j].Directory {
-			return elements[i].Directory
+// getSizeCompareFunc compares the files by size, and the directories by their
+// count of direct children
+func getSizeCompareFunc(readDir readDirFunc) elementCompareFunc {
+	childCount := memoize(func(elem Element) int {
+		files, err := readDir(elem.Location)
+		// No need of early return, we only call len() on files, so nil would
+		// just result in 0
+		if err != nil {
+			slog.Error("Error when reading directory during sort", "error", err)
 		}
-
-		// This needs to be improved, and we should sort by actual size only
-		// Repeated recursive read would be slow, so we could cache
-		if elements[i].Directory && elements[j].Directory {
-			filesI, err := os.ReadDir(elements[i].Location)
-			// No need of early return, we only call len() on filesI, so nil would
-			// just result in 0
-			if err != nil {
-				slog.Error("Error when reading directory during sort", "error", err)
-			}
-			filesJ, err := os.ReadDir(elements[j].Location)
-			if err != nil {
-				slog.Error("Error when reading directory during sort", "error", err)
-			}
-			return len(filesI) < len(filesJ) != reversed
+		return len(files)
+	})
+	return func(a, b Element) int {
+		if a.Directory && b.Directory {
+			return cmp.Compare(childCount(a), childCount(b))
 		}
-		return elements[i].Info.Size() < elements[j].Info.Size() != reversed
+		return cmp.Compare(a.Info.Size(), b.Info.Size())
 	}
 }
 
-func getTypeOrderingFunc(elements []Element, reversed bool) sliceOrderFunc {
-	return func(i, j int) bool {
-		// One of them is a directory, and the other is not
-		if elements[i].Directory != elements[j].Directory {
-			return elements[i].Directory
-		}
-
-		var extI, extJ string
-		if !elements[i].Directory {
-			extI = strings.ToLower(filepath.Ext(elements[i].Name))
+// getTotalSizeCompareFunc compares the directories by the total size of their
+// files, computed in the background. The ones not computed yet come last
+func getTotalSizeCompareFunc(dirSizes *dirsize.Cache) elementCompareFunc {
+	size := func(elem Element) int64 {
+		if !elem.Directory {
+			return elem.Info.Size()
 		}
-		if !elements[j].Directory {
-			extJ = strings.ToLower(filepath.Ext(elements[j].Name))
+		total, ok := dirSizes.Size(elem.Location, elem.Info.ModTime())
+		if !ok {
+			return -1
 		}
+		return total
+	}
+	return func(a, b Element) int {
+		return cmp.Compare(size(a), size(b))
+	}
+}
 
-		// Compare by extension/type
-		if extI != extJ {
-			return (extI < extJ) != reversed
-		}
+func compareByKey(key func(elem Element) string) elementCompareFunc {
+	return func(a, b Element) int {
+		return strings.Compare(key(a), key(b))
+	}
+}
 
-		// If same type, fall back to name
-		if common.Config.CaseSensitiveSort {
-			return (elements[i].Name < elements[j].Name) != reversed
+// memoize caches the values of f by element Location, for the values that
+// need syscalls
+func memoize[T any](f func(elem Element) T) func(elem Element) T {
+	values := make(map[string]T)
+	return func(elem Element) T {
+		value, ok := values[elem.Location]
+		if !ok {
+			value = f(elem)
+			values[elem.Location] = value
 		}
-		return (strings.ToLower(elements[i].Name) < strings.ToLower(elements[j].Name)) != reversed
+		return value
+	}
+}
+
+func caseFolded(name string) string {
+	if common.Config.CaseSensitiveSort {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+func compareNames(a, b string) int {
+	return strings.Compare(caseFolded(a), caseFolded(b))
+}
+
+// fullExtension returns the extension of name, with all its parts, like
+// tar.gz. The parts starting with a digit are left out, to not take a version
+// like in file-1.10.txt, and so are the leading dots of hidden files
+func fullExtension(name string) string {
+	parts := strings.Split(strings.TrimLeft(name, "."), ".")
+	first := len(parts)
+	for first > 1 && parts[first-1] != "" && !isDigit(parts[first-1][0]) {
+		first--
 	}
+	return strings.ToLower(strings.Join(parts[first:], "."))
 }
 
-func sortFileElement(sortKind sortmodel.SortKind, reversed bool, dirEntries []os.DirEntry, location string) []Element {
+// sortFileElement makes the elements of the entries of the directory at
+// location, sorted following the panel sort
+func (m *Model) sortFileElement(dirEntries []os.DirEntry, location string) []Element {
 	elements := make([]Element, 0, len(dirEntries))
 	for _, item := range dirEntries {
 		info, err := item.Info()
@@ -128,7 +210,7 @@ func sortFileElement(sortKind sortmodel.SortKind, reversed bool, dirEntries []os
 		})
 	}
 
-	sort.Slice(elements, getOrderingFunc(elements, reversed, sortKind))
+	sort.Slice(elements, getOrderingFunc(elements, m.SortOptions(), m.readDir, m.DirSizes))
 
 	return elements
 }
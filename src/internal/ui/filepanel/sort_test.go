@@ -0,0 +1,110 @@
+package filepanel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestCompareVersions(t *testing.T) {
+	testdata := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"file-1.9", "file-1.10", -1},
+		{"file-1.10", "file-1.9", 1},
+		{"file-1.0~rc1", "file-1.0", -1},
+		{"file-1.0~rc1.tar.gz", "file-1.0.tar.gz", -1},
+		{"file-1.0a", "file-1.0.1", -1},
+		{"file-01", "file-1", 0},
+		{"same", "same", 0},
+		{"a", "b", -1},
+	}
+	for _, tt := range testdata {
+		assert.Equal(t, tt.expected, compareVersions(tt.a, tt.b), "%s and %s", tt.a, tt.b)
+	}
+}
+
+func TestFullExtension(t *testing.T) {
+	assert.Equal(t, "tar.gz", fullExtension("archive.TAR.gz"))
+	assert.Empty(t, fullExtension("README"))
+	assert.Empty(t, fullExtension(".bashrc"))
+	assert.Equal(t, "bak", fullExtension(".bashrc.bak"))
+	assert.Equal(t, "txt", fullExtension("file-1.10.txt"))
+}
+
+func TestSortKinds(t *testing.T) {
+	curTestDir := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(curTestDir, "z_dir"))
+	utils.SetupFiles(t,
+		filepath.Join(curTestDir, "b.tar.gz"),
+		filepath.Join(curTestDir, "a.gz"),
+		filepath.Join(curTestDir, "c.tar.gz"),
+		filepath.Join(curTestDir, "file-1.10.txt"),
+		filepath.Join(curTestDir, "file-1.9.txt"),
+		filepath.Join(curTestDir, "file-1.10~rc1.txt"),
+	)
+
+	testdata := []struct {
+		name              string
+		opts              sortmodel.Options
+		expectedElemNames []string
+	}{
+		{
+			name: "Extension, the names breaking the ties",
+			opts: sortmodel.Options{Kind: sortmodel.SortByExtension},
+			expectedElemNames: []string{"z_dir", "a.gz", "b.tar.gz", "c.tar.gz", "file-1.10.txt",
+				"file-1.10~rc1.txt", "file-1.9.txt"},
+		},
+		{
+			name: "Extension reversed, the ties reversed too",
+			opts: sortmodel.Options{Kind: sortmodel.SortByExtension, Reversed: true},
+			expectedElemNames: []string{"z_dir", "file-1.9.txt", "file-1.10~rc1.txt", "file-1.10.txt",
+				"c.tar.gz", "b.tar.gz", "a.gz"},
+		},
+		{
+			name: "Version, directories mixed",
+			opts: sortmodel.Options{Kind: sortmodel.SortByVersion, Directories: sortmodel.DirectoriesMixed},
+			expectedElemNames: []string{"a.gz", "b.tar.gz", "c.tar.gz", "file-1.9.txt", "file-1.10~rc1.txt",
+				"file-1.10.txt", "z_dir"},
+		},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			panel := testModel(0, 0, 0, BrowserMode, nil)
+			panel.Location = curTestDir
+			panel.SetSortOptions(tt.opts)
+			actualNames := []string{}
+			for _, elem := range panel.getDirectoryElements(false) {
+				actualNames = append(actualNames, elem.Name)
+			}
+			assert.Equal(t, tt.expectedElemNames, actualNames)
+		})
+	}
+}
+
+func TestApplyDirSort(t *testing.T) {
+	memory := sortmodel.NewMemory("")
+	bySize := sortmodel.Options{Kind: sortmodel.SortBySize, Reversed: true}
+	memory.Set("/remembered", bySize)
+
+	panel := testModel(0, 0, 0, BrowserMode, nil)
+	panel.Location = "/new"
+	panel.SortKind = sortmodel.SortByType
+	panel.ApplyDirSort(memory)
+	assert.Equal(t, sortmodel.SortByType, panel.SortKind, "a new panel keeps its sort")
+
+	panel.Location = "/remembered"
+	panel.ApplyDirSort(memory)
+	assert.Equal(t, bySize, panel.SortOptions())
+	assert.True(t, panel.sortChanged)
+
+	panel.Location = "/other"
+	panel.ApplyDirSort(memory)
+	assert.Equal(t, sortmodel.DefaultOptions(), panel.SortOptions())
+}
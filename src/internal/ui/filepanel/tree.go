@@ -0,0 +1,183 @@
+package filepanel
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// In the tree mode, the directories are expanded in place instead of being
+// entered. The children of an expanded directory are listed right after it,
+// one level deeper. The expanded directories are kept in the DirectoryRecords
+// of the panel's Location, so they are expanded again when coming back to it.
+// The select mode entered from the tree mode keeps showing the tree, so that
+// the nested items can be selected
+
+// IsTreeView tells whether the elements are shown as a tree
+func (m *Model) IsTreeView() bool {
+	return m.PanelMode == TreeMode || (m.PanelMode == SelectMode && m.selectInTree)
+}
+
+// ToggleTreeMode switches between the browser mode and the tree mode. In the
+// select mode, only the view changes, and the selection is kept
+func (m *Model) ToggleTreeMode() {
+	switch m.PanelMode {
+	case BrowserMode:
+		m.PanelMode = TreeMode
+	case TreeMode:
+		m.PanelMode = BrowserMode
+	case SelectMode:
+		m.selectInTree = !m.selectInTree
+	default:
+		slog.Error("Unexpected panelMode", "panelMode", m.PanelMode)
+	}
+}
+
+func (m *Model) expandedDirs() map[string]struct{} {
+	return m.DirectoryRecords[m.Location].expanded
+}
+
+func (m *Model) isExpanded(location string) bool {
+	_, ok := m.expandedDirs()[location]
+	return ok
+}
+
+func (m *Model) setExpanded(location string, expanded bool) {
+	record := m.DirectoryRecords[m.Location]
+	if record.expanded == nil {
+		record.expanded = make(map[string]struct{})
+	}
+	if expanded {
+		record.expanded[location] = struct{}{}
+	} else {
+		// The directories expanded below it are kept, and shown again when
+		// it is expanded again
+		delete(record.expanded, location)
+	}
+	m.DirectoryRecords[m.Location] = record
+}
+
+// ToggleExpandFocused expands the focused directory, or collapses it if it
+// is expanded. Returns false if the focused item is not a directory
+func (m *Model) ToggleExpandFocused() bool {
+	if m.EmptyOrInvalid() || !m.GetFocusedItem().Directory {
+		return false
+	}
+	location := m.GetFocusedItem().Location
+	m.setExpanded(location, !m.isExpanded(location))
+	return true
+}
+
+// CollapseFocused collapses the focused directory if it is expanded, or else
+// the directory containing the focused item, and moves the cursor to it.
+// Returns false if there is nothing to collapse, as the focused item is a
+// collapsed item of Location
+func (m *Model) CollapseFocused() bool {
+	if m.EmptyOrInvalid() {
+		return false
+	}
+	focused := m.GetFocusedItem()
+	if focused.Directory && m.isExpanded(focused.Location) {
+		m.setExpanded(focused.Location, false)
+		return true
+	}
+	if focused.Depth == 0 {
+		return false
+	}
+	parent := filepath.Dir(focused.Location)
+	m.setExpanded(parent, false)
+	if idx := m.FindElementIndexByLocation(parent); idx != -1 {
+		m.scrollToCursor(idx)
+	}
+	return true
+}
+
+// ShownDirs returns the directories whose items are shown: the Location, and
+// the expanded directories in the tree view
+func (m *Model) ShownDirs() []string {
+	dirs := []string{m.Location}
+	if !m.IsTreeView() || m.InRecursiveResults() {
+		return dirs
+	}
+	for _, elem := range m.element {
+		if elem.Directory && m.isExpanded(elem.Location) {
+			dirs = append(dirs, elem.Location)
+		}
+	}
+	return dirs
+}
+
+// expandTree inserts the items of the expanded directories after them
+func (m *Model) expandTree(elements []Element, displayDotFile bool) []Element {
+	if len(m.expandedDirs()) == 0 {
+		return elements
+	}
+	result := make([]Element, 0, len(elements))
+	for _, elem := range elements {
+		result = append(result, elem)
+		if !elem.Directory || !m.isExpanded(elem.Location) {
+			continue
+		}
+		children, err := m.readDirElements(elem.Location, displayDotFile)
+		if err != nil {
+			slog.Debug("Cannot expand directory", "location", elem.Location, "error", err)
+			continue
+		}
+		for i := range children {
+			children[i].Depth = elem.Depth + 1
+		}
+		result = append(result, m.expandTree(children, displayDotFile)...)
+	}
+	return result
+}
+
+// getTreeElementsBySearch returns the items of the tree matching the search,
+// along with the directories containing them to keep the tree readable
+func (m *Model) getTreeElementsBySearch(displayDotFile bool) []Element {
+	elements := m.getDirectoryElements(displayDotFile)
+	names := make([]string, len(elements))
+	for i, elem := range elements {
+		names[i] = elem.Name
+	}
+	keep := make([]bool, len(elements))
+	for _, match := range utils.FzfSearch(m.SearchBar.Value(), names) {
+		keep[match.HayIndex] = true
+	}
+
+	// ancestors[d] is the index of the last element seen at depth d
+	var ancestors []int
+	for i, elem := range elements {
+		ancestors = append(ancestors[:elem.Depth], i)
+		if keep[i] {
+			for _, ancestor := range ancestors {
+				keep[ancestor] = true
+			}
+		}
+	}
+
+	result := make([]Element, 0, len(elements))
+	for i, elem := range elements {
+		if keep[i] {
+			result = append(result, elem)
+		}
+	}
+	return result
+}
+
+// treePrefix returns the indentation and the expanded marker of the element
+func (m *Model) treePrefix(elem Element) string {
+	if !m.IsTreeView() || m.InRecursiveResults() {
+		return ""
+	}
+	marker := " "
+	if elem.Directory {
+		marker = icon.TreeCollapsed
+		if m.isExpanded(elem.Location) {
+			marker = icon.TreeExpanded
+		}
+	}
+	return strings.Repeat("  ", elem.Depth) + marker + " "
+}
@@ -0,0 +1,196 @@
+package filepanel
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/textinput"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func treeTestModel(location string) Model {
+	return Model{
+		Location:         location,
+		PanelMode:        TreeMode,
+		DirectoryRecords: make(map[string]directoryRecord),
+		selected:         make(map[string]int),
+		SearchBar:        textinput.New(),
+		height:           20,
+	}
+}
+
+// treeLines returns the elements as their path from the Location, indented
+// by their depth
+func treeLines(t *testing.T, m *Model) []string {
+	t.Helper()
+	lines := make([]string, 0, m.ElemCount())
+	for _, elem := range m.element {
+		rel, err := filepath.Rel(m.Location, elem.Location)
+		require.NoError(t, err)
+		lines = append(lines, indent(elem.Depth, rel))
+	}
+	return lines
+}
+
+func indent(depth int, path string) string {
+	return strings.Repeat("  ", depth) + path
+}
+
+func setupTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(root, "a", "b"), filepath.Join(root, "c"))
+	utils.SetupFiles(t,
+		filepath.Join(root, "a", "b", "deep.txt"),
+		filepath.Join(root, "a", "file.txt"),
+		filepath.Join(root, "c", "other.txt"),
+		filepath.Join(root, "top.txt"),
+	)
+	return root
+}
+
+func TestTreeExpandCollapse(t *testing.T) {
+	root := setupTree(t)
+	m := treeTestModel(root)
+	m.UpdateElementsIfNeeded(true, false)
+	require.Equal(t, []string{"a", "c", "top.txt"}, treeLines(t, &m))
+
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"a", indent(1, filepath.Join("a", "b")), indent(1, filepath.Join("a", "file.txt")),
+		"c", "top.txt"}, treeLines(t, &m))
+
+	m.ListDown()
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"a", indent(1, filepath.Join("a", "b")),
+		indent(2, filepath.Join("a", "b", "deep.txt")), indent(1, filepath.Join("a", "file.txt")),
+		"c", "top.txt"}, treeLines(t, &m))
+	assert.Equal(t, []string{root, filepath.Join(root, "a"), filepath.Join(root, "a", "b")}, m.ShownDirs())
+
+	// Collapsing from a nested item collapses its directory, and moves to it
+	m.ListDown()
+	require.Equal(t, "deep.txt", m.GetFocusedItem().Name)
+	require.True(t, m.CollapseFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, "b", m.GetFocusedItem().Name)
+	assert.Equal(t, 1, m.GetCursor())
+	assert.Len(t, m.element, 5)
+
+	require.True(t, m.CollapseFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, "a", m.GetFocusedItem().Name)
+	assert.Equal(t, []string{"a", "c", "top.txt"}, treeLines(t, &m))
+	assert.False(t, m.CollapseFocused(), "nothing left to collapse")
+
+	// The nested directories expanded before are expanded again
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	m.ListDown()
+	require.True(t, m.ToggleExpandFocused())
+	m.ListUp()
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	require.Len(t, m.element, 3)
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Len(t, m.element, 6)
+
+	m.scrollToCursor(m.ElemCount() - 1)
+	assert.False(t, m.ToggleExpandFocused(), "a file cannot be expanded")
+}
+
+func TestTreeKeptOnNavigation(t *testing.T) {
+	root := setupTree(t)
+	m := treeTestModel(root)
+	m.UpdateElementsIfNeeded(true, false)
+	require.True(t, m.ToggleExpandFocused())
+
+	require.NoError(t, m.UpdateCurrentFilePanelDir(filepath.Join(root, "c")))
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"other.txt"}, treeLines(t, &m))
+
+	require.NoError(t, m.ParentDirectory())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Len(t, m.element, 5, "a is still expanded")
+
+	// The browser mode shows the items of the Location only
+	m.ToggleTreeMode()
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, BrowserMode, m.PanelMode)
+	assert.Equal(t, []string{"a", "c", "top.txt"}, treeLines(t, &m))
+	assert.Equal(t, []string{root}, m.ShownDirs())
+}
+
+func TestTreeSelectMode(t *testing.T) {
+	root := setupTree(t)
+	m := treeTestModel(root)
+	m.UpdateElementsIfNeeded(true, false)
+	require.True(t, m.ToggleExpandFocused())
+
+	m.ChangeFilePanelMode()
+	assert.Equal(t, SelectMode, m.PanelMode)
+	assert.True(t, m.IsTreeView(), "the select mode keeps the tree")
+	m.UpdateElementsIfNeeded(true, false)
+	m.SelectAllItem()
+	assert.Len(t, m.GetSelectedLocationsSortedAsVisible(), 5)
+	assert.Equal(t, []string{
+		filepath.Join(root, "a"), filepath.Join(root, "c"), filepath.Join(root, "top.txt"),
+	}, m.GetSelectedTopLocationsSortedAsVisible(), "items inside a selected directory are left out")
+
+	m.ChangeFilePanelMode()
+	assert.Equal(t, TreeMode, m.PanelMode)
+	assert.Equal(t, uint(0), m.SelectedCount())
+
+	m.ToggleTreeMode()
+	m.ChangeFilePanelMode()
+	assert.False(t, m.IsTreeView())
+	m.ChangeFilePanelMode()
+	assert.Equal(t, BrowserMode, m.PanelMode)
+}
+
+func TestTreeSearch(t *testing.T) {
+	root := setupTree(t)
+	m := treeTestModel(root)
+	m.UpdateElementsIfNeeded(true, false)
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+	m.ListDown()
+	require.True(t, m.ToggleExpandFocused())
+
+	m.SearchBar.SetValue("deep")
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"a", indent(1, filepath.Join("a", "b")),
+		indent(2, filepath.Join("a", "b", "deep.txt"))}, treeLines(t, &m),
+		"the directories containing the matches are kept")
+
+	m.SearchBar.SetValue("txt")
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"a", indent(1, filepath.Join("a", "b")),
+		indent(2, filepath.Join("a", "b", "deep.txt")), indent(1, filepath.Join("a", "file.txt")),
+		"top.txt"}, treeLines(t, &m), "collapsed directories are not searched")
+}
+
+func TestTreePrefix(t *testing.T) {
+	root := setupTree(t)
+	m := treeTestModel(root)
+	m.UpdateElementsIfNeeded(true, false)
+	require.True(t, m.ToggleExpandFocused())
+	m.UpdateElementsIfNeeded(true, false)
+
+	expanded := m.treePrefix(m.GetElementAtIdx(0))
+	nestedCollapsed := m.treePrefix(m.GetElementAtIdx(1))
+	nestedFile := m.treePrefix(m.GetElementAtIdx(2))
+	assert.Equal(t, icon.TreeExpanded+" ", expanded)
+	assert.Equal(t, "  "+icon.TreeCollapsed+" ", nestedCollapsed)
+	assert.Equal(t, ansi.StringWidth(nestedCollapsed), ansi.StringWidth(nestedFile), "the names are aligned")
+
+	m.ToggleTreeMode()
+	assert.Empty(t, m.treePrefix(m.GetElementAtIdx(0)))
+}
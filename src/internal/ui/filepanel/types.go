@@ -7,6 +7,10 @@ import (
 	"charm.land/bubbles/v2/textinput"
 	"charm.land/lipgloss/v2"
 
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/dircompare"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 )
 
@@ -28,6 +32,12 @@ type Model struct {
 
 	SortKind     sortmodel.SortKind
 	SortReversed bool
+	// Whether the directories are listed before the files
+	Directories sortmodel.DirectoriesOrder
+	// Location the sort was last chosen for, see ApplyDirSort
+	sortLocation string
+	// The sort changed, and the elements have to be sorted again
+	sortChanged bool
 
 	PanelMode PanelMode
 	// key is file location, value order of selection
@@ -35,18 +45,42 @@ type Model struct {
 	selectOrderCounter int
 	element            []Element
 	DirectoryRecords   map[string]directoryRecord
+	// Directories visited before and after Location, see history.go
+	history            navigationHistory
 	Rename             textinput.Model
 	Renaming           bool
 	SearchBar          textinput.Model
 	LastTimeGetElement time.Time
-	TargetFile         string             // filename to position cursor on after load
-	columns            []columnDefinition // columns for rendering
+	// The changes in Location are reported by the filesystem watcher, so the
+	// panel is not re-read on a timer
+	Watched    bool
+	TargetFile string             // filename to position cursor on after load
+	columns    []columnDefinition // columns for rendering
+	// Values of the extra columns by column, and by item Location. Cleared
+	// when the elements are read again
+	columnValues map[string]map[string]string
+	// Archive browsed by the panel, if any. Each panel opens its own
+	archive *archivefs.FS
+	// Search of the tree below Location, see recursive_search.go
+	recursive recursiveSearch
+	// The select mode was entered from the tree mode, and keeps showing the tree
+	selectInTree bool
+	// Status of the git work tree of Location, nil if it is not in one, see git.go
+	Git *gitstatus.Repo
+	// Sizes of the directories computed in the background, nil if disabled,
+	// see dir_size.go
+	DirSizes *dirsize.Cache
+	// Comparison of two directory trees, set if Location is in one of them,
+	// see compare.go
+	Compare *dircompare.Comparison
 }
 
 // Record for directory navigation
 type directoryRecord struct {
 	directoryCursor int
 	directoryRender int
+	// Locations of the directories expanded below it in the tree mode
+	expanded map[string]struct{}
 }
 
 // Element within a file panel
@@ -55,6 +89,8 @@ type Element struct {
 	Location  string
 	Directory bool
 	Info      os.FileInfo
+	// Depth in the tree of the panel. Zero for the items of its Location
+	Depth int
 }
 
 // Type representing the mode of the panel
@@ -64,6 +100,8 @@ type PanelMode uint
 const (
 	SelectMode PanelMode = iota
 	BrowserMode
+	// Browser mode where the directories are expanded in place, see tree.go
+	TreeMode
 )
 
 type sliceOrderFunc func(i, j int) bool
@@ -1,9 +1,7 @@
 package filepanel
 
 import (
-	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 
 	"github.com/yorukot/superfile/src/pkg/utils"
@@ -14,15 +12,34 @@ func (m *Model) ChangeFilePanelMode() {
 	case SelectMode:
 		m.ResetSelected()
 		m.PanelMode = BrowserMode
+		if m.selectInTree {
+			m.PanelMode = TreeMode
+		}
+		m.selectInTree = false
 	case BrowserMode:
 		m.PanelMode = SelectMode
+	case TreeMode:
+		m.PanelMode = SelectMode
+		m.selectInTree = true
 	default:
 		slog.Error("Unexpected panelMode", "panelMode", m.PanelMode)
 	}
 }
 
 // This should be the function that is always called whenever we are updating a directory.
+// The directory left is recorded in the navigation history, see history.go
 func (m *Model) UpdateCurrentFilePanelDir(path string) error {
+	previous := m.historyEntry()
+	if err := m.changeDirectory(path); err != nil {
+		return err
+	}
+	if m.Location != previous.location {
+		m.history.visit(previous)
+	}
+	return nil
+}
+
+func (m *Model) changeDirectory(path string) error {
 	slog.Debug("updateCurrentFilePanelDir", "panel.location", m.Location, "path", path)
 	// In case non Absolute path is passed, make sure to resolve it.
 	path = utils.ResolveAbsPath(m.Location, path)
@@ -34,15 +51,18 @@ func (m *Model) UpdateCurrentFilePanelDir(path string) error {
 
 	// NOTE: This could be a configurable feature
 	// Update the cursor and render status in case we switch back to this.
-	m.DirectoryRecords[m.Location] = directoryRecord{
-		directoryCursor: m.cursor,
-		directoryRender: m.renderIndex,
-	}
+	record := m.DirectoryRecords[m.Location]
+	record.directoryCursor = m.cursor
+	record.directoryRender = m.renderIndex
+	m.DirectoryRecords[m.Location] = record
 
-	if info, err := os.Stat(path); err != nil {
-		return fmt.Errorf("%s : no such file or directory, stats err : %w", path, err)
-	} else if !info.IsDir() {
-		return fmt.Errorf("%s is not a directory", path)
+	archive, err := m.resolveArchive(path)
+	if err != nil {
+		return err
+	}
+	if archive != m.archive {
+		m.CloseArchive()
+		m.archive = archive
 	}
 
 	// In case of switching to parent, explicitly set focus.
@@ -72,6 +92,7 @@ func (m *Model) UpdateCurrentFilePanelDir(path string) error {
 	// Reset the searchbar Value
 	// TODO(Refactoring) : Have a common searchBar type for sidebar and this search bar.
 	m.SearchBar.SetValue("")
+	m.StopRecursiveSearch()
 
 	return nil
 }
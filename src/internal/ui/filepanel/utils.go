@@ -1,6 +1,12 @@
 package filepanel
 
-import "math"
+import (
+	"math"
+	"path/filepath"
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+)
 
 func (m *Model) GetCursor() int {
 	return m.cursor
@@ -99,6 +105,20 @@ func (m *Model) GetSelectedLocationsSortedAsVisible() []string {
 	return result
 }
 
+// Returns the selected locations like GetSelectedLocationsSortedAsVisible, except
+// the ones inside a selected directory, which an operation on the directory covers.
+// Only the tree view and the recursive search can show such nested items
+func (m *Model) GetSelectedTopLocationsSortedAsVisible() []string {
+	return slices.DeleteFunc(m.GetSelectedLocationsSortedAsVisible(), func(location string) bool {
+		for dir := filepath.Dir(location); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if m.CheckSelected(dir) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
 func (m *Model) GetFirstSelectedLocation() string {
 	if len(m.selected) == 0 {
 		return ""
@@ -137,8 +157,56 @@ func (m *Model) EmptyOrInvalid() bool {
 	return m.Empty() || m.ValidateCursorAndRenderIndex() != nil
 }
 
+// SortOptions returns the sort of the panel
+func (m *Model) SortOptions() sortmodel.Options {
+	return sortmodel.Options{Kind: m.SortKind, Reversed: m.SortReversed, Directories: m.Directories}
+}
+
+// SetSortOptions changes the sort of the panel. The elements are sorted again
+// on the next update
+func (m *Model) SetSortOptions(opts sortmodel.Options) {
+	m.SortKind = opts.Kind
+	m.SortReversed = opts.Reversed
+	m.Directories = opts.Directories
+	m.sortChanged = true
+}
+
 func (m *Model) ToggleReverseSort() {
-	m.SortReversed = !m.SortReversed
+	opts := m.SortOptions()
+	opts.Reversed = !opts.Reversed
+	m.SetSortOptions(opts)
+}
+
+// ToggleDirectoriesFirst lists the directories before the files, or mixes
+// them, whatever the kind of sort
+func (m *Model) ToggleDirectoriesFirst() {
+	opts := m.SortOptions()
+	opts.Directories = sortmodel.DirectoriesFirst
+	if opts.ListDirectoriesFirst() {
+		opts.Directories = sortmodel.DirectoriesMixed
+	}
+	m.SetSortOptions(opts)
+}
+
+// ApplyDirSort sorts the panel with the sort chosen for its Location, once
+// it navigated there. The directories without one get the default sort, but
+// a new panel keeps the sort it was made with
+func (m *Model) ApplyDirSort(memory *sortmodel.Memory) {
+	if m.sortLocation == m.Location {
+		return
+	}
+	isNewPanel := m.sortLocation == ""
+	m.sortLocation = m.Location
+	opts, ok := memory.Get(m.Location)
+	if !ok {
+		if isNewPanel {
+			return
+		}
+		opts = sortmodel.DefaultOptions()
+	}
+	if opts != m.SortOptions() {
+		m.SetSortOptions(opts)
+	}
 }
 
 // SetCursorPosition sets cursor and updates renderIndex accordingly.
@@ -0,0 +1,63 @@
+package filepanel
+
+import (
+	"cmp"
+	"strings"
+)
+
+// compareVersions compares names holding version numbers, like sort -V. The
+// numbers are compared by value, and a '~' sorts before anything, even the
+// end of the name, so that file-1.0~rc1 comes before file-1.0
+func compareVersions(a, b string) int {
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			orderA, orderB := versionCharOrder(a), versionCharOrder(b)
+			if orderA != orderB {
+				return cmp.Compare(orderA, orderB)
+			}
+			// Same non digit character in both
+			a, b = a[1:], b[1:]
+		}
+		var numberA, numberB string
+		numberA, a = cutNumber(a)
+		numberB, b = cutNumber(b)
+		if len(numberA) != len(numberB) {
+			return cmp.Compare(len(numberA), len(numberB))
+		}
+		if result := strings.Compare(numberA, numberB); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// versionCharOrder returns the order of the first character of s, in a
+// non digit part. Letters come before the other characters, and the end of
+// the part before both
+func versionCharOrder(s string) int {
+	switch {
+	case s == "" || isDigit(s[0]):
+		return 0
+	case s[0] == '~':
+		return -1
+	case ('a' <= s[0] && s[0] <= 'z') || ('A' <= s[0] && s[0] <= 'Z'):
+		return int(s[0])
+	default:
+		return int(s[0]) + 256 //nolint:mnd // After all the letters
+	}
+}
+
+// cutNumber returns the number at the start of s, without its leading
+// zeros, and the rest of s
+func cutNumber(s string) (string, string) {
+	s = strings.TrimLeft(s, "0")
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return s[:end], s[end:]
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -64,6 +64,16 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Open zoxide navigation",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.OpenContentSearch,
+			description:    "Search in the content of the files",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ContentSearchOpenInEditor,
+			description:    "Edit the file of the selected match, at its line (content search only)",
+			hotkeyWorkType: globalType,
+		},
 		{
 			subTitle: "Panel navigation",
 		},
@@ -97,6 +107,11 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Toggle reverse sort",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleDirectoriesFirst,
+			description:    "Toggle listing the directories first",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ToggleFooter,
 			description:    "Toggle footer",
@@ -127,6 +142,80 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Focus on the metadata panel",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.FocusOnParentColumn,
+			description:    "Focus on the parent directory column (miller columns)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Tabs",
+		},
+		{
+			hotkey:         common.Hotkeys.NewTab,
+			description:    "Open a new tab in the directory of the focused panel",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.CloseTab,
+			description:    "Close the tab and its panels",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.NextTab,
+			description:    "Switch to the next tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PreviousTab,
+			description:    "Switch to the previous tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.RenameTab,
+			description:    "Rename the tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Marks and history",
+		},
+		{
+			hotkey:         common.Hotkeys.SetMark,
+			description:    "Mark the directory with the next letter pressed",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.JumpToMark,
+			description:    "Go to the directory marked with the next letter pressed",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.HistoryBack,
+			description:    "Go back to the previous directory of the panel",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.HistoryForward,
+			description:    "Go forward to the next directory of the panel",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Compare directories",
+		},
+		{
+			hotkey:         common.Hotkeys.ComparePanels,
+			description:    "Compare the directories of the focused and next panels, or stop",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ComparePanelsByContent,
+			description:    "Compare the directories, and the content of the files",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.SelectDifferences,
+			description:    "Select the items that differ from the other directory",
+			hotkeyWorkType: globalType,
+		},
 		{
 			subTitle: "Panel movement",
 		},
@@ -180,11 +269,31 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Toggle active search bar",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleRecursiveSearch,
+			description:    "Toggle search in subdirectories (in the search bar)",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ChangePanelMode,
 			description:    "Change between selection mode or normal mode",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleTreeMode,
+			description:    "Toggle tree mode (expand folders in place)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.Confirm,
+			description:    "Expand or collapse folder (in tree mode)",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ParentDirectory,
+			description:    "Collapse folder (in tree mode)",
+			hotkeyWorkType: normalType,
+		},
 		{
 			hotkey:         common.Hotkeys.PinnedDirectory,
 			description:    "Pin or Unpin folder to sidebar (can be auto saved)",
@@ -203,6 +312,16 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Rename file or folder",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.BulkRename,
+			description:    "Rename selected items in the editor",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PatternRename,
+			description:    "Rename selected items with a pattern",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.CopyItems,
 			description:    "Copy selected items to the clipboard",
@@ -218,6 +337,21 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Paste clipboard items into the current file panel",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.PasteSymlink,
+			description:    "Paste clipboard items as symlinks with absolute paths",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PasteRelativeSymlink,
+			description:    "Paste clipboard items as symlinks with relative paths",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PasteHardlink,
+			description:    "Paste clipboard items as hardlinks",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.DeleteItems,
 			description:    "Delete selected items",
@@ -228,6 +362,31 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Permanently delete selected items",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ChangePermissions,
+			description:    "Change the permissions and owner of selected items",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.Undo,
+			description:    "Undo the last file operation",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.Redo,
+			description:    "Redo the last undone file operation",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.CancelProcess,
+			description:    "Cancel the selected process (process bar only)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PauseProcess,
+			description:    "Pause or resume the selected process (process bar only)",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.CopyPath,
 			description:    "Copy current or selected file/directory paths",
@@ -245,7 +404,7 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 		},
 		{
 			hotkey:         common.Hotkeys.CompressFile,
-			description:    "Zip file or folder to .zip file",
+			description:    "Compress file or folder to an archive",
 			hotkeyWorkType: normalType,
 		},
 		{
@@ -258,6 +417,88 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Open current directory with default editor",
 			hotkeyWorkType: normalType,
 		},
+		{
+			subTitle: "Conflicts",
+		},
+		{
+			hotkey:         common.Hotkeys.ConflictOverwrite,
+			description:    "Overwrite the existing item",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ConflictOverwriteIfNewer,
+			description:    "Overwrite the existing item if it is older",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ConflictSkip,
+			description:    "Skip the item, and keep the existing one",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ConflictKeepBoth,
+			description:    "Keep both, renaming the new item",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ConflictApplyToAll,
+			description:    "Apply the answer to all the remaining conflicts",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Pattern rename",
+		},
+		{
+			hotkey:         common.Hotkeys.PatternRenameNextField,
+			description:    "Go to the next field",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PatternRenameCycleCase,
+			description:    "Change the case of the new names",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Trash bin",
+		},
+		{
+			hotkey:         common.Hotkeys.TrashBinToggleSelect,
+			description:    "Select or unselect the item",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.TrashBinEmpty,
+			description:    "Empty the trash",
+			hotkeyWorkType: globalType,
+		},
+		{
+			subTitle: "Permissions",
+		},
+		{
+			hotkey:         common.Hotkeys.PermissionToggle,
+			description:    "Toggle the permission or the recursive change",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PermissionNextField,
+			description:    "Go to the next field",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PermissionPreviousField,
+			description:    "Go to the previous field",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PermissionGridLeft,
+			description:    "Go to the permission on the left in the grid",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PermissionGridRight,
+			description:    "Go to the permission on the right in the grid",
+			hotkeyWorkType: globalType,
+		},
 	}
 
 	return data
@@ -21,6 +21,7 @@ const keyGroup = "Group"
 const keyAttributes = "Attributes"
 const keyPath = "Path"
 const keyArchitecture = "Architecture"
+const keyArchive = "Archive"
 const borderSize = 2
 
 // Cache configuration
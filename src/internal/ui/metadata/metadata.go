@@ -81,6 +81,23 @@ func GetMetadata(filePath string, metadataFocused bool, et *exiftool.Exiftool) M
 	return meta
 }
 
+// GetArchiveEntryMetadata returns the metadata stored in the archive for one
+// of its entries, as it's not a file on disk
+func GetArchiveEntryMetadata(filePath string, archivePath string, fileInfo os.FileInfo) Metadata {
+	meta := Metadata{
+		filepath: filePath,
+		data: [][2]string{
+			{keyName, fileInfo.Name()},
+			{keySize, common.FormatFileSize(fileInfo.Size())},
+			{keyDataModified, fileInfo.ModTime().String()},
+			{keyPermissions, fileInfo.Mode().String()},
+			{keyArchive, archivePath},
+		},
+	}
+	sortMetadata(meta.data)
+	return meta
+}
+
 func getSymLinkMetaData(filePath string) Metadata {
 	res := Metadata{
 		filepath: filePath,
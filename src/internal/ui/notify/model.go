@@ -1,6 +1,11 @@
 package notify
 
 import (
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
 	"github.com/yorukot/superfile/src/internal/common"
 )
 
@@ -9,6 +14,8 @@ type Model struct {
 	title         string
 	content       string
 	confirmAction ConfirmActionType
+	choices       []Choice
+	applyToAll    bool
 }
 
 func New(open bool, title string, content string, confirmAction ConfirmActionType) Model {
@@ -20,6 +27,19 @@ func New(open bool, title string, content string, confirmAction ConfirmActionTyp
 	}
 }
 
+// NewWithChoices creates an open modal that offers more answers than confirm
+// and cancel. The user can also toggle whether the answer applies to all the
+// remaining questions of the same operation
+func NewWithChoices(title string, content string, confirmAction ConfirmActionType, choices []Choice) Model {
+	m := New(true, title, content, confirmAction)
+	m.choices = choices
+	return m
+}
+
+func KeyApplyToAll() []string {
+	return common.Hotkeys.ConflictApplyToAll
+}
+
 func (m *Model) GetTitle() string {
 	return m.title
 }
@@ -44,10 +64,34 @@ func (m *Model) GetConfirmAction() ConfirmActionType {
 	return m.confirmAction
 }
 
+func (m *Model) HasChoices() bool {
+	return len(m.choices) > 0
+}
+
+// GetChoiceAction returns the action of the choice triggered by the key
+func (m *Model) GetChoiceAction(key string) (ConfirmActionType, bool) {
+	for _, choice := range m.choices {
+		if slices.Contains(choice.Keys, key) {
+			return choice.Action, true
+		}
+	}
+	return NoAction, false
+}
+
+func (m *Model) ToggleApplyToAll() {
+	m.applyToAll = !m.applyToAll
+}
+
+func (m *Model) IsApplyToAll() bool {
+	return m.applyToAll
+}
+
 // TODO: Remove code duplication with typineModalRender
 func (m *Model) Render() string {
 	var inputKeysText string
-	if m.confirmAction == NoAction {
+	if m.HasChoices() {
+		inputKeysText = m.renderChoices()
+	} else if m.confirmAction == NoAction {
 		inputKeysText = common.ModalOkayInputText
 	} else {
 		inputKeysText = common.ModalConfirmInputText + common.ModalInputSpacingText + common.ModalCancelInputText
@@ -55,3 +99,36 @@ func (m *Model) Render() string {
 	return common.ModalBorderStyle(common.ModalHeight, common.ModalWidth).
 		Render(m.title + "\n\n" + m.content + "\n\n" + inputKeysText)
 }
+
+// Lay out the choice buttons in as many rows as needed to fit the modal
+func (m *Model) renderChoices() string {
+	buttons := make([]string, 0, len(m.choices)+1)
+	for _, choice := range m.choices {
+		buttons = append(buttons, common.ModalConfirm.Render(" ("+choice.Keys[0]+") "+choice.Label+" "))
+	}
+	buttons = append(buttons, common.ModalCancelInputText)
+
+	spacing := lipgloss.NewStyle().Background(common.ModalBGColor).Render("  ")
+	maxWidth := common.ModalWidth - 4 //nolint:mnd // borders and padding
+	var rows []string
+	row := ""
+	for _, button := range buttons {
+		switch {
+		case row == "":
+			row = button
+		case lipgloss.Width(row)+lipgloss.Width(spacing)+lipgloss.Width(button) > maxWidth:
+			rows = append(rows, row)
+			row = button
+		default:
+			row += spacing + button
+		}
+	}
+	rows = append(rows, row)
+
+	checkbox := "[ ]"
+	if m.applyToAll {
+		checkbox = "[x]"
+	}
+	rows = append(rows, checkbox+" ("+KeyApplyToAll()[0]+") Apply to all remaining")
+	return strings.Join(rows, "\n")
+}
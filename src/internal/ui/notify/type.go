@@ -8,4 +8,18 @@ const (
 	QuitAction
 	NoAction
 	PermanentDeleteAction
+	// Answers for an item pasted, restored or extracted where one already exists
+	ConflictOverwriteAction
+	ConflictOverwriteIfNewerAction
+	ConflictSkipAction
+	ConflictKeepBothAction
+	PurgeTrashAction
+	EmptyTrashAction
 )
+
+// Choice is an answer offered by the modal, in addition to confirm and cancel
+type Choice struct {
+	Keys   []string
+	Label  string
+	Action ConfirmActionType
+}
@@ -0,0 +1,19 @@
+package permissionmodel
+
+const (
+	permissionModalDefaultWidth  = 40
+	permissionModalDefaultHeight = 16
+
+	// Width of the labels in front of the fields
+	labelWidth = 13
+	// Width of a cell of the rwx grid, like "[x]" and the spacing
+	gridCellWidth = 6
+
+	// Largest mode that can be typed, with the setuid, setgid and sticky bits
+	maxMode = 0o7777
+
+	// The directories keep their execute bit, that allows to enter them,
+	// and the files lose it, unless the masks are changed
+	defaultDirMask  = 0o7777
+	defaultFileMask = 0o7666
+)
@@ -0,0 +1,106 @@
+package permissionmodel
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/user"
+	"strconv"
+)
+
+var errInvalidMode = errors.New("mode must be 3 or 4 octal digits")
+
+// ParseMode parses an octal mode, like 755 or 4755
+func ParseMode(s string) (uint32, error) {
+	if len(s) < 3 || len(s) > 4 {
+		return 0, errInvalidMode
+	}
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > maxMode {
+		return 0, errInvalidMode
+	}
+	return uint32(mode), nil
+}
+
+// FormatMode formats the mode in octal, with the special bits only if set
+func FormatMode(mode uint32) string {
+	if mode&^uint32(fs.ModePerm) != 0 {
+		return fmt.Sprintf("%04o", mode)
+	}
+	return fmt.Sprintf("%03o", mode)
+}
+
+// ToFileMode converts a Unix mode to the one of os.Chmod
+func ToFileMode(mode uint32) fs.FileMode {
+	fileMode := fs.FileMode(mode) & fs.ModePerm
+	if mode&0o4000 != 0 {
+		fileMode |= fs.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		fileMode |= fs.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		fileMode |= fs.ModeSticky
+	}
+	return fileMode
+}
+
+// FromFileMode converts the mode of a file info to a Unix mode
+func FromFileMode(fileMode fs.FileMode) uint32 {
+	mode := uint32(fileMode.Perm())
+	if fileMode&fs.ModeSetuid != 0 {
+		mode |= 0o4000
+	}
+	if fileMode&fs.ModeSetgid != 0 {
+		mode |= 0o2000
+	}
+	if fileMode&fs.ModeSticky != 0 {
+		mode |= 0o1000
+	}
+	return mode
+}
+
+// LookupUser returns the id of the user with the given name or id, if it is
+// in the user database
+func LookupUser(nameOrID string) (int, error) {
+	usr, err := user.Lookup(nameOrID)
+	if err != nil {
+		var idErr error
+		if usr, idErr = user.LookupId(nameOrID); idErr != nil {
+			return 0, fmt.Errorf("no user %q", nameOrID)
+		}
+	}
+	uid, err := strconv.Atoi(usr.Uid)
+	if err != nil {
+		return 0, fmt.Errorf("user %q has no numeric id", nameOrID)
+	}
+	return uid, nil
+}
+
+// LookupGroup returns the id of the group with the given name or id, if it
+// is in the group database
+func LookupGroup(nameOrID string) (int, error) {
+	grp, err := user.LookupGroup(nameOrID)
+	if err != nil {
+		var idErr error
+		if grp, idErr = user.LookupGroupId(nameOrID); idErr != nil {
+			return 0, fmt.Errorf("no group %q", nameOrID)
+		}
+	}
+	gid, err := strconv.Atoi(grp.Gid)
+	if err != nil {
+		return 0, fmt.Errorf("group %q has no numeric id", nameOrID)
+	}
+	return gid, nil
+}
+
+// ModeFor returns the mode to set to an item of the change
+func (c Change) ModeFor(isDir bool) fs.FileMode {
+	if !c.Recursive {
+		return ToFileMode(c.Mode)
+	}
+	if isDir {
+		return ToFileMode(c.Mode & c.DirMask)
+	}
+	return ToFileMode(c.Mode & c.FileMask)
+}
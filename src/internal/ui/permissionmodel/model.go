@@ -0,0 +1,295 @@
+package permissionmodel
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func New() Model {
+	m := Model{
+		Height: permissionModalDefaultHeight,
+		Width:  permissionModalDefaultWidth,
+	}
+	m.octalInput = m.newInput("755")
+	m.ownerInput = m.newInput("User name or id")
+	m.groupInput = m.newInput("Group name or id")
+	m.dirMaskInput = m.newInput("7777")
+	m.fileMaskInput = m.newInput("7666")
+	return m
+}
+
+func (m *Model) newInput(placeholder string) textinput.Model {
+	ti := common.GenerateRenameTextInput(m.inputWidth(), 0, "")
+	ti.Prompt = ""
+	ti.Placeholder = placeholder
+	ti.Blur()
+	return ti
+}
+
+// Width of the inputs: modal width - borders(2) - cursor(2) - label - padding(1)
+func (m *Model) inputWidth() int {
+	return m.Width - 2 - 2 - labelWidth - 1 //nolint:mnd // see above
+}
+
+func KeyConfirm() []string {
+	return common.Hotkeys.ConfirmTyping
+}
+
+func KeyClose() []string {
+	return slices.Concat(common.Hotkeys.Quit, common.Hotkeys.CancelTyping)
+}
+
+func KeyToggle() []string {
+	return common.Hotkeys.PermissionToggle
+}
+
+func KeyNextField() []string {
+	return common.Hotkeys.PermissionNextField
+}
+
+func KeyPreviousField() []string {
+	return common.Hotkeys.PermissionPreviousField
+}
+
+func KeyLeft() []string {
+	return common.Hotkeys.PermissionGridLeft
+}
+
+func KeyRight() []string {
+	return common.Hotkeys.PermissionGridRight
+}
+
+// Open the modal to change items. The fields start with the mode, owner and
+// group of the focused item. hasDir tells whether some items are directories,
+// that can be changed recursively
+func (m *Model) Open(items []string, mode fs.FileMode, owner string, group string, hasDir bool) {
+	m.open = true
+	m.items = items
+	m.hasDir = hasDir
+	m.cursor = rowUser
+	m.gridCol = 0
+	m.mode = FromFileMode(mode)
+	m.recursive = false
+	m.modeEdited, m.ownerEdited, m.groupEdited = false, false, false
+
+	m.octalInput.SetValue(FormatMode(m.mode))
+	m.ownerInput.SetValue(owner)
+	m.groupInput.SetValue(group)
+	m.dirMaskInput.SetValue(FormatMode(defaultDirMask))
+	m.fileMaskInput.SetValue(FormatMode(defaultFileMask))
+	m.modeErr, m.ownerErr, m.groupErr, m.dirMaskErr, m.fileMaskErr = nil, nil, nil, nil, nil
+	m.blurInputs()
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.items = nil
+	m.cursor = rowUser
+	m.blurInputs()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// IsTyping tells whether the cursor is on a text field, in which case the
+// printable keys are typed in it
+func (m *Model) IsTyping() bool {
+	return m.focusedInput() != nil
+}
+
+// HandleUpdate handles the keys of the modal, except confirming and closing,
+// and passes the others, like a typed key or a cursor blink, to the focused
+// field. Navigating and typing are both done here, so that the key that moves
+// the cursor to a field is not typed in it
+func (m *Model) HandleUpdate(msg tea.Msg) tea.Cmd {
+	if !m.open {
+		return nil
+	}
+	key, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m.updateFocusedInput(msg)
+	}
+	keyStr := key.String()
+	// Printable keys, like 'j', are typed in the text fields
+	typing := m.IsTyping() && utf8.RuneCountInString(keyStr) == 1
+	switch {
+	case slices.Contains(KeyPreviousField(), keyStr),
+		slices.Contains(common.Hotkeys.ListUp, keyStr) && !typing:
+		return m.ListUp()
+	case slices.Contains(KeyNextField(), keyStr),
+		slices.Contains(common.Hotkeys.ListDown, keyStr) && !typing:
+		return m.ListDown()
+	case slices.Contains(KeyLeft(), keyStr) && !m.IsTyping():
+		m.GridLeft()
+	case slices.Contains(KeyRight(), keyStr) && !m.IsTyping():
+		m.GridRight()
+	case slices.Contains(KeyToggle(), keyStr) && !m.IsTyping():
+		m.Toggle()
+	default:
+		return m.updateFocusedInput(msg)
+	}
+	return nil
+}
+
+func (m *Model) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	input := m.focusedInput()
+	if input == nil {
+		return nil
+	}
+	value := input.Value()
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+	if input.Value() != value {
+		m.validate(m.cursor)
+	}
+	return cmd
+}
+
+func (m *Model) focusedInput() *textinput.Model {
+	switch m.cursor {
+	case rowOctal:
+		return &m.octalInput
+	case rowOwner:
+		return &m.ownerInput
+	case rowOwnerGroup:
+		return &m.groupInput
+	case rowDirMask:
+		return &m.dirMaskInput
+	case rowFileMask:
+		return &m.fileMaskInput
+	case rowUser, rowGroup, rowOthers, rowRecursive:
+	}
+	return nil
+}
+
+func (m *Model) blurInputs() {
+	for _, input := range []*textinput.Model{
+		&m.octalInput, &m.ownerInput, &m.groupInput, &m.dirMaskInput, &m.fileMaskInput,
+	} {
+		input.Blur()
+	}
+}
+
+// Toggle the permission under the cursor in the grid, or the recursive change
+func (m *Model) Toggle() {
+	switch m.cursor { //nolint:exhaustive // Only the grid and the checkbox can be toggled
+	case rowUser, rowGroup, rowOthers:
+		m.mode ^= gridBit(m.cursor, m.gridCol)
+		m.octalInput.SetValue(FormatMode(m.mode))
+		m.modeErr = nil
+		m.modeEdited = true
+	case rowRecursive:
+		m.recursive = !m.recursive
+	}
+}
+
+// The bit of the mode shown in the grid at row and col, the user read bit
+// being the top left one
+func gridBit(r row, col int) uint32 {
+	return 1 << (8 - (int(r)*3 + col)) //nolint:mnd // 9 permission bits, 3 per row
+}
+
+// validate checks the field of the row after it was edited. The mode typed in
+// octal is shown in the grid once it is valid
+func (m *Model) validate(r row) {
+	switch r { //nolint:exhaustive // Only the text fields are validated
+	case rowOctal:
+		m.modeEdited = true
+		var mode uint32
+		mode, m.modeErr = ParseMode(m.octalInput.Value())
+		if m.modeErr == nil {
+			m.mode = mode
+		}
+	case rowOwner:
+		m.ownerEdited = true
+		m.ownerErr = nil
+		if owner := m.ownerValue(); owner != "" {
+			_, m.ownerErr = LookupUser(owner)
+		}
+	case rowOwnerGroup:
+		m.groupEdited = true
+		m.groupErr = nil
+		if group := m.groupValue(); group != "" {
+			_, m.groupErr = LookupGroup(group)
+		}
+	case rowDirMask:
+		_, m.dirMaskErr = ParseMode(m.dirMaskInput.Value())
+	case rowFileMask:
+		_, m.fileMaskErr = ParseMode(m.fileMaskInput.Value())
+	}
+}
+
+// The owner typed, empty if it was not edited or left empty, in which case
+// the owners are kept
+func (m *Model) ownerValue() string {
+	if !m.ownerEdited {
+		return ""
+	}
+	return strings.TrimSpace(m.ownerInput.Value())
+}
+
+func (m *Model) groupValue() string {
+	if !m.groupEdited {
+		return ""
+	}
+	return strings.TrimSpace(m.groupInput.Value())
+}
+
+// Err returns the error of the first invalid field. The masks are only
+// checked for a recursive change
+func (m *Model) Err() error {
+	errs := []error{m.modeErr, m.ownerErr, m.groupErr}
+	if m.recursive {
+		errs = append(errs, m.dirMaskErr, m.fileMaskErr)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetChange returns the change to apply, or the error of an invalid field
+func (m *Model) GetChange() (Change, error) {
+	if err := m.Err(); err != nil {
+		return Change{}, err
+	}
+	change := Change{
+		Items:     m.items,
+		SetMode:   m.modeEdited || m.recursive,
+		Mode:      m.mode,
+		UID:       -1,
+		GID:       -1,
+		Recursive: m.recursive,
+	}
+	var errs []error
+	var err error
+	if owner := m.ownerValue(); owner != "" {
+		change.UID, err = LookupUser(owner)
+		errs = append(errs, err)
+	}
+	if group := m.groupValue(); group != "" {
+		change.GID, err = LookupGroup(group)
+		errs = append(errs, err)
+	}
+	if m.recursive {
+		change.DirMask, err = ParseMode(m.dirMaskInput.Value())
+		errs = append(errs, err)
+		change.FileMask, err = ParseMode(m.fileMaskInput.Value())
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Change{}, err
+	}
+	return change, nil
+}
@@ -0,0 +1,201 @@
+package permissionmodel
+
+import (
+	"io/fs"
+	"os/user"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestMain(m *testing.M) {
+	common.Hotkeys.ConfirmTyping = []string{"enter"}
+	common.Hotkeys.CancelTyping = []string{"esc"}
+	common.Hotkeys.Quit = []string{"q", "esc"}
+	common.Hotkeys.ListUp = []string{"up", "k"}
+	common.Hotkeys.ListDown = []string{"down", "j"}
+	common.Hotkeys.PermissionToggle = []string{"space"}
+	common.Hotkeys.PermissionNextField = []string{"tab"}
+	common.Hotkeys.PermissionPreviousField = []string{"shift+tab"}
+	common.Hotkeys.PermissionGridLeft = []string{"left", "h"}
+	common.Hotkeys.PermissionGridRight = []string{"right", "l"}
+	m.Run()
+}
+
+func keyMsg(key string) tea.KeyPressMsg {
+	if len(key) == 1 {
+		return tea.KeyPressMsg{Code: rune(key[0]), Text: key}
+	}
+	switch key {
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "space":
+		return tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	case "backspace":
+		return tea.KeyPressMsg{Code: tea.KeyBackspace}
+	case "right":
+		return tea.KeyPressMsg{Code: tea.KeyRight}
+	}
+	return tea.KeyPressMsg{Code: tea.KeyExtended, Text: key}
+}
+
+func sendKeys(m *Model, keys ...string) {
+	for _, key := range keys {
+		m.HandleUpdate(keyMsg(key))
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	testdata := []struct {
+		input    string
+		expected uint32
+		valid    bool
+	}{
+		{"755", 0o755, true},
+		{"0644", 0o644, true},
+		{"4755", 0o4755, true},
+		{"75", 0, false},
+		{"75555", 0, false},
+		{"789", 0, false},
+		{"rwx", 0, false},
+	}
+	for _, tt := range testdata {
+		mode, err := ParseMode(tt.input)
+		if !tt.valid {
+			require.ErrorIs(t, err, errInvalidMode, tt.input)
+			continue
+		}
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, mode, tt.input)
+	}
+	assert.Equal(t, "644", FormatMode(0o644))
+	assert.Equal(t, "2775", FormatMode(0o2775))
+	assert.Equal(t, fs.ModeSetuid|0o755, ToFileMode(0o4755))
+	assert.Equal(t, uint32(0o1777), FromFileMode(fs.ModeDir|fs.ModeSticky|0o777))
+}
+
+func TestGridAndOctal(t *testing.T) {
+	m := New()
+	m.Open([]string{"/a"}, 0o644, "", "", false)
+	assert.Equal(t, "644", m.octalInput.Value())
+
+	// User execute, then group write
+	sendKeys(&m, "right", "right", "space", "j", "h", "space")
+	assert.Equal(t, uint32(0o764), m.mode)
+	assert.Equal(t, "764", m.octalInput.Value())
+
+	// The printable keys are typed in the octal field, not taken as hotkeys
+	sendKeys(&m, "j", "j")
+	require.True(t, m.IsTyping())
+	m.octalInput.SetValue("")
+	sendKeys(&m, "7", "j")
+	require.ErrorIs(t, m.Err(), errInvalidMode)
+	assert.Equal(t, uint32(0o764), m.mode, "grid kept while the octal is invalid")
+	sendKeys(&m, "backspace", "5", "0")
+	require.NoError(t, m.Err())
+	assert.Equal(t, uint32(0o750), m.mode)
+
+	change, err := m.GetChange()
+	require.NoError(t, err)
+	assert.True(t, change.SetMode)
+	assert.Equal(t, uint32(0o750), change.Mode)
+	assert.Equal(t, -1, change.UID, "owner kept")
+	assert.Equal(t, -1, change.GID, "group kept")
+}
+
+func TestRecursiveRows(t *testing.T) {
+	m := New()
+	m.Open([]string{"/a"}, 0o644, "", "", false)
+	assert.NotContains(t, m.rows(), rowRecursive, "only for directories")
+
+	m.Open([]string{"/dir"}, fs.ModeDir|0o755, "", "", true)
+	for range 6 {
+		m.ListDown()
+	}
+	require.Equal(t, rowRecursive, m.cursor)
+	m.ListDown()
+	assert.Equal(t, rowUser, m.cursor, "wraps to the top")
+
+	m.ListUp()
+	sendKeys(&m, "space")
+	assert.Equal(t, []row{rowUser, rowGroup, rowOthers, rowOctal, rowOwner, rowOwnerGroup,
+		rowRecursive, rowDirMask, rowFileMask}, m.rows())
+	change, err := m.GetChange()
+	require.NoError(t, err)
+	assert.True(t, change.SetMode, "set even if the mode was not edited")
+	assert.Equal(t, fs.FileMode(0o755), change.ModeFor(true))
+	assert.Equal(t, fs.FileMode(0o644), change.ModeFor(false))
+
+	sendKeys(&m, "tab", "tab", "backspace", "backspace", "backspace", "backspace", "7", "7", "7")
+	assert.Equal(t, rowFileMask, m.cursor)
+	change, err = m.GetChange()
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o755), change.ModeFor(false))
+}
+
+func TestOwnership(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skip("No current user", err)
+	}
+	m := New()
+	m.Open([]string{"/a"}, 0o644, "nobody-known", "", false)
+	m.cursor = rowOwner
+	m.ownerInput.SetValue("superfile-no-such-user")
+	m.validate(rowOwner)
+	require.Error(t, m.Err())
+	_, err = m.GetChange()
+	require.Error(t, err)
+
+	m.ownerInput.SetValue(current.Uid)
+	m.validate(rowOwner)
+	require.NoError(t, m.Err())
+	change, err := m.GetChange()
+	require.NoError(t, err)
+	assert.False(t, change.SetMode)
+	uid, err := LookupUser(current.Username)
+	require.NoError(t, err)
+	assert.Equal(t, uid, change.UID)
+
+	// The owner of the focused item is given to the others once edited
+	m.Open([]string{"/a", "/b"}, 0o644, current.Uid, "", false)
+	change, err = m.GetChange()
+	require.NoError(t, err)
+	assert.Equal(t, -1, change.UID, "not edited")
+	m.ownerInput.SetValue(current.Uid)
+	m.validate(rowOwner)
+	change, err = m.GetChange()
+	require.NoError(t, err)
+	assert.Equal(t, uid, change.UID)
+}
+
+func TestSeveralItems(t *testing.T) {
+	m := New()
+	m.Open([]string{"/a", "/b"}, 0o644, "", "", false)
+	change, err := m.GetChange()
+	require.NoError(t, err)
+	assert.False(t, change.SetMode, "not edited")
+
+	// The mode of the focused item is given to the others once edited, even
+	// if it is set back
+	sendKeys(&m, "space", "space")
+	change, err = m.GetChange()
+	require.NoError(t, err)
+	assert.True(t, change.SetMode)
+	assert.Equal(t, uint32(0o644), change.Mode)
+}
+
+func TestRender(t *testing.T) {
+	m := New()
+	m.Open([]string{"/dir", "/b"}, fs.ModeDir|0o750, "", "", true)
+	res := m.Render()
+	assert.Contains(t, res, "Permissions")
+	assert.Contains(t, res, "2 items")
+	assert.Contains(t, res, "750")
+	assert.Contains(t, res, "Recursive")
+	assert.NotContains(t, res, "Dir mask")
+}
@@ -0,0 +1,48 @@
+package permissionmodel
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// The rows shown, the recursive change only being offered for directories
+func (m *Model) rows() []row {
+	rows := []row{rowUser, rowGroup, rowOthers, rowOctal, rowOwner, rowOwnerGroup}
+	if m.hasDir {
+		rows = append(rows, rowRecursive)
+	}
+	if m.hasDir && m.recursive {
+		rows = append(rows, rowDirMask, rowFileMask)
+	}
+	return rows
+}
+
+func (m *Model) ListUp() tea.Cmd {
+	return m.moveCursor(-1)
+}
+
+func (m *Model) ListDown() tea.Cmd {
+	return m.moveCursor(1)
+}
+
+// Move the cursor by delta rows, wrapping around, and focus the text field
+// it lands on
+func (m *Model) moveCursor(delta int) tea.Cmd {
+	rows := m.rows()
+	idx := max(slices.Index(rows, m.cursor), 0)
+	m.cursor = rows[(idx+delta+len(rows))%len(rows)]
+	m.blurInputs()
+	if input := m.focusedInput(); input != nil {
+		return input.Focus()
+	}
+	return nil
+}
+
+func (m *Model) GridLeft() {
+	m.gridCol = (m.gridCol + 2) % 3 //nolint:mnd // 3 columns, moving back one
+}
+
+func (m *Model) GridRight() {
+	m.gridCol = (m.gridCol + 1) % 3 //nolint:mnd // 3 columns
+}
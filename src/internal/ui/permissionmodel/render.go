@@ -0,0 +1,95 @@
+package permissionmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func (m *Model) Render() string {
+	var content strings.Builder
+	content.WriteString(common.ModalTitleStyle.Render(" "+icon.Permissions+icon.Space+"Permissions") + "\n\n")
+
+	header := fmt.Sprintf("%*s", 2+1+labelWidth, "") //nolint:mnd // cursor and padding
+	for _, col := range []string{"Read", "Write", "Exec"} {
+		header += fmt.Sprintf("%-*s", gridCellWidth, col)
+	}
+	content.WriteString(common.ModalStyle.Render(header) + "\n")
+	for _, r := range []row{rowUser, rowGroup, rowOthers} {
+		content.WriteString(m.renderGridRow(r) + "\n")
+	}
+	content.WriteString(m.renderInputRow(rowOctal, "Octal", &m.octalInput) + "\n")
+	content.WriteString(m.renderInputRow(rowOwner, "Owner", &m.ownerInput) + "\n")
+	content.WriteString(m.renderInputRow(rowOwnerGroup, "Group owner", &m.groupInput) + "\n")
+
+	if m.hasDir {
+		content.WriteString(m.renderCursor(rowRecursive) +
+			common.ModalStyle.Render(" "+checkbox(m.recursive)+" Recursive") + "\n")
+	} else {
+		content.WriteString("\n")
+	}
+	if m.hasDir && m.recursive {
+		content.WriteString(m.renderInputRow(rowDirMask, "Dir mask", &m.dirMaskInput) + "\n")
+		content.WriteString(m.renderInputRow(rowFileMask, "File mask", &m.fileMaskInput) + "\n")
+	} else {
+		content.WriteString("\n\n")
+	}
+
+	content.WriteString("\n")
+	if err := m.Err(); err != nil {
+		content.WriteString(common.ModalErrorStyle.Render(" " + err.Error()))
+	}
+	content.WriteString("\n")
+	content.WriteString(common.ModalStyle.Render(
+		keyHint(KeyConfirm(), "Apply")+keyHint(common.Hotkeys.CancelTyping, "Cancel")) + "\n")
+	content.WriteString(common.ModalStyle.Render(keyHint(KeyToggle(), "Toggle") + keyHint(KeyNextField(), "Next field")))
+
+	bottomBorder := common.GenerateFooterBorder(fmt.Sprintf("%d items", len(m.items)), m.Width-common.BorderPadding)
+	return common.SortOptionsModalBorderStyle(m.Height, m.Width, bottomBorder).Render(content.String())
+}
+
+func (m *Model) renderCursor(r row) string {
+	if r == m.cursor {
+		return common.FilePanelCursorStyle.Render(icon.Cursor) + " "
+	}
+	return "  "
+}
+
+// A row of the rwx grid, with the cell under the cursor highlighted
+func (m *Model) renderGridRow(r row) string {
+	labels := map[row]string{rowUser: "User", rowGroup: "Group", rowOthers: "Others"}
+	line := m.renderCursor(r) + common.ModalStyle.Render(fmt.Sprintf(" %-*s", labelWidth, labels[r]))
+	for col := range 3 {
+		cell := checkbox(m.mode&gridBit(r, col) != 0)
+		if r == m.cursor && col == m.gridCol {
+			cell = common.ModalCursorStyle.Render(cell)
+		} else {
+			cell = common.ModalStyle.Render(cell)
+		}
+		line += cell + common.ModalStyle.Render(strings.Repeat(" ", gridCellWidth-len("[ ]")))
+	}
+	return line
+}
+
+func (m *Model) renderInputRow(r row, label string, input *textinput.Model) string {
+	return m.renderCursor(r) + common.ModalStyle.Render(fmt.Sprintf(" %-*s", labelWidth, label)) + input.View()
+}
+
+func checkbox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func keyHint(keys []string, text string) string {
+	key := ""
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	return " (" + key + ") " + text
+}
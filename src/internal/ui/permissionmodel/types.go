@@ -0,0 +1,74 @@
+package permissionmodel
+
+import "charm.land/bubbles/v2/textinput"
+
+// A row of the modal the cursor can be on
+type row int
+
+const (
+	rowUser row = iota
+	rowGroup
+	rowOthers
+	rowOctal
+	rowOwner
+	rowOwnerGroup
+	// Only shown when a directory is changed
+	rowRecursive
+	// Only shown when the change is recursive
+	rowDirMask
+	rowFileMask
+)
+
+// Change is the change confirmed in the modal, to apply to Items. The modes
+// are the Unix ones, like 0o755, with the setuid, setgid and sticky bits
+type Change struct {
+	Items []string
+	// The mode is only set if it was edited, so that the items keep theirs
+	// when only the ownership is changed
+	SetMode bool
+	Mode    uint32
+	// Ids of the user and group to set, -1 to keep them, like for os.Chown
+	UID int
+	GID int
+	// The content of the directories is changed too, and the mode set to a
+	// file or directory is masked by FileMask or DirMask
+	Recursive bool
+	DirMask   uint32
+	FileMask  uint32
+}
+
+// Model of the modal to change the permissions and the ownership of items.
+// The mode can be edited in the rwx grid or typed in octal, both are kept in
+// sync. The change is validated as it is edited, and applied by the main model
+type Model struct {
+	Width  int
+	Height int
+	open   bool
+
+	items  []string
+	hasDir bool
+	cursor row
+	// Column of the rwx grid the cursor is on, for the rows of the grid
+	gridCol int
+
+	mode      uint32
+	recursive bool
+	// The fields edited by the user. Only those are applied, even if set back
+	// to the values of the focused item, as the other items can differ
+	modeEdited  bool
+	ownerEdited bool
+	groupEdited bool
+
+	octalInput    textinput.Model
+	ownerInput    textinput.Model
+	groupInput    textinput.Model
+	dirMaskInput  textinput.Model
+	fileMaskInput textinput.Model
+
+	// Errors of the fields, the change cannot be confirmed while there are some
+	modeErr     error
+	ownerErr    error
+	groupErr    error
+	dirMaskErr  error
+	fileMaskErr error
+}
@@ -24,6 +24,10 @@ import (
 
 func renderDirectoryPreview(r *rendering.Renderer, itemPath string, previewHeight int) string {
 	files, err := os.ReadDir(itemPath)
+	return renderDirEntries(r, files, err, previewHeight)
+}
+
+func renderDirEntries(r *rendering.Renderer, files []fs.DirEntry, err error, previewHeight int) string {
 	if err != nil {
 		slog.Error("Error render directory preview", "error", err)
 		r.AddLines(common.FilePreviewDirectoryUnreadableText)
@@ -0,0 +1,102 @@
+package preview
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/yorukot/ansichroma"
+
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/internal/ui/rendering"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// RenderArchiveEntry is RenderWithPath for an entry of an archive. Only
+// directories and text files are previewed, as other previews need a file
+// on disk
+func (m *Model) RenderArchiveEntry(archive *archivefs.FS, itemPath string, previewWidth int,
+	previewHeight int) (string, string) {
+	r := ui.FilePreviewPanelRenderer(previewHeight, previewWidth)
+	kittyClear := m.imagePreviewer.GetKittyClearRaw()
+
+	contentWidth := previewWidth
+	contentHeight := previewHeight
+	if common.Config.EnableFilePreviewBorder {
+		contentWidth = previewWidth - common.BorderPadding
+		contentHeight = previewHeight - common.BorderPadding
+	}
+
+	name, ok := archive.Contains(itemPath)
+	if !ok {
+		return r.AddLines(common.FilePreviewNoFileInfoText).Render(), kittyClear
+	}
+	fileInfo, err := archive.Stat(name)
+	if err != nil {
+		slog.Error("Error get archive entry info", "error", err)
+		return r.AddLines(common.FilePreviewNoFileInfoText).Render(), kittyClear
+	}
+	if fileInfo.IsDir() {
+		files, err := archive.ReadDir(name)
+		return renderDirEntries(r, files, err, contentHeight), kittyClear
+	}
+	if !fileInfo.Mode().IsRegular() || isImageFile(itemPath) ||
+		slices.Contains(common.UnsupportedPreviewFormats, filepath.Ext(itemPath)) {
+		return r.AddLines(common.FilePreviewUnsupportedFormatText).Render(), kittyClear
+	}
+	return renderArchiveTextPreview(r, archive, name, contentWidth, contentHeight), kittyClear
+}
+
+func renderArchiveTextPreview(r *rendering.Renderer, archive *archivefs.FS, name string,
+	previewWidth, previewHeight int) string {
+	file, err := archive.Open(name)
+	if err != nil {
+		slog.Error("Error open archive entry", "error", err)
+		return r.AddLines(renderPreviewError(err)).Render()
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, common.DefaultBufferSize)
+	format := lexers.Match(path.Base(name))
+	if format == nil {
+		buffer, err := reader.Peek(common.DefaultBufferSize)
+		if err != nil && !errors.Is(err, io.EOF) {
+			slog.Error("Error while checking text file", "error", err)
+			return r.AddLines(renderPreviewError(err)).Render()
+		}
+		if !common.IsBufferPrintable(buffer) {
+			return r.AddLines(common.FilePreviewUnsupportedFormatText).Render()
+		}
+	}
+
+	fileContent, err := utils.ReadContent(reader, previewWidth, previewHeight)
+	if err != nil {
+		slog.Error("Error read archive entry", "error", err)
+		return r.AddLines(renderPreviewError(err)).Render()
+	}
+	if fileContent == "" {
+		return r.AddLines(common.FilePreviewEmptyText).Render()
+	}
+
+	// bat needs a file on disk, so the content is always highlighted with chroma
+	if format != nil {
+		background := ""
+		if !common.Config.TransparentBackground {
+			background = common.Theme.FilePanelBG
+		}
+		fileContent, err = ansichroma.HightlightString(fileContent, format.Config().Name,
+			common.Theme.CodeSyntaxHighlightTheme, background)
+		if err != nil {
+			slog.Error("Error render code highlight", "error", err)
+			return r.AddLines(renderPreviewError(err)).Render()
+		}
+	}
+	return r.AddLines(fileContent).Render()
+}
@@ -0,0 +1,73 @@
+package preview
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/archivefs"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestRenderArchiveEntry(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "test.zip")
+	out, err := os.Create(zipPath)
+	require.NoError(t, err)
+	zw := zip.NewWriter(out)
+	for name, content := range map[string]string{
+		"text.txt":     "abcd\n1234",
+		"binary.bin":   "\x00\x01\x02",
+		"dir/file.txt": "",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, out.Close())
+
+	archive, err := archivefs.Open(zipPath)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	testdata := []struct {
+		name     string
+		entry    string
+		expected string
+	}{
+		{"Text file", "text.txt", "abcd\n1234"},
+		{"Binary file", "binary.bin", common.FilePreviewUnsupportedFormatText},
+		{"Empty file", "dir/file.txt", common.FilePreviewEmptyText},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+			render, _ := m.RenderArchiveEntry(archive, archive.VirtualPath(tt.entry), 10, 2)
+			assert.Equal(t, strings.TrimSpace(ansi.Strip(tt.expected)), trimLines(ansi.Strip(render)))
+		})
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		m := New()
+		render, _ := m.RenderArchiveEntry(archive, zipPath, 20, 4)
+		res := ansi.Strip(render)
+		assert.Contains(t, res, "dir")
+		assert.Contains(t, res, "text.txt")
+	})
+}
+
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,118 @@
+package processbar
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrProcessCancelled = errors.New("process cancelled by user")
+
+// Control lets the user cancel, pause and resume a running process.
+// It is shared between the copies of a Process, so the goroutine doing the
+// work sees the actions done on the process bar.
+// Control is a context.Context, so that it can be passed to file operation
+// loops, which should call Checkpoint between units of work.
+// All methods are safe to call on a nil Control
+type Control struct {
+	context.Context
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	// Non nil while paused. Closed on resume
+	resumeCh chan struct{}
+}
+
+func NewControl() *Control {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &Control{
+		Context: ctx,
+		cancel:  func() { cancel(ErrProcessCancelled) },
+	}
+}
+
+// Cancel the process. A paused process is resumed so that it can stop
+func (c *Control) Cancel() {
+	if c == nil {
+		return
+	}
+	c.cancel()
+	c.Resume()
+}
+
+func (c *Control) IsCancelled() bool {
+	return c != nil && c.Err() != nil
+}
+
+func (c *Control) Pause() {
+	if c == nil || c.IsCancelled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumeCh == nil {
+		c.resumeCh = make(chan struct{})
+	}
+}
+
+func (c *Control) Resume() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumeCh != nil {
+		close(c.resumeCh)
+		c.resumeCh = nil
+	}
+}
+
+func (c *Control) IsPaused() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resumeCh != nil
+}
+
+func (c *Control) TogglePause() {
+	if c.IsPaused() {
+		c.Resume()
+	} else {
+		c.Pause()
+	}
+}
+
+// Checkpoint blocks while the process is paused, and returns
+// ErrProcessCancelled once the process is cancelled
+func (c *Control) Checkpoint() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	resumeCh := c.resumeCh
+	c.mu.Unlock()
+	if resumeCh != nil {
+		select {
+		case <-resumeCh:
+		case <-c.Done():
+		}
+	}
+	if c.Err() != nil {
+		return ErrProcessCancelled
+	}
+	return nil
+}
+
+// Checkpoint is Control.Checkpoint for any context. Contexts other than
+// a Control can only be cancelled, not paused
+func Checkpoint(ctx context.Context) error {
+	if c, ok := ctx.(*Control); ok {
+		return c.Checkpoint()
+	}
+	if ctx.Err() != nil {
+		return ErrProcessCancelled
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package processbar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlPauseResumeCancel(t *testing.T) {
+	c := NewControl()
+	require.NoError(t, c.Checkpoint())
+
+	c.Pause()
+	assert.True(t, c.IsPaused())
+	done := make(chan error, 1)
+	go func() { done <- c.Checkpoint() }()
+
+	select {
+	case <-done:
+		t.Fatal("Checkpoint returned while paused")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.TogglePause()
+	assert.False(t, c.IsPaused())
+	require.NoError(t, <-done)
+
+	c.Pause()
+	go func() { done <- c.Checkpoint() }()
+	c.Cancel()
+	require.ErrorIs(t, <-done, ErrProcessCancelled)
+	assert.True(t, c.IsCancelled())
+	assert.False(t, c.IsPaused())
+
+	// Cancelled processes can't be paused again
+	c.Pause()
+	assert.False(t, c.IsPaused())
+}
+
+func TestCheckpoint(t *testing.T) {
+	var nilControl *Control
+	require.NoError(t, nilControl.Checkpoint())
+	nilControl.Cancel()
+	nilControl.TogglePause()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, Checkpoint(ctx))
+	cancel()
+	require.ErrorIs(t, Checkpoint(ctx), ErrProcessCancelled)
+
+	p := NewProcess("1", "file.txt", OpCopy, 1)
+	require.NoError(t, Checkpoint(p.Context()))
+	p.control.Cancel()
+	require.ErrorIs(t, Checkpoint(p.Context()), ErrProcessCancelled)
+}
+
+func TestCancelFocusedProcess(t *testing.T) {
+	m := NewModelWithOptions(20, 20)
+	running := NewProcess("1", "file.txt", OpCopy, 10)
+	require.NoError(t, m.AddProcess(running))
+
+	m.TogglePauseFocusedProcess()
+	assert.True(t, running.control.IsPaused())
+	m.TogglePauseFocusedProcess()
+	assert.False(t, running.control.IsPaused())
+
+	m.CancelFocusedProcess()
+	assert.True(t, running.control.IsCancelled())
+
+	finished := NewProcess("1", "file.txt", OpCopy, 10)
+	finished.State = Successful
+	m.AddOrUpdateProcess(finished)
+	m.CancelFocusedProcess()
+	assert.False(t, finished.control.IsCancelled())
+}
@@ -90,6 +90,31 @@ func (m *Model) HasRunningProcesses() bool {
 	return false
 }
 
+// Cancel the process under the cursor, if it is still running
+func (m *Model) CancelFocusedProcess() {
+	if p, ok := m.getFocusedRunningProcess(); ok {
+		slog.Debug("Cancelling process", "id", p.ID)
+		p.control.Cancel()
+	}
+}
+
+// Pause the process under the cursor, or resume it if it is paused
+func (m *Model) TogglePauseFocusedProcess() {
+	if p, ok := m.getFocusedRunningProcess(); ok {
+		slog.Debug("Toggling pause of process", "id", p.ID)
+		p.control.TogglePause()
+	}
+}
+
+func (m *Model) getFocusedRunningProcess() (Process, bool) {
+	processes := m.getSortedProcesses()
+	if m.cursor < 0 || m.cursor >= len(processes) {
+		return Process{}, false
+	}
+	p := processes[m.cursor]
+	return p, p.State == InOperation
+}
+
 func (m *Model) Render(processBarFocused bool) string {
 	r := ui.ProcessBarRenderer(m.height, m.width, processBarFocused)
 	if !m.isValid() {
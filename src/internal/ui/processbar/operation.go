@@ -11,6 +11,13 @@ const (
 	OpCompress
 	OpExtract
 	OpCreate
+	OpUndo
+	OpRedo
+	OpRestore
+	OpRename
+	OpSymlink
+	OpHardlink
+	OpPermissions
 )
 
 // GetIcon returns the appropriate icon for the operation type
@@ -28,6 +35,20 @@ func (op OperationType) GetIcon() string {
 		return icon.ExtractFile
 	case OpCreate:
 		return icon.InOperation
+	case OpUndo:
+		return icon.Undo
+	case OpRedo:
+		return icon.Redo
+	case OpRestore:
+		return icon.Restore
+	case OpRename:
+		return icon.Rename
+	case OpSymlink:
+		return icon.Symlink
+	case OpHardlink:
+		return icon.Hardlink
+	case OpPermissions:
+		return icon.Permissions
 	default:
 		return icon.InOperation
 	}
@@ -48,6 +69,20 @@ func (op OperationType) GetVerb() string {
 		return "Extracting"
 	case OpCreate:
 		return "Creating"
+	case OpUndo:
+		return "Undoing"
+	case OpRedo:
+		return "Redoing"
+	case OpRestore:
+		return "Restoring"
+	case OpRename:
+		return "Renaming"
+	case OpSymlink:
+		return "Symlinking"
+	case OpHardlink:
+		return "Hardlinking"
+	case OpPermissions:
+		return "Changing"
 	default:
 		return "Processing"
 	}
@@ -68,6 +103,20 @@ func (op OperationType) GetPastVerb() string {
 		return "Extracted"
 	case OpCreate:
 		return "Created"
+	case OpUndo:
+		return "Undid"
+	case OpRedo:
+		return "Redid"
+	case OpRestore:
+		return "Restored"
+	case OpRename:
+		return "Renamed"
+	case OpSymlink:
+		return "Symlinked"
+	case OpHardlink:
+		return "Hardlinked"
+	case OpPermissions:
+		return "Changed"
 	default:
 		return "Processed"
 	}
@@ -1,6 +1,7 @@
 package processbar
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -20,13 +21,18 @@ type Process struct {
 	// TODO : We always want ErrorMsg to be set when State is
 	// moved to Cancelled or Failed. To ensure it, we need to only allow state
 	// change via helper functions and ask for the  errorMsg
-	ErrorMsg  string
+	ErrorMsg string
+	// Short note on how the items were handled, shown once the process is over
+	Summary   string
 	Operation OperationType
 	Progress  progress.Model
 	State     ProcessState
 	Total     int
 	Done      int
 	DoneTime  time.Time
+
+	// Shared by all the copies of this process
+	control *Control
 }
 
 type FileListProcessor func(items []string) (Process, []string)
@@ -48,7 +54,24 @@ func NewProcess(id string, currentFile string, operation OperationType, total in
 		State:       InOperation,
 		Total:       total,
 		Done:        0,
+		control:     NewControl(),
+	}
+}
+
+// Context to be passed to the loops doing the work of the process. It is
+// cancelled, or paused, via the actions on the process bar
+func (p *Process) Context() context.Context {
+	if p.control == nil {
+		return context.Background()
 	}
+	return p.control
+}
+
+// MarkCancelled moves the process to the Cancelled state after the
+// user cancelled it, recording how much of the work was finished
+func (p *Process) MarkCancelled() {
+	p.State = Cancelled
+	p.ErrorMsg = fmt.Sprintf("%d/%d done", p.Done, p.Total)
 }
 
 type ProcessState int
@@ -79,7 +102,11 @@ func (p ProcessState) Icon() string {
 
 // GetDisplayName returns the appropriate display name for the process
 func (p *Process) GetDisplayName() string {
-	return p.Operation.GetIcon() + icon.Space + p.displayNameWithoutIcon()
+	name := p.Operation.GetIcon() + icon.Space + p.displayNameWithoutIcon()
+	if p.State != InOperation && p.Summary != "" {
+		name += " (" + p.Summary + ")"
+	}
+	return name
 }
 
 func (p *Process) displayNameWithoutIcon() string {
@@ -91,6 +118,9 @@ func (p *Process) displayNameWithoutIcon() string {
 	}
 
 	if p.State == InOperation {
+		if p.control.IsPaused() {
+			return "Paused : " + p.Operation.GetVerb() + " " + p.CurrentFile
+		}
 		return p.Operation.GetVerb() + " " + p.CurrentFile
 	}
 
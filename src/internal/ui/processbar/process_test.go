@@ -86,6 +86,38 @@ func TestGetDisplayName(t *testing.T) {
 			},
 			expected: icon.Delete + icon.Space + "Deleted file.txt",
 		},
+		{
+			name: "Summary after completion",
+			process: Process{
+				CurrentFile: "file.txt",
+				Summary:     "1 overwritten, 2 skipped",
+				Operation:   OpCopy,
+				Total:       5,
+				State:       Successful,
+			},
+			expected: icon.Copy + icon.Space + "Copied 5 files (1 overwritten, 2 skipped)",
+		},
+		{
+			name: "Summary hidden during operation",
+			process: Process{
+				CurrentFile: "file.txt",
+				Summary:     "1 overwritten",
+				Operation:   OpCopy,
+				Total:       5,
+				State:       InOperation,
+			},
+			expected: icon.Copy + icon.Space + "Copying file.txt",
+		},
+		{
+			name:     "Paused during operation",
+			process:  pausedProcess(),
+			expected: icon.Copy + icon.Space + "Paused : Copying file.txt",
+		},
+		{
+			name:     "Cancelled by user",
+			process:  userCancelledProcess(),
+			expected: icon.Copy + icon.Space + "Copying cancelled : 3/10 done",
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,3 +126,16 @@ func TestGetDisplayName(t *testing.T) {
 		})
 	}
 }
+
+func pausedProcess() Process {
+	p := NewProcess("1", "file.txt", OpCopy, 10)
+	p.control.Pause()
+	return p
+}
+
+func userCancelledProcess() Process {
+	p := NewProcess("1", "file.txt", OpCopy, 10)
+	p.Done = 3
+	p.MarkCancelled()
+	return p
+}
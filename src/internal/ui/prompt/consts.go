@@ -10,6 +10,11 @@ const (
 	OpenCommand  = "open"
 	SplitCommand = "split"
 	CdCommand    = "cd"
+	TrashCommand = "trash"
+
+	SessionCommand     = "session"
+	sessionSaveCommand = "save"
+	sessionLoadCommand = "load"
 
 	// We could later make this configurable. But, not needed now.
 	spfPromptChar   = ">"
@@ -24,6 +29,8 @@ const (
 	// Error message string
 	tokenizationError    = "Failed during tokenization"
 	splitCommandArgError = "split command should not be given arguments"
+	trashCommandArgError = "trash command should not be given arguments"
+	sessionCommandError  = "session command should be 'session save <NAME>' or 'session load <NAME>'"
 
 	// Timeout for command executed for shell substitution
 	shellSubTimeout        = 1000 * time.Millisecond
@@ -76,5 +83,15 @@ func defaultCommandSlice() []promptCommand {
 			usage:       CdCommand + " <PATH>",
 			description: "Change directory of current panel",
 		},
+		{
+			command:     TrashCommand,
+			usage:       TrashCommand,
+			description: "Browse the trash to restore or delete items",
+		},
+		{
+			command:     SessionCommand,
+			usage:       SessionCommand + " save|load <NAME>",
+			description: "Save the panels under a name, or open the panels saved under it",
+		},
 	}
 }
@@ -299,6 +299,8 @@ func TestModel_Render(t *testing.T) {
 			"│ 'open <PATH>' - Open a new panel at a│\n" +
 			"│ 'split' - Open a new panel at the cur│\n" +
 			"│ 'cd <PATH>' - Change directory of cur│\n" +
+			"│ 'trash' - Browse the trash to restore│\n" +
+			"│ 'session save|load <NAME>' - Save the│\n" +
 			"╰──────────────────────────────────────╯"
 		assert.Equal(t, exp, res)
 	})
@@ -363,6 +365,8 @@ func TestModel_Render(t *testing.T) {
 	var openCmdSuggestion string
 	var splitCmdSuggestion string
 	var cdCmdSuggestion string
+	var trashCmdSuggestion string
+	var sessionCmdSuggestion string
 	for _, cmd := range defaultCommandSlice() {
 		curSuggestion := "'" + cmd.usage + "' - " + cmd.description
 
@@ -373,6 +377,10 @@ func TestModel_Render(t *testing.T) {
 			splitCmdSuggestion = curSuggestion
 		case CdCommand:
 			cdCmdSuggestion = curSuggestion
+		case TrashCommand:
+			trashCmdSuggestion = curSuggestion
+		case SessionCommand:
+			sessionCmdSuggestion = curSuggestion
 		default:
 			assert.Fail(t, "Unknow command")
 		}
@@ -391,6 +399,15 @@ func TestModel_Render(t *testing.T) {
 				openCmdSuggestion,
 				splitCmdSuggestion,
 				cdCmdSuggestion,
+				trashCmdSuggestion,
+				sessionCmdSuggestion,
+			},
+		},
+		{
+			name:      "Command with subcommand",
+			textInput: "session load work",
+			expectedSuggestions: []string{
+				sessionCmdSuggestion,
 			},
 		},
 		{
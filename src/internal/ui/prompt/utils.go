@@ -54,6 +54,15 @@ func getPromptAction(shellMode bool, value string, cwdLocation string) (common.M
 		return common.OpenPanelAction{
 			Location: promptArgs[1],
 		}, nil
+	case "trash":
+		if len(promptArgs) != 1 {
+			return noAction, invalidCmdError{
+				uiMsg: trashCommandArgError,
+			}
+		}
+		return common.OpenTrashBinAction{}, nil
+	case "session":
+		return getSessionAction(promptArgs)
 
 	default:
 		return noAction, invalidCmdError{
@@ -62,6 +71,24 @@ func getPromptAction(shellMode bool, value string, cwdLocation string) (common.M
 	}
 }
 
+func getSessionAction(promptArgs []string) (common.ModelAction, error) {
+	if len(promptArgs) != 3 { //nolint:mnd // "session", the subcommand and the name
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: sessionCommandError,
+		}
+	}
+	switch promptArgs[1] {
+	case sessionSaveCommand:
+		return common.SaveSessionAction{Name: promptArgs[2]}, nil
+	case sessionLoadCommand:
+		return common.LoadSessionAction{Name: promptArgs[2]}, nil
+	default:
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: sessionCommandError,
+		}
+	}
+}
+
 // Only allocates memory proportional to first token's size
 // Only works for space right now. Does not splits command based on
 // \n or \t , etc
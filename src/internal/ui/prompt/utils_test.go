@@ -55,6 +55,14 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    true,
 			expectedErrMsg: splitCommandArgError,
 		},
+		{
+			name:           "Trash with extra arguments",
+			text:           TrashCommand + " xyz",
+			shellMode:      false,
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: trashCommandArgError,
+		},
 		{
 			name:           "cd with 0 arguments",
 			text:           CdCommand,
@@ -79,6 +87,14 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    false,
 			expectedErrMsg: "",
 		},
+		{
+			name:           "Correct trash command",
+			text:           TrashCommand,
+			shellMode:      false,
+			expectecAction: common.OpenTrashBinAction{},
+			expectedErr:    false,
+			expectedErrMsg: "",
+		},
 		{
 			name:           "Correct cd command",
 			text:           CdCommand + " /abc",
@@ -103,6 +119,38 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    true,
 			expectedErrMsg: "open command needs exactly one argument, received 2",
 		},
+		{
+			name:           "Correct session save command",
+			text:           SessionCommand + " save work",
+			shellMode:      false,
+			expectecAction: common.SaveSessionAction{Name: "work"},
+			expectedErr:    false,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "Correct session load command",
+			text:           SessionCommand + " load 'my work'",
+			shellMode:      false,
+			expectecAction: common.LoadSessionAction{Name: "my work"},
+			expectedErr:    false,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "session without a name",
+			text:           SessionCommand + " save",
+			shellMode:      false,
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: sessionCommandError,
+		},
+		{
+			name:           "session with an unknown subcommand",
+			text:           SessionCommand + " delete work",
+			shellMode:      false,
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: sessionCommandError,
+		},
 	}
 
 	for _, tt := range testdata {
@@ -0,0 +1,73 @@
+package sortmodel
+
+import (
+	"log/slog"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// DefaultOptions returns the sort of the directories without one chosen
+func DefaultOptions() Options {
+	return Options{
+		Kind:        SortKind(common.Config.DefaultSortType),
+		Reversed:    common.Config.SortOrderReversed,
+		Directories: DefaultDirectoriesOrder(),
+	}
+}
+
+// DefaultDirectoriesOrder returns the order of the directories of the panels
+// without one chosen
+func DefaultDirectoriesOrder() DirectoriesOrder {
+	if !common.Config.SortDirectoriesFirst {
+		return DirectoriesMixed
+	}
+	return DirectoriesByKind
+}
+
+// Memory keeps the sort chosen for each directory, persisted as JSON so the
+// directories reopen with it
+type Memory struct {
+	filePath string
+	dirs     map[string]Options
+}
+
+// NewMemory loads the sorts stored at filePath. An empty filePath keeps them
+// in memory only
+func NewMemory(filePath string) *Memory {
+	m := &Memory{filePath: filePath, dirs: make(map[string]Options)}
+	if filePath == "" {
+		return m
+	}
+	if err := utils.LoadJSONFile(filePath, &m.dirs); err != nil {
+		slog.Error("Error loading sort memory", "error", err)
+	}
+	return m
+}
+
+// Get returns the sort chosen for dir, if any
+func (m *Memory) Get(dir string) (Options, bool) {
+	opts, ok := m.dirs[dir]
+	if !ok || opts.Kind < 0 || int(opts.Kind) >= len(SortOptionsStr) {
+		return Options{}, false
+	}
+	return opts, true
+}
+
+// Set remembers the sort chosen for dir. The default sort is not stored
+func (m *Memory) Set(dir string, opts Options) {
+	if opts == DefaultOptions() {
+		if _, ok := m.dirs[dir]; !ok {
+			return
+		}
+		delete(m.dirs, dir)
+	} else {
+		m.dirs[dir] = opts
+	}
+	if m.filePath == "" {
+		return
+	}
+	if err := utils.SaveJSONFile(m.filePath, m.dirs); err != nil {
+		slog.Error("Error saving sort memory", "error", err)
+	}
+}
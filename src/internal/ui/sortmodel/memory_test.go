@@ -0,0 +1,32 @@
+package sortmodel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "sort.json")
+	memory := NewMemory(filePath)
+	_, ok := memory.Get("/dir")
+	assert.False(t, ok)
+
+	opts := Options{Kind: SortByVersion, Reversed: true, Directories: DirectoriesMixed}
+	memory.Set("/dir", opts)
+	memory.Set("/default", DefaultOptions())
+
+	memory = NewMemory(filePath)
+	got, ok := memory.Get("/dir")
+	require.True(t, ok, "persisted")
+	assert.Equal(t, opts, got)
+	_, ok = memory.Get("/default")
+	assert.False(t, ok, "the default sort is not stored")
+
+	memory.Set("/dir", DefaultOptions())
+	memory = NewMemory(filePath)
+	_, ok = memory.Get("/dir")
+	assert.False(t, ok, "forgotten when set back to the default")
+}
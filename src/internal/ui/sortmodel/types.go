@@ -9,14 +9,52 @@ const (
 	SortByDate
 	SortByType
 	SortByNatural
+	// The whole extension, like tar.gz, where SortByType only uses the last one
+	SortByExtension
+	SortByBirthTime
+	SortByAccessTime
+	// Version numbers in the names, like file-1.9 before file-1.10, and
+	// file-1.0~rc1 before file-1.0
+	SortByVersion
 )
 
 var SortOptionsStr = []string{ //nolint: gochecknoglobals // Effectively const
-	"Name", "Size", "Date Modified", "Type", "Natural",
+	"Name", "Size", "Date Modified", "Type", "Natural", "Extension", "Date Created", "Date Accessed", "Version",
 }
 
 var SortOptionsShortStr = []string{ //nolint: gochecknoglobals // Effectively const
-	"Name", "Size", "Date", "Type", "Natural",
+	"Name", "Size", "Date", "Type", "Natural", "Ext", "Created", "Accessed", "Version",
+}
+
+// DirectoriesOrder tells whether the directories are listed before the files
+type DirectoriesOrder int
+
+const (
+	// The directories come first, except when sorted by date where they are
+	// mixed with the files, as superfile always did
+	DirectoriesByKind DirectoriesOrder = iota
+	DirectoriesFirst
+	DirectoriesMixed
+)
+
+// Options is the sort of a file panel
+type Options struct {
+	Kind        SortKind         `json:"kind"`
+	Reversed    bool             `json:"reversed,omitempty"`
+	Directories DirectoriesOrder `json:"directories,omitempty"`
+}
+
+// ListDirectoriesFirst tells whether the directories are listed before the
+// files
+func (o Options) ListDirectoriesFirst() bool {
+	switch o.Directories {
+	case DirectoriesFirst:
+		return true
+	case DirectoriesMixed:
+		return false
+	case DirectoriesByKind:
+	}
+	return o.Kind != SortByDate
 }
 
 // Sort options
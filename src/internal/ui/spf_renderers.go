@@ -98,6 +98,20 @@ func HelpMenuRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
 	return r
 }
 
+// Unlike the prompt, the trash bin keeps its height, so that it doesn't
+// jump around while items are restored or purged
+func TrashBinRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return HelpMenuRenderer(totalHeight, totalWidth)
+}
+
+func BulkRenameRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return HelpMenuRenderer(totalHeight, totalWidth)
+}
+
+func ContentSearchRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return HelpMenuRenderer(totalHeight, totalWidth)
+}
+
 func DefaultFooterRenderer(totalHeight int, totalWidth int, focused bool, name string) *rendering.Renderer {
 	cfg := rendering.DefaultRendererConfig(totalHeight, totalWidth)
 
@@ -0,0 +1,17 @@
+package trashbin
+
+const (
+	trashBinHeadlineText = "Trash"
+
+	TrashBinMinWidth  = 30
+	TrashBinMinHeight = 8
+
+	// renderOverhead is the number of lines that are not trash items
+	// (borders + section divider + key hints)
+	renderOverhead = 5
+
+	deletionDateLayout = "2006-01-02 15:04"
+	// Width of a line that is not the original path
+	// (padding + selection mark + spacing + date + spacing)
+	itemLineOverhead = 1 + 3 + 1 + len(deletionDateLayout) + 2
+)
@@ -0,0 +1,158 @@
+package trashbin
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/trash"
+)
+
+func New(maxHeight int, width int) Model {
+	m := Model{
+		headline: icon.Trash + icon.Space + trashBinHeadlineText,
+		selected: make(map[string]bool),
+	}
+	m.SetMaxHeight(maxHeight)
+	m.SetWidth(width)
+	return m
+}
+
+func KeyRestore() []string {
+	return common.Hotkeys.ConfirmTyping
+}
+
+func KeyPurge() []string {
+	return common.Hotkeys.PermanentlyDeleteItems
+}
+
+func KeyToggleSelect() []string {
+	return common.Hotkeys.TrashBinToggleSelect
+}
+
+func KeySelectAll() []string {
+	return common.Hotkeys.FilePanelSelectAllItem
+}
+
+func KeyEmptyTrash() []string {
+	return common.Hotkeys.TrashBinEmpty
+}
+
+func KeyClose() []string {
+	return slices.Concat(common.Hotkeys.Quit, common.Hotkeys.CancelTyping)
+}
+
+// Open the modal. The caller is expected to load the items via SetItems
+func (m *Model) Open() {
+	m.open = true
+	m.loading = true
+	m.listErr = nil
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.loading = false
+	m.listErr = nil
+	m.items = nil
+	m.selected = make(map[string]bool)
+	m.cursor = 0
+	m.renderIndex = 0
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// SetItems updates the listing. The selection of items that are still in the
+// trash is kept, so that a reload after an operation doesn't lose it
+func (m *Model) SetItems(items []trash.Item, err error) {
+	m.loading = false
+	m.listErr = err
+	m.items = items
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[item.TrashedPath] = true
+	}
+	for path := range m.selected {
+		if !present[path] {
+			delete(m.selected, path)
+		}
+	}
+	m.cursor = min(m.cursor, max(len(items)-1, 0))
+	m.updateRenderIndex()
+}
+
+func (m *Model) GetItems() []trash.Item {
+	return m.items
+}
+
+// GetTargetItems returns the selected items, or the item under the cursor
+// if nothing is selected
+func (m *Model) GetTargetItems() []trash.Item {
+	if len(m.items) == 0 {
+		return nil
+	}
+	var targets []trash.Item
+	for _, item := range m.items {
+		if m.selected[item.TrashedPath] {
+			targets = append(targets, item)
+		}
+	}
+	if len(targets) == 0 {
+		targets = append(targets, m.items[m.cursor])
+	}
+	return targets
+}
+
+func (m *Model) ToggleSelect() {
+	if len(m.items) == 0 {
+		return
+	}
+	path := m.items[m.cursor].TrashedPath
+	if m.selected[path] {
+		delete(m.selected, path)
+	} else {
+		m.selected[path] = true
+	}
+}
+
+// SelectAll selects all items, or clears the selection if all are selected
+func (m *Model) SelectAll() {
+	if len(m.selected) == len(m.items) {
+		m.selected = make(map[string]bool)
+		return
+	}
+	for _, item := range m.items {
+		m.selected[item.TrashedPath] = true
+	}
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetMaxHeight() int {
+	return m.maxHeight
+}
+
+func (m *Model) SetWidth(width int) {
+	if width < TrashBinMinWidth {
+		slog.Warn("Trash bin initialized with too less width", "width", width)
+		width = TrashBinMinWidth
+	}
+	m.width = width
+}
+
+func (m *Model) SetMaxHeight(maxHeight int) {
+	if maxHeight < TrashBinMinHeight {
+		slog.Warn("Trash bin initialized with too less maxHeight", "maxHeight", maxHeight)
+		maxHeight = TrashBinMinHeight
+	}
+	m.maxHeight = maxHeight
+	m.updateRenderIndex()
+}
+
+func (m *Model) visibleCount() int {
+	return m.maxHeight - renderOverhead
+}
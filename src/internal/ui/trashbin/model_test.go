@@ -0,0 +1,139 @@
+package trashbin
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/trash"
+)
+
+func testItems(cnt int) []trash.Item {
+	items := make([]trash.Item, cnt)
+	for i := range items {
+		items[i] = trash.Item{
+			OriginalPath: "/home/user/file" + strconv.Itoa(i),
+			TrashedPath:  "/home/user/.local/share/Trash/files/file" + strconv.Itoa(i),
+			DeletionDate: time.Date(2024, 1, 1, 0, i, 0, 0, time.UTC),
+		}
+	}
+	return items
+}
+
+func TestNavigation(t *testing.T) {
+	testdata := []struct {
+		name           string
+		itemCnt        int
+		startCursor    int
+		navigateUp     bool
+		expectedCursor int
+	}{
+		{"up at the top wraps to the bottom", 5, 0, true, 4},
+		{"up decrements", 5, 3, true, 2},
+		{"down increments", 5, 0, false, 1},
+		{"down at the bottom wraps to the top", 5, 4, false, 0},
+		{"up without items keeps the cursor", 0, 0, true, 0},
+		{"down without items keeps the cursor", 0, 0, false, 0},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(20, 80)
+			m.Open()
+			m.SetItems(testItems(tt.itemCnt), nil)
+			m.cursor = tt.startCursor
+			if tt.navigateUp {
+				m.ListUp()
+			} else {
+				m.ListDown()
+			}
+			assert.Equal(t, tt.expectedCursor, m.cursor)
+		})
+	}
+}
+
+func TestRenderIndexFollowsCursor(t *testing.T) {
+	m := New(TrashBinMinHeight, 80)
+	m.Open()
+	m.SetItems(testItems(10), nil)
+	visible := m.visibleCount()
+
+	for range visible {
+		m.ListDown()
+	}
+	assert.Equal(t, visible, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+
+	m.ListUp()
+	m.ListUp()
+	assert.Equal(t, visible-2, m.cursor)
+	assert.Equal(t, 1, m.renderIndex)
+
+	// Wrap around to the top
+	m.cursor = 9
+	m.ListDown()
+	assert.Equal(t, 0, m.renderIndex)
+}
+
+func TestTargetItems(t *testing.T) {
+	m := New(20, 80)
+	m.Open()
+	assert.Empty(t, m.GetTargetItems())
+
+	items := testItems(4)
+	m.SetItems(items, nil)
+	m.ListDown()
+	assert.Equal(t, []trash.Item{items[1]}, m.GetTargetItems(), "cursor item without a selection")
+
+	m.ToggleSelect()
+	m.ListDown()
+	m.ListDown()
+	m.ToggleSelect()
+	assert.Equal(t, []trash.Item{items[1], items[3]}, m.GetTargetItems())
+
+	m.ToggleSelect()
+	assert.Equal(t, []trash.Item{items[1]}, m.GetTargetItems())
+
+	m.SelectAll()
+	assert.Equal(t, items, m.GetTargetItems())
+	m.SelectAll()
+	assert.Empty(t, m.selected, "select all when all are selected clears the selection")
+}
+
+func TestSetItemsKeepsSelection(t *testing.T) {
+	m := New(20, 80)
+	m.Open()
+	items := testItems(3)
+	m.SetItems(items, nil)
+	m.SelectAll()
+	m.cursor = 2
+
+	// items[0] was restored
+	m.SetItems(items[1:], nil)
+	assert.Equal(t, items[1:], m.GetTargetItems())
+	assert.Equal(t, 1, m.cursor, "cursor is clamped to the new items")
+
+	m.Close()
+	assert.False(t, m.IsOpen())
+	assert.Empty(t, m.GetItems())
+	assert.Empty(t, m.selected)
+}
+
+func TestRender(t *testing.T) {
+	m := New(TrashBinMinHeight, 60)
+	m.Open()
+	assert.Contains(t, m.Render(), "Loading...")
+
+	m.SetItems(nil, nil)
+	assert.Contains(t, m.Render(), "Trash is empty")
+
+	m.SetItems(testItems(2), nil)
+	m.ToggleSelect()
+	res := m.Render()
+	assert.Contains(t, res, "[x] 2024-01-01 00:00  /home/user/file0")
+	assert.Contains(t, res, "[ ] 2024-01-01 00:01  /home/user/file1")
+	assert.Contains(t, res, "1/2")
+	require.Contains(t, res, "Restore")
+}
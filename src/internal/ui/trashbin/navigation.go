@@ -0,0 +1,37 @@
+package trashbin
+
+func (m *Model) ListUp() {
+	if len(m.items) == 0 {
+		return
+	}
+	if m.cursor > 0 {
+		m.cursor--
+	} else {
+		m.cursor = len(m.items) - 1 // Wrap to bottom
+	}
+	m.updateRenderIndex()
+}
+
+func (m *Model) ListDown() {
+	if len(m.items) == 0 {
+		return
+	}
+	if m.cursor < len(m.items)-1 {
+		m.cursor++
+	} else {
+		m.cursor = 0 // Wrap to top
+	}
+	m.updateRenderIndex()
+}
+
+// Keep the cursor within the visible range
+func (m *Model) updateRenderIndex() {
+	visible := m.visibleCount()
+	if m.cursor < m.renderIndex {
+		m.renderIndex = m.cursor
+	}
+	if m.cursor >= m.renderIndex+visible {
+		m.renderIndex = m.cursor - visible + 1
+	}
+	m.renderIndex = max(0, min(m.renderIndex, len(m.items)-visible))
+}
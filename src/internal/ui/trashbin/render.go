@@ -0,0 +1,72 @@
+package trashbin
+
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/internal/ui/rendering"
+)
+
+func (m *Model) Render() string {
+	r := ui.TrashBinRenderer(m.maxHeight, m.width)
+	r.SetBorderTitle(m.headline)
+
+	lineCnt := 1
+	switch {
+	case m.loading:
+		r.AddLines(" Loading...")
+	case m.listErr != nil:
+		r.AddLines(" Cannot list the trash: " + m.listErr.Error())
+	case len(m.items) == 0:
+		r.AddLines(" Trash is empty")
+	default:
+		r.SetBorderInfoItems(fmt.Sprintf("%d/%d", m.cursor+1, len(m.items)))
+		lineCnt = m.renderItems(r)
+	}
+	// Keep the key hints at the bottom of the modal
+	for range m.visibleCount() - lineCnt {
+		r.AddLines("")
+	}
+
+	r.AddSection()
+	r.AddLines(
+		keyHint(KeyRestore(), "Restore")+keyHint(KeyPurge(), "Purge")+keyHint(KeyEmptyTrash(), "Empty trash"),
+		keyHint(KeyToggleSelect(), "Select")+keyHint(KeySelectAll(), "Select all")+keyHint(KeyClose(), "Close"),
+	)
+	return r.Render()
+}
+
+// renderItems adds the visible items and returns how many were added
+func (m *Model) renderItems(r *rendering.Renderer) int {
+	endIndex := min(m.renderIndex+m.visibleCount(), len(m.items))
+	// Available width: modal width - borders(2) - rest of the line
+	availablePathWidth := m.width - 2 - itemLineOverhead
+	for i := m.renderIndex; i < endIndex; i++ {
+		item := m.items[i]
+		mark := "[ ]"
+		if m.selected[item.TrashedPath] {
+			mark = "[x]"
+		}
+		date := item.DeletionDate.Format(deletionDateLayout)
+		if item.DeletionDate.IsZero() {
+			date = fmt.Sprintf("%-*s", len(deletionDateLayout), "unknown")
+		}
+		path := common.TruncateTextBeginning(item.OriginalPath, availablePathWidth, "...")
+
+		line := fmt.Sprintf(" %s %s  %s", mark, date, path)
+		if i == m.cursor {
+			line = common.ModalCursorStyle.Render(line)
+		}
+		r.AddLines(line)
+	}
+	return endIndex - m.renderIndex
+}
+
+func keyHint(keys []string, text string) string {
+	key := ""
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	return " (" + key + ") " + text
+}
@@ -0,0 +1,25 @@
+package trashbin
+
+import (
+	"github.com/yorukot/superfile/src/internal/trash"
+)
+
+// Model of the trash browser modal. It only holds the listing, the trash
+// operations themselves are run by the main model
+type Model struct {
+	headline string
+
+	// State
+	open    bool
+	loading bool
+	// Error while listing the trash, shown instead of the items
+	listErr     error
+	items       []trash.Item
+	selected    map[string]bool // Keyed by TrashedPath
+	cursor      int
+	renderIndex int
+
+	// Dimensions
+	width     int
+	maxHeight int
+}
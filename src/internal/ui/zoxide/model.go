@@ -11,19 +11,21 @@ import (
 
 	"github.com/yorukot/superfile/src/config/icon"
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/frecency"
 )
 
-func DefaultModel(maxHeight int, width int, zClient *zoxidelib.Client) Model {
-	return GenerateModel(zClient, maxHeight, width)
+func DefaultModel(maxHeight int, width int, zClient *zoxidelib.Client, frecencyDB *frecency.DB) Model {
+	return GenerateModel(zClient, frecencyDB, maxHeight, width)
 }
 
-func GenerateModel(zClient *zoxidelib.Client, maxHeight int, width int) Model {
+func GenerateModel(zClient *zoxidelib.Client, frecencyDB *frecency.DB, maxHeight int, width int) Model {
 	m := Model{
-		headline:  icon.Search + icon.Space + zoxideHeadlineText,
-		open:      false,
-		textInput: common.GeneratePromptTextInput(),
-		zClient:   zClient,
-		results:   []zoxidelib.Result{},
+		headline:   icon.Search + icon.Space + zoxideHeadlineText,
+		open:       false,
+		textInput:  common.GeneratePromptTextInput(),
+		zClient:    zClient,
+		frecencyDB: frecencyDB,
+		results:    []zoxidelib.Result{},
 	}
 	m.SetMaxHeight(maxHeight)
 	m.SetWidth(width)
@@ -46,8 +48,8 @@ func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
-		// If zoxide is not available, only allow confirm/cancel to close modal
-		if m.zClient == nil {
+		// If no database is available, only allow confirm/cancel to close modal
+		if !m.available() {
 			switch {
 			case slices.Contains(common.Hotkeys.ConfirmTyping, msg.String()),
 				slices.Contains(common.Hotkeys.CancelTyping, msg.String()),
@@ -79,8 +81,8 @@ func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
 		}
 	default:
 		// Non keypress updates like Cursor Blink
-		// Only update text input if zoxide is available
-		if m.zClient != nil {
+		// Only update text input if a database is available
+		if m.available() {
 			m.textInput, cmd = m.textInput.Update(msg)
 		}
 	}
@@ -106,8 +108,18 @@ func (m *Model) handleNormalKeyInput(msg tea.KeyPressMsg) tea.Cmd {
 	return tea.Batch(cmd, m.GetQueryCmd(m.textInput.Value()))
 }
 
+// useZoxide reports whether the queries go to zoxide, rather than to the
+// frecency database of superfile
+func (m *Model) useZoxide() bool {
+	return m.zClient != nil && common.Config.ZoxideSupport
+}
+
+func (m *Model) available() bool {
+	return m.useZoxide() || m.frecencyDB != nil
+}
+
 func (m *Model) GetQueryCmd(query string) tea.Cmd {
-	if m.zClient == nil || !common.Config.ZoxideSupport {
+	if !m.available() {
 		return nil
 	}
 
@@ -116,9 +128,16 @@ func (m *Model) GetQueryCmd(query string) tea.Cmd {
 
 	slog.Debug("Submitting zoxide query request", "query", query, "id", reqID)
 
+	if !m.useZoxide() {
+		db := m.frecencyDB
+		return func() tea.Msg {
+			return NewUpdateMsg(query, frecencyResults(db.Query(strings.Fields(query)...)), reqID)
+		}
+	}
+	zClient := m.zClient
 	return func() tea.Msg {
 		queryFields := strings.Fields(query)
-		results, err := m.zClient.QueryAll(queryFields...)
+		results, err := zClient.QueryAll(queryFields...)
 		if err != nil {
 			slog.Debug("Zoxide query failed", "query", query, "error", err, "id", reqID)
 			return NewUpdateMsg(query, []zoxidelib.Result{}, reqID)
@@ -127,6 +146,14 @@ func (m *Model) GetQueryCmd(query string) tea.Cmd {
 	}
 }
 
+func frecencyResults(results []frecency.Result) []zoxidelib.Result {
+	out := make([]zoxidelib.Result, 0, len(results))
+	for _, r := range results {
+		out = append(out, zoxidelib.Result{Path: r.Path, Score: r.Score})
+	}
+	return out
+}
+
 // Apply updates the zoxide modal with query results
 func (msg UpdateMsg) Apply(m *Model) tea.Cmd {
 	// Ignore stale results - only apply if query matches current input
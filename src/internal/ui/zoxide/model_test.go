@@ -1,6 +1,7 @@
 package zoxide
 
 import (
+	"path/filepath"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
@@ -11,6 +12,7 @@ import (
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/frecency"
 )
 
 func TestMain(m *testing.M) {
@@ -135,3 +137,27 @@ func TestApplyWithStaleQuery(t *testing.T) {
 	assert.Equal(t, 1, m.cursor, "cursor should remain unchanged")
 	assert.Equal(t, 1, m.renderIndex, "renderIndex should remain unchanged")
 }
+
+func TestQueryFrecencyDB(t *testing.T) {
+	dir := t.TempDir()
+	db := frecency.New("")
+	db.Add(dir)
+	originalZoxideSupport := common.Config.ZoxideSupport
+	t.Cleanup(func() {
+		common.Config.ZoxideSupport = originalZoxideSupport
+	})
+
+	for _, zoxideSupport := range []bool{true, false} {
+		common.Config.ZoxideSupport = zoxideSupport
+		// Without zoxide, or with it disabled
+		m := GenerateModel(nil, db, 50, 80) //nolint:mnd // test dimensions
+		cmd := m.Open()
+		require.NotNil(t, cmd)
+		msg, ok := cmd().(UpdateMsg)
+		require.True(t, ok)
+		msg.Apply(&m)
+		require.Len(t, m.GetResults(), 1)
+		assert.Equal(t, dir, m.GetResults()[0].Path)
+		assert.Contains(t, m.Render(), filepath.Base(dir))
+	}
+}
@@ -17,7 +17,7 @@ func (m *Model) Render() string {
 	r := ui.ZoxideRenderer(m.maxHeight, m.width)
 	r.SetBorderTitle(m.headline)
 
-	if m.zClient == nil {
+	if !m.available() {
 		r.AddSection()
 		r.AddLines(" Zoxide not available (check zoxide_support in config)")
 		return r.Render()
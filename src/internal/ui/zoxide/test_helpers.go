@@ -10,7 +10,7 @@ import (
 )
 
 func setupTestModel() Model {
-	return GenerateModel(nil, 50, 80) //nolint:mnd // test dimensions
+	return GenerateModel(nil, nil, 50, 80) //nolint:mnd // test dimensions
 }
 
 func setupTestModelWithClient(t *testing.T) Model {
@@ -23,7 +23,7 @@ func setupTestModelWithClient(t *testing.T) Model {
 			t.Fatalf("zoxide initialization failed")
 		}
 	}
-	return GenerateModel(zClient, 50, 80) //nolint:mnd // test dimensions
+	return GenerateModel(zClient, nil, 50, 80) //nolint:mnd // test dimensions
 }
 
 func setupTestModelWithResults(resultCount int) Model {
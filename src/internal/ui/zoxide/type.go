@@ -3,6 +3,8 @@ package zoxide
 import (
 	"charm.land/bubbles/v2/textinput"
 	zoxidelib "github.com/lazysegtree/go-zoxide"
+
+	"github.com/yorukot/superfile/src/internal/frecency"
 )
 
 // No need to name it as ZoxideModel. It will me imported as zoxide.Model
@@ -11,6 +13,8 @@ type Model struct {
 	// Configuration
 	headline string
 	zClient  *zoxidelib.Client
+	// Queried instead of zoxide when it is not installed or disabled
+	frecencyDB *frecency.DB
 
 	// State
 	open        bool
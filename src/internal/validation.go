@@ -27,8 +27,9 @@ func (m *model) validateLayout() error { //nolint:gocognit // cumilation of vali
 		return errors.New("footer open but footerHeight is 0")
 	}
 
-	// PanelHeight + 2 lines (main border) + actual footer height
-	if m.fullHeight != (m.mainPanelHeight+common.BorderPadding)+utils.FullFooterHeight(m.footerHeight, m.toggleFooter) {
+	// PanelHeight + 2 lines (main border) + actual footer height + tab strip
+	if m.fullHeight != (m.mainPanelHeight+common.BorderPadding)+utils.FullFooterHeight(m.footerHeight, m.toggleFooter)+
+		m.tabStripHeight() {
 		return fmt.Errorf(
 			"invalid model layout, total height doesn't sum correctly, fullHeight : %v, mainPanelHeight : %v, footerHeight : %v",
 			m.fullHeight,
@@ -73,6 +74,14 @@ func (m *model) validateLayout() error { //nolint:gocognit // cumilation of vali
 		totalFileModelWidth += m.fileModel.ExpectedPreviewWidth
 	}
 
+	if parent := m.fileModel.GetParentColumn(); parent != nil {
+		totalFileModelWidth += parent.GetWidth()
+		if parent.GetHeight() != m.fileModel.Height {
+			return fmt.Errorf("parent column height mismatch: expected %v, got %v",
+				m.fileModel.Height, parent.GetHeight())
+		}
+	}
+
 	// Check each file panel has correct dimensions set
 	for i, panel := range m.fileModel.FilePanels {
 		totalFileModelWidth += panel.GetWidth()
@@ -287,11 +296,13 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 
 	strippedOut := ansi.Strip(mainRender)
 	lines := strings.Split(strippedOut, "\n")
+	// The tab strip takes the first lines when shown
+	mainStRow := m.tabStripHeight()
 	if common.Config.SidebarWidth != 0 {
 		sidebarPos := compPosition{
-			stRow:  0,
+			stRow:  mainStRow,
 			stCol:  0,
-			endRow: m.sidebarModel.GetHeight() - 1,
+			endRow: mainStRow + m.sidebarModel.GetHeight() - 1,
 			endCol: m.sidebarModel.GetWidth() - 1,
 		}
 		// Note: This wont work when any overlay model is open
@@ -306,9 +317,13 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 	}
 	for i := range m.fileModel.FilePanels {
 		panel := &m.fileModel.FilePanels[i]
+		if parent := m.fileModel.GetParentColumn(); parent != nil && i == m.fileModel.FocusedPanelIndex {
+			// Shown right before the focused panel
+			filePanelColStart += parent.GetWidth()
+		}
 		panelPos := compPosition{
-			stRow:  0,
-			endRow: m.mainPanelHeight + 1,
+			stRow:  mainStRow,
+			endRow: mainStRow + m.mainPanelHeight + 1,
 			stCol:  filePanelColStart,
 			endCol: filePanelColStart + panel.GetWidth() - 1,
 		}
@@ -321,8 +336,8 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 
 	if m.fileModel.FilePreview.IsOpen() {
 		previewPanelPos := compPosition{
-			stRow:  0,
-			endRow: m.mainPanelHeight + 1,
+			stRow:  mainStRow,
+			endRow: mainStRow + m.mainPanelHeight + 1,
 			stCol:  m.fullWidth - m.fileModel.ExpectedPreviewWidth,
 			endCol: m.fullWidth - 1,
 		}
@@ -337,7 +352,7 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 
 	if m.toggleFooter {
 		processBarPos := compPosition{
-			stRow:  m.mainPanelHeight + common.BorderPadding,
+			stRow:  mainStRow + m.mainPanelHeight + common.BorderPadding,
 			stCol:  0,
 			endRow: m.fullHeight - 1,
 			endCol: m.processBarModel.GetWidth() - 1,
@@ -346,7 +361,7 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 			return fmt.Errorf("process bar position validation failed: %w", err)
 		}
 		metadataPos := compPosition{
-			stRow:  m.mainPanelHeight + common.BorderPadding,
+			stRow:  mainStRow + m.mainPanelHeight + common.BorderPadding,
 			stCol:  m.processBarModel.GetWidth(),
 			endRow: m.fullHeight - 1,
 			endCol: m.processBarModel.GetWidth() + m.fileMetaData.GetWidth() - 1,
@@ -355,7 +370,7 @@ func (m *model) validateFinalRender() error { //nolint:gocognit // cumilation of
 			return fmt.Errorf("metadata bar position validation failed: %w", err)
 		}
 		clipboardPos := compPosition{
-			stRow:  m.mainPanelHeight + common.BorderPadding,
+			stRow:  mainStRow + m.mainPanelHeight + common.BorderPadding,
 			stCol:  m.processBarModel.GetWidth() + m.fileMetaData.GetWidth(),
 			endRow: m.fullHeight - 1,
 			endCol: m.fullWidth - 1,
@@ -425,6 +440,7 @@ type compPosition struct {
 
 func (m *model) IsOverlayModelOpen() bool {
 	return m.zoxideModal.IsOpen() || m.helpMenu.IsOpen() || m.promptModal.IsOpen() ||
-		m.sortModal.IsOpen() || m.firstUse || m.typingModal.open ||
-		m.notifyModel.IsOpen()
+		m.sortModal.IsOpen() || m.compressModal.IsOpen() || m.extractModal.IsOpen() || m.firstUse ||
+		m.typingModal.open || m.notifyModel.IsOpen() || m.trashBin.IsOpen() || m.bulkRenameModal.IsOpen() ||
+		m.contentSearchModal.IsOpen() || m.permissionModal.IsOpen()
 }
@@ -0,0 +1,32 @@
+package watcher
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Filesystems whose changes made by other machines are not reported by
+// inotify. FUSE is included for the likes of sshfs and rclone
+//
+//nolint:gochecknoglobals // Effectively const
+var remoteFilesystems = map[uint32]struct{}{
+	unix.NFS_SUPER_MAGIC:  {},
+	unix.SMB_SUPER_MAGIC:  {},
+	unix.SMB2_SUPER_MAGIC: {},
+	unix.CIFS_SUPER_MAGIC: {},
+	unix.CEPH_SUPER_MAGIC: {},
+	unix.CODA_SUPER_MAGIC: {},
+	unix.AFS_SUPER_MAGIC:  {},
+	unix.V9FS_MAGIC:       {},
+	unix.FUSE_SUPER_MAGIC: {},
+}
+
+// isRemote tells whether dir is on a network filesystem
+func isRemote(dir string) bool {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return false
+	}
+	// Type is signed, and 32 bits on some architectures
+	_, ok := remoteFilesystems[uint32(stat.Type)] //nolint:gosec // magic numbers are 32 bits
+	return ok
+}
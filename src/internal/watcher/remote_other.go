@@ -0,0 +1,8 @@
+//go:build !linux
+
+package watcher
+
+// isRemote tells whether dir is on a network filesystem. Only known on Linux
+func isRemote(_ string) bool {
+	return false
+}
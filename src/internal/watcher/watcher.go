@@ -0,0 +1,201 @@
+// Package watcher reports the changes made to the watched directories, so
+// that the panels and the preview showing them are refreshed as soon as they
+// change, instead of being re-read on a timer. A directory that cannot be
+// watched, for example on a network mount or once the limit of watches of
+// the system is reached, is reported as such and is still to be re-read on
+// a timer.
+package watcher
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Events are gathered for that long before being sent, so that a burst of
+// changes, like a copy of many files, only refreshes once
+const flushDelay = 100 * time.Millisecond
+
+// Changes are the paths changed since the last Changes. The paths are the
+// changed items, not the watched directories containing them
+type Changes struct {
+	Paths []string
+	// Events were lost. Anything watched may have changed
+	Overflow bool
+}
+
+// Watcher watches a set of directories, the items directly inside them
+// included. Use New(), the zero value is not usable. A nil *Watcher is valid,
+// and watches nothing
+type Watcher struct {
+	fs      *fsnotify.Watcher
+	changes chan Changes
+	done    chan struct{}
+	closed  sync.Once
+
+	mu sync.Mutex
+	// Directories currently watched
+	watched map[string]struct{}
+	// Directories that cannot be watched. Adding them is not retried until
+	// they are no longer wanted
+	failed map[string]struct{}
+}
+
+func New() (*Watcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		fs:      fs,
+		changes: make(chan Changes),
+		done:    make(chan struct{}),
+		watched: make(map[string]struct{}),
+		failed:  make(map[string]struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Changes returns the channel receiving the changes. It is closed by Close
+func (w *Watcher) Changes() <-chan Changes {
+	return w.changes
+}
+
+// Sync makes the watched directories be exactly dirs
+func (w *Watcher) Sync(dirs []string) {
+	if w == nil {
+		return
+	}
+	wanted := make(map[string]struct{}, len(dirs))
+	for _, dir := range dirs {
+		wanted[filepath.Clean(dir)] = struct{}{}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for dir := range w.watched {
+		if _, ok := wanted[dir]; !ok {
+			// Fails if the directory is gone, its watch is gone with it
+			_ = w.fs.Remove(dir)
+			delete(w.watched, dir)
+		}
+	}
+	for dir := range w.failed {
+		if _, ok := wanted[dir]; !ok {
+			delete(w.failed, dir)
+		}
+	}
+	for dir := range wanted {
+		_, watched := w.watched[dir]
+		_, failed := w.failed[dir]
+		if watched || failed {
+			continue
+		}
+		if err := w.add(dir); err != nil {
+			slog.Debug("Cannot watch directory, it will be polled", "dir", dir, "error", err)
+			w.failed[dir] = struct{}{}
+			continue
+		}
+		w.watched[dir] = struct{}{}
+	}
+}
+
+var errRemote = errors.New("changes on remote filesystems are not reported")
+
+func (w *Watcher) add(dir string) error {
+	if isRemote(dir) {
+		return errRemote
+	}
+	return w.fs.Add(dir)
+}
+
+// IsWatching tells whether the changes in dir are reported
+func (w *Watcher) IsWatching(dir string) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.watched[filepath.Clean(dir)]
+	return ok
+}
+
+// Close stops watching. Safe to call more than once
+func (w *Watcher) Close() {
+	if w == nil {
+		return
+	}
+	w.closed.Do(func() {
+		close(w.done)
+		if err := w.fs.Close(); err != nil {
+			slog.Error("Error while closing the filesystem watcher", "error", err)
+		}
+	})
+}
+
+// run gathers the events, and sends them as Changes
+func (w *Watcher) run() {
+	defer close(w.changes)
+	pending := make(map[string]struct{})
+	overflow := false
+	var flush <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			w.handleRemovedDir(event)
+			pending[event.Name] = struct{}{}
+			if flush == nil {
+				flush = time.After(flushDelay)
+			}
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			slog.Debug("Filesystem watcher error", "error", err)
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				overflow = true
+				if flush == nil {
+					flush = time.After(flushDelay)
+				}
+			}
+		case <-flush:
+			changes := Changes{Paths: make([]string, 0, len(pending)), Overflow: overflow}
+			for path := range pending {
+				changes.Paths = append(changes.Paths, path)
+			}
+			select {
+			case w.changes <- changes:
+			case <-w.done:
+				return
+			}
+			clear(pending)
+			overflow = false
+			flush = nil
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// handleRemovedDir forgets a watched directory that is removed or renamed.
+// Its watch is gone, and if it is created again, it has to be watched again
+func (w *Watcher) handleRemovedDir(event fsnotify.Event) {
+	if !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watched[event.Name]; ok {
+		_ = w.fs.Remove(event.Name)
+		delete(w.watched, event.Name)
+	}
+}
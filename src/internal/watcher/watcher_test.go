@@ -0,0 +1,98 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+const testTimeout = 2 * time.Second
+
+func newTestWatcher(t *testing.T) *Watcher {
+	t.Helper()
+	w, err := New()
+	require.NoError(t, err)
+	t.Cleanup(w.Close)
+	return w
+}
+
+func receive(t *testing.T, w *Watcher) Changes {
+	t.Helper()
+	select {
+	case changes, ok := <-w.Changes():
+		require.True(t, ok, "changes channel closed")
+		return changes
+	case <-time.After(testTimeout):
+		require.FailNow(t, "no changes reported")
+	}
+	return Changes{}
+}
+
+func TestWatcher(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	w := newTestWatcher(t)
+
+	w.Sync([]string{dir1, dir2, dir1})
+	assert.True(t, w.IsWatching(dir1))
+	assert.True(t, w.IsWatching(dir2))
+
+	// A burst of changes is reported at once
+	file1 := filepath.Join(dir1, "file1")
+	file2 := filepath.Join(dir1, "file2")
+	utils.SetupFiles(t, file1, file2)
+	changes := receive(t, w)
+	assert.ElementsMatch(t, []string{file1, file2}, changes.Paths)
+	assert.False(t, changes.Overflow)
+
+	w.Sync([]string{dir2})
+	assert.False(t, w.IsWatching(dir1))
+	utils.SetupFiles(t, filepath.Join(dir1, "ignored"))
+	file3 := filepath.Join(dir2, "file3")
+	utils.SetupFiles(t, file3)
+	assert.Equal(t, []string{file3}, receive(t, w).Paths)
+}
+
+func TestWatcherRemovedDir(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Removing a watched directory is not reported the same way on Windows")
+	}
+	dir := filepath.Join(t.TempDir(), "dir")
+	utils.SetupDirectories(t, dir)
+	w := newTestWatcher(t)
+	w.Sync([]string{dir})
+	require.True(t, w.IsWatching(dir))
+
+	require.NoError(t, os.Remove(dir))
+	assert.Contains(t, receive(t, w).Paths, dir)
+	assert.False(t, w.IsWatching(dir), "a removed directory is no longer watched")
+
+	// Not retried while it is wanted
+	w.Sync([]string{dir})
+	utils.SetupDirectories(t, dir)
+	w.Sync([]string{dir})
+	assert.False(t, w.IsWatching(dir))
+	w.Sync(nil)
+	w.Sync([]string{dir})
+	assert.True(t, w.IsWatching(dir))
+}
+
+func TestWatcherClose(t *testing.T) {
+	w := newTestWatcher(t)
+	w.Close()
+	w.Close()
+	_, ok := <-w.Changes()
+	assert.False(t, ok)
+
+	var nilWatcher *Watcher
+	nilWatcher.Sync([]string{t.TempDir()})
+	assert.False(t, nilWatcher.IsWatching("/"))
+	nilWatcher.Close()
+}
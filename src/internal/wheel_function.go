@@ -20,6 +20,9 @@ func wheelMainAction(msg string, m *model) {
 			action = func() { m.fileMetaData.ListUp() }
 		case nonePanelFocus:
 			action = func() { m.getFocusedFilePanel().ListUp() }
+			if m.fileModel.IsParentColumnFocused() {
+				action = func() { m.fileModel.ParentColumnListUp() }
+			}
 		}
 
 	case "wheeldown":
@@ -32,6 +35,9 @@ func wheelMainAction(msg string, m *model) {
 			action = func() { m.fileMetaData.ListDown() }
 		case nonePanelFocus:
 			action = func() { m.getFocusedFilePanel().ListDown() }
+			if m.fileModel.IsParentColumnFocused() {
+				action = func() { m.fileModel.ParentColumnListDown() }
+			}
 		}
 	default:
 		slog.Error("Unexpected type of mouse action in wheelMainAction", "msg", msg)
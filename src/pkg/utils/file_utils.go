@@ -264,14 +264,19 @@ func CreateFiles(files ...string) error {
 }
 
 func ReadFileContent(filepath string, maxLineLength int, previewLine int) (string, error) {
-	var resultBuilder strings.Builder
 	file, err := os.Open(filepath)
 	if err != nil {
-		return resultBuilder.String(), err
+		return "", err
 	}
 	defer file.Close()
+	return ReadContent(file, maxLineLength, previewLine)
+}
 
-	reader := transform.NewReader(file, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+// ReadContent is ReadFileContent for content that is not a file on disk,
+// like an entry of an archive
+func ReadContent(r io.Reader, maxLineLength int, previewLine int) (string, error) {
+	var resultBuilder strings.Builder
+	reader := transform.NewReader(r, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
 	scanner := bufio.NewScanner(reader)
 	lineCount := 0
 	for scanner.Scan() {
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// This file provides utilities for storing values as JSON in a file
+
+// LoadJSONFile reads the value stored at path, and initializes the file if it
+// does not exist. value is left as is while nothing was stored, as the file
+// is initialized with null, and if the file cannot be parsed
+func LoadJSONFile[T any](path string, value *T) error {
+	if err := InitJSONFile(path); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read json file %s: %w", path, err)
+	}
+	var loaded *T
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to parse json file %s: %w", path, err)
+	}
+	if loaded != nil {
+		*value = *loaded
+	}
+	return nil
+}
+
+// SaveJSONFile writes value as JSON to the file at path
+func SaveJSONFile[T any](path string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json file %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, ConfigFilePerm); err != nil {
+		return fmt.Errorf("failed to write json file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("Initialized when missing", func(t *testing.T) {
+		path := filepath.Join(tempDir, "missing.json")
+		value := map[string]int{}
+		require.NoError(t, LoadJSONFile(path, &value))
+		assert.NotNil(t, value, "kept as is with the null the file is initialized with")
+		assert.FileExists(t, path)
+	})
+
+	t.Run("Save and load", func(t *testing.T) {
+		path := filepath.Join(tempDir, "saved.json")
+		require.NoError(t, SaveJSONFile(path, map[string]int{"a": 1}))
+		value := map[string]int{}
+		require.NoError(t, LoadJSONFile(path, &value))
+		assert.Equal(t, map[string]int{"a": 1}, value)
+	})
+
+	t.Run("Invalid content", func(t *testing.T) {
+		path := filepath.Join(tempDir, "invalid.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+		value := map[string]int{"kept": 1}
+		require.Error(t, LoadJSONFile(path, &value))
+		assert.Equal(t, map[string]int{"kept": 1}, value)
+	})
+}